@@ -17,9 +17,15 @@ type API struct {
 	Router              *mux.Router
 	permissionsStore    PermissionsStore
 	bundler             PermissionsBundler
+	auth                authorisation.Middleware
+	signer              BundleSigner
+	entityResolver      EntityResolver
+	serviceTokens       ServiceTokenChecker
 	defaultLimit        int
 	defaultOffset       int
 	maximumDefaultLimit int
+	lockoutProtection   bool
+	protectedPolicies   []string
 }
 
 type baseHandler func(ctx context.Context, w http.ResponseWriter, r *http.Request) (*models.SuccessResponse, *models.ErrorResponse)
@@ -42,6 +48,9 @@ func Setup(
 	r *mux.Router,
 	permissionsStore PermissionsStore,
 	bundler PermissionsBundler,
+	signer BundleSigner,
+	entityResolver EntityResolver,
+	serviceTokens ServiceTokenChecker,
 	auth authorisation.Middleware) *API {
 	api := &API{
 		Router:              r,
@@ -50,16 +59,36 @@ func Setup(
 		defaultOffset:       cfg.DefaultOffset,
 		maximumDefaultLimit: cfg.MaximumDefaultLimit,
 		bundler:             bundler,
+		auth:                auth,
+		signer:              signer,
+		entityResolver:      entityResolver,
+		serviceTokens:       serviceTokens,
+		lockoutProtection:   cfg.LockoutProtection,
+		protectedPolicies:   cfg.ProtectedPolicies,
 	}
 
 	r.HandleFunc("/v1/roles", auth.Require(models.RolesRead, contextAndErrors(api.GetRolesHandler))).Methods(http.MethodGet)
+	r.HandleFunc("/v1/roles", auth.Require(models.RolesCreate, contextAndErrors(api.PostRoleHandler))).Methods(http.MethodPost)
 	r.HandleFunc("/v1/roles/{id}", auth.Require(models.RolesRead, contextAndErrors(api.GetRoleHandler))).Methods(http.MethodGet)
+	r.HandleFunc("/v1/roles/{id}", auth.Require(models.RolesUpdate, contextAndErrors(api.UpdateRoleHandler))).Methods(http.MethodPut)
+	r.HandleFunc("/v1/roles/{id}", auth.Require(models.RolesDelete, contextAndErrors(api.DeleteRoleHandler))).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/roles/{id}/policies", auth.Require(models.PoliciesRead, contextAndErrors(api.GetRolePoliciesHandler))).Methods(http.MethodGet)
 	r.HandleFunc("/v1/policies", auth.Require(models.PoliciesCreate, contextAndErrors(api.PostPolicyHandler))).Methods(http.MethodPost)
 	r.HandleFunc("/v1/policies/{id}", auth.Require(models.PoliciesCreate, contextAndErrors(api.PostPolicyWithIDHandler))).Methods(http.MethodPost)
 	r.HandleFunc("/v1/policies/{id}", auth.Require(models.PoliciesRead, contextAndErrors(api.GetPolicyHandler))).Methods(http.MethodGet)
 	r.HandleFunc("/v1/policies/{id}", auth.Require(models.PoliciesUpdate, contextAndErrors(api.UpdatePolicyHandler))).Methods(http.MethodPut)
+	r.HandleFunc("/v1/policies/{id}", auth.Require(models.PoliciesUpdate, contextAndErrors(api.PatchPolicyHandler))).Methods(http.MethodPatch)
 	r.HandleFunc("/v1/policies/{id}", auth.Require(models.PoliciesDelete, contextAndErrors(api.DeletePolicyHandler))).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/policies/{id}/restore", auth.Require(models.PoliciesCreate, contextAndErrors(api.RestorePolicyHandler))).Methods(http.MethodPost)
+	r.HandleFunc("/v1/policies/{id}/entities/{entity:.+}", auth.Require(models.PoliciesUpdate, contextAndErrors(api.AddPolicyEntityHandler))).Methods(http.MethodPost)
+	r.HandleFunc("/v1/policies/{id}/entities/{entity:.+}", auth.Require(models.PoliciesUpdate, contextAndErrors(api.RemovePolicyEntityHandler))).Methods(http.MethodDelete)
+	r.HandleFunc("/v1/deleted-policies", auth.Require(models.PoliciesRead, contextAndErrors(api.GetDeletedPoliciesHandler))).Methods(http.MethodGet)
+	r.HandleFunc("/v1/permissions", auth.Require(models.PermissionsRead, contextAndErrors(api.GetPermissionsHandler))).Methods(http.MethodGet)
+	r.HandleFunc("/v1/permissions/{id}", auth.Require(models.PermissionsRead, contextAndErrors(api.GetPermissionHandler))).Methods(http.MethodGet)
+	r.HandleFunc("/v1/permissions/{id}", auth.Require(models.PermissionsUpdate, contextAndErrors(api.PutPermissionHandler))).Methods(http.MethodPut)
+	r.HandleFunc("/v1/permissions/{id}/roles", auth.Require(models.RolesRead, contextAndErrors(api.GetPermissionRolesHandler))).Methods(http.MethodGet)
 	r.HandleFunc("/v1/permissions-bundle", contextAndErrors(api.GetPermissionsBundleHandler)).Methods(http.MethodGet)
+	r.HandleFunc("/v1/permissions-bundle/keys", contextAndErrors(api.GetBundleKeysHandler)).Methods(http.MethodGet)
 
 	return api
 }
@@ -22,17 +22,25 @@ func TestSetup(t *testing.T) {
 
 		cfg := &config.Config{}
 		r := mux.NewRouter()
-		permissionsAPI := api.Setup(cfg, r, mongoMock, bundlerMock, newAuthMiddlwareMock())
+		permissionsAPI := api.Setup(cfg, r, mongoMock, bundlerMock, nil, nil, nil, newAuthMiddlwareMock())
 
 		Convey("When created the following routes should have been added", func() {
 			So(hasRoute(permissionsAPI.Router, "/v1/roles", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/roles", "POST"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/roles/{id}", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/roles/{id}", "PUT"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/policies", "POST"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/policies/{id}", "POST"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/policies/{id}", "GET"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/policies/{id}", "PUT"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/policies/{id}", "DELETE"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/policies/{id}/restore", "POST"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/deleted-policies", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/permissions", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/permissions/{id}", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/permissions/{id}", "PUT"), ShouldBeTrue)
 			So(hasRoute(permissionsAPI.Router, "/v1/permissions-bundle", "GET"), ShouldBeTrue)
+			So(hasRoute(permissionsAPI.Router, "/v1/permissions-bundle/keys", "GET"), ShouldBeTrue)
 		})
 	})
 }
@@ -43,6 +51,8 @@ func hasRoute(r *mux.Router, path, method string) bool {
 	return r.Match(req, match)
 }
 
+const testUserID = "test-user"
+
 var cfg = &config.Config{
 	DefaultLimit:        20,
 	DefaultOffset:       0,
@@ -54,11 +64,23 @@ func setupAPI() *api.API {
 }
 
 func setupAPIWithStore(permissionsStore api.PermissionsStore) *api.API {
-	return api.Setup(cfg, mux.NewRouter(), permissionsStore, &mock.PermissionsBundlerMock{}, newAuthMiddlwareMock())
+	return api.Setup(cfg, mux.NewRouter(), permissionsStore, &mock.PermissionsBundlerMock{}, nil, nil, nil, newAuthMiddlwareMock())
 }
 
 func setupAPIWithBundler(bundler api.PermissionsBundler) *api.API {
-	return api.Setup(cfg, mux.NewRouter(), &mock.PermissionsStoreMock{}, bundler, newAuthMiddlwareMock())
+	return api.Setup(cfg, mux.NewRouter(), &mock.PermissionsStoreMock{}, bundler, nil, nil, nil, newAuthMiddlwareMock())
+}
+
+func setupAPIWithSigner(bundler api.PermissionsBundler, signer api.BundleSigner) *api.API {
+	return api.Setup(cfg, mux.NewRouter(), &mock.PermissionsStoreMock{}, bundler, signer, nil, nil, newAuthMiddlwareMock())
+}
+
+func setupAPIWithEntityResolver(permissionsStore api.PermissionsStore, entityResolver api.EntityResolver) *api.API {
+	return api.Setup(cfg, mux.NewRouter(), permissionsStore, &mock.PermissionsBundlerMock{}, nil, entityResolver, nil, newAuthMiddlwareMock())
+}
+
+func setupAPIWithServiceTokenChecker(bundler api.PermissionsBundler, serviceTokens api.ServiceTokenChecker) *api.API {
+	return api.Setup(cfg, mux.NewRouter(), &mock.PermissionsStoreMock{}, bundler, nil, nil, serviceTokens, newAuthMiddlwareMock())
 }
 
 func newAuthMiddlwareMock() *authmock.MiddlewareMock {
@@ -66,7 +88,7 @@ func newAuthMiddlwareMock() *authmock.MiddlewareMock {
 		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
 				entityData := &permsdk.EntityData{
-					UserID: "test-user",
+					UserID: testUserID,
 					Groups: []string{"role-admin"},
 				}
 				authEntityData := auth.CreateAuthEntityData(entityData, false)
@@ -76,7 +98,7 @@ func newAuthMiddlwareMock() *authmock.MiddlewareMock {
 		},
 		ParseFunc: func(token string) (*permsdk.EntityData, error) {
 			return &permsdk.EntityData{
-				UserID: "test-user",
+				UserID: testUserID,
 				Groups: []string{"role-admin"},
 			}, nil
 		},
@@ -4,25 +4,184 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
 	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-permissions-api/tracing"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
+const entityQueryParameter = "entity"
+
 // GetPermissionsBundleHandler gets and returns the permissions bundle as JSON in the HTTP response body.
+// If one or more entity query parameters are provided, the bundle is pruned to only contain the policies of those entities.
+// The normalised bundle encoding and gzip/brotli compression are returned if the request accepts them.
 func (api *API) GetPermissionsBundleHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	entities := req.URL.Query()[entityQueryParameter]
+	for _, entity := range entities {
+		if entity == "" {
+			return nil, handleInvalidQueryParameterError(ctx, apierrors.ErrEmptyEntity, entityQueryParameter, entity)
+		}
+	}
+
 	bundle, err := api.bundler.Get(ctx)
 	if err != nil {
 		return nil, handleGetPermissionsBundleError(ctx, err)
 	}
 
-	b, err := json.Marshal(bundle)
+	if len(entities) > 0 {
+		if errorResponse := api.checkEntityBundleAccess(ctx, req, bundle, entities); errorResponse != nil {
+			return nil, errorResponse
+		}
+		bundle = bundle.FilterByEntities(entities)
+	}
+
+	b, headers, errorResponse := api.encodePermissionsBundle(ctx, req, bundle)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, headers), nil
+}
+
+// encodePermissionsBundle marshals the bundle in the encoding accepted by the request, signs it if bundle signing is
+// enabled, and compresses it if the request accepts a supported content encoding
+func (api *API) encodePermissionsBundle(ctx context.Context, req *http.Request, bundle models.Bundle) ([]byte, map[string]string, *models.ErrorResponse) {
+	ctx, span := tracing.Start(ctx, "api.encodePermissionsBundle")
+	defer span.End()
+
+	headers := map[string]string{
+		"Vary": "Accept, Accept-Encoding",
+	}
+
+	var b []byte
+	var err error
+	if acceptsMediaType(req.Header.Get("Accept"), models.BundleMediaTypeNormalised) {
+		normalisedBundle := bundle.Normalise()
+		b, err = json.Marshal(normalisedBundle)
+		if err != nil {
+			return nil, nil, handleBodyMarshalError(ctx, err, "normalised_bundle", normalisedBundle)
+		}
+		headers["Content-Type"] = models.BundleMediaTypeNormalised
+	} else {
+		b, err = json.Marshal(bundle)
+		if err != nil {
+			return nil, nil, handleBodyMarshalError(ctx, err, "bundle", bundle)
+		}
+	}
+
+	// the signature is always over the uncompressed body, so that it can be verified after decoding
+	if api.signer != nil {
+		headers[models.BundleSignatureHeader] = api.signer.Sign(b)
+		headers[models.BundleSignatureKeyIDHeader] = api.signer.KeyID()
+	}
+
+	if encoding := negotiateContentEncoding(req.Header.Get("Accept-Encoding")); encoding != "" {
+		b, err = compress(b, encoding)
+		if err != nil {
+			return nil, nil, handleCompressBundleError(ctx, err, encoding)
+		}
+		headers["Content-Encoding"] = encoding
+	}
+
+	return b, headers, nil
+}
+
+// GetBundleKeysHandler returns the set of public keys that can be used to verify signed permissions bundles.
+// The key set is empty if bundle signing is not enabled.
+func (api *API) GetBundleKeysHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	keys := models.JSONWebKeySet{Keys: []models.JSONWebKey{}}
+	if api.signer != nil {
+		keys = api.signer.JWKS()
+	}
+
+	b, err := json.Marshal(keys)
 	if err != nil {
-		return nil, handleBodyMarshalError(ctx, err, "bundle", bundle)
+		return nil, handleBodyMarshalError(ctx, err, "keys", keys)
 	}
 
 	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
 }
 
+// checkEntityBundleAccess ensures that the caller is allowed to see the bundle slices of the requested entities.
+// Callers may always see their own user and group entities. Any other entity requires the policies:read permission.
+// The caller of a service auth token is the user entity of that service. Nothing is restricted when authorisation is
+// disabled, even without an auth token.
+func (api *API) checkEntityBundleAccess(ctx context.Context, req *http.Request, bundle models.Bundle, entities []string) *models.ErrorResponse {
+	logData := log.Data{"entities": entities}
+
+	authToken := strings.TrimPrefix(req.Header.Get(sdk.Authorization), sdk.BearerPrefix)
+	if authToken == "" {
+		// the middleware of disabled authorisation parses any token, even a missing one, to no entity data
+		if entityData, err := api.auth.Parse(authToken); err == nil && entityData == nil {
+			return nil
+		}
+		return handleEntityBundleUnauthorisedError(ctx, apierrors.ErrMissingAuthToken, logData)
+	}
+
+	entityData, err := api.parseAuthToken(ctx, authToken)
+	if err != nil {
+		return handleEntityBundleUnauthorisedError(ctx, err, logData)
+	}
+	if entityData == nil {
+		// authorisation is disabled, so there is nothing to restrict
+		return nil
+	}
+
+	callerEntities := entityData.Entities()
+	if bundle.HoldsUnconditionally(models.PoliciesRead, callerEntities) {
+		return nil
+	}
+
+	allowed := make(map[string]struct{}, len(callerEntities))
+	for _, entity := range callerEntities {
+		allowed[entity] = struct{}{}
+	}
+
+	for _, entity := range entities {
+		if _, ok := allowed[entity]; !ok {
+			logData["entity"] = entity
+			return models.NewErrorResponse(http.StatusForbidden,
+				nil,
+				models.NewError(ctx, apierrors.ErrEntityNotPermitted, models.EntityNotPermittedError, models.EntityNotPermittedDescription, logData),
+			)
+		}
+	}
+
+	return nil
+}
+
+// parseAuthToken returns the entity data of the caller of the given auth token. Service auth tokens are not JWTs,
+// so they are checked against the identity service in the same way as the authorisation middleware does.
+func (api *API) parseAuthToken(ctx context.Context, authToken string) (*sdk.EntityData, error) {
+	if api.serviceTokens == nil || strings.Contains(authToken, ".") {
+		return api.auth.Parse(authToken)
+	}
+
+	identity, err := api.serviceTokens.CheckTokenIdentity(ctx, authToken)
+	if err != nil {
+		return nil, err
+	}
+	return &sdk.EntityData{UserID: identity.Identifier}, nil
+}
+
+func handleEntityBundleUnauthorisedError(ctx context.Context, err error, logData log.Data) *models.ErrorResponse {
+	return models.NewErrorResponse(http.StatusUnauthorized,
+		nil,
+		models.NewError(ctx, err, models.InvalidAuthTokenError, models.InvalidAuthTokenDescription, logData),
+	)
+}
+
+func handleCompressBundleError(ctx context.Context, err error, encoding string) *models.ErrorResponse {
+	logData := log.Data{"content_encoding": encoding}
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.CompressBundleError, models.CompressBundleErrorDescription, logData),
+	)
+}
+
 func handleGetPermissionsBundleError(ctx context.Context, err error) *models.ErrorResponse {
 	return models.NewErrorResponse(http.StatusInternalServerError,
 		nil,
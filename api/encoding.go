@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content codings supported for compressing responses
+const (
+	contentEncodingBrotli = "br"
+	contentEncodingGzip   = "gzip"
+)
+
+// supportedContentEncodings is ordered by preference, for when a client accepts several codings equally
+var supportedContentEncodings = []string{contentEncodingBrotli, contentEncodingGzip}
+
+// acceptsMediaType returns true if the Accept header value explicitly includes the given media type with a non-zero quality
+func acceptsMediaType(acceptHeader, mediaType string) bool {
+	q, ok := parseQualityValues(acceptHeader)[mediaType]
+	return ok && q > 0
+}
+
+// negotiateContentEncoding returns the supported content coding with the highest quality in the Accept-Encoding
+// header value, or an empty string if the response should not be compressed
+func negotiateContentEncoding(acceptEncodingHeader string) string {
+	qualities := parseQualityValues(acceptEncodingHeader)
+
+	var encoding string
+	var bestQuality float64
+	for _, supported := range supportedContentEncodings {
+		q, ok := qualities[supported]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQuality {
+			encoding, bestQuality = supported, q
+		}
+	}
+
+	return encoding
+}
+
+// parseQualityValues parses a comma separated header value with optional quality parameters, e.g. "gzip;q=0.8, br"
+func parseQualityValues(headerValue string) map[string]float64 {
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(headerValue, ",") {
+		params := strings.Split(part, ";")
+		value := strings.ToLower(strings.TrimSpace(params[0]))
+		if value == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			name, paramValue, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(paramValue), 64); err == nil {
+				q = parsed
+			}
+		}
+
+		qualities[value] = q
+	}
+
+	return qualities
+}
+
+// compress encodes the payload with the given content coding
+func compress(payload []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var w io.WriteCloser
+	switch encoding {
+	case contentEncodingBrotli:
+		w = brotli.NewWriter(&buf)
+	case contentEncodingGzip:
+		w = gzip.NewWriter(&buf)
+	default:
+		return payload, nil
+	}
+
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/dp-permissions-api/models"
 )
 
 //go:generate moq -out mock/permissionsStore.go -pkg mock . PermissionsStore
 //go:generate moq -out ../service/mock/store.go -pkg mock . PermissionsStore
 //go:generate moq -out mock/bundler.go -pkg mock . PermissionsBundler
+//go:generate moq -out mock/signer.go -pkg mock . BundleSigner
+//go:generate moq -out mock/entityResolver.go -pkg mock . EntityResolver
+//go:generate moq -out mock/serviceTokenChecker.go -pkg mock . ServiceTokenChecker
 
 // PermissionsStore defines the behaviour of a PermissionsStore
 type PermissionsStore interface {
@@ -17,13 +21,53 @@ type PermissionsStore interface {
 	Close(ctx context.Context) error
 	GetRole(ctx context.Context, id string) (*models.Role, error)
 	GetRoles(ctx context.Context, offset, limit int) (*models.Roles, error)
+	FindRoles(ctx context.Context, filter models.RoleFilter, offset, limit int) (*models.Roles, error)
+	GetAllRoles(ctx context.Context) ([]*models.Role, error)
+	GetRolesWithDeprecatedPermissions(ctx context.Context, offset, limit int) (*models.Roles, error)
+	GetRolesGrantingPermission(ctx context.Context, permission string) ([]*models.Role, error)
+	CountPoliciesByRole(ctx context.Context, roleIDs []string) (map[string]int, error)
+	AddRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	UpdateRole(ctx context.Context, role *models.Role) error
+	DeleteRole(ctx context.Context, id string) error
 	AddPolicy(ctx context.Context, policy *models.Policy) (*models.Policy, error)
 	UpdatePolicy(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error)
+	ReplacePolicy(ctx context.Context, policy *models.Policy) error
+	ReplacePolicyIfUnmodified(ctx context.Context, policy *models.Policy, version int) error
 	GetPolicy(ctx context.Context, id string) (*models.Policy, error)
-	DeletePolicy(ctx context.Context, id string) error
+	DeletePolicy(ctx context.Context, id, deletedBy string) error
+	GetDeletedPolicies(ctx context.Context, offset, limit int) (*models.Policies, error)
+	GetDeletedPolicy(ctx context.Context, id string) (*models.Policy, error)
+	RestorePolicy(ctx context.Context, id, updatedBy string) error
+	AddPolicyEntity(ctx context.Context, policyID, entity, updatedBy string) (bool, error)
+	RemovePolicyEntity(ctx context.Context, policyID, entity, updatedBy string) (bool, error)
+	GetPoliciesByRole(ctx context.Context, roleID string, offset, limit int) (*models.Policies, error)
+	DeletePoliciesByRole(ctx context.Context, roleID string) ([]string, error)
+	GetPermission(ctx context.Context, id string) (*models.Permission, error)
+	GetPermissions(ctx context.Context, offset, limit int) (*models.Permissions, error)
+	UpsertPermission(ctx context.Context, permission *models.Permission) (*models.UpdateResult, error)
+	GetUnknownPermissions(ctx context.Context, permissions []string) ([]string, error)
 }
 
-// PermissionsBundler defines the functions used by the API to get permissions bundles
+// PermissionsBundler defines the functions used by the API to get permissions bundles, and to preview the bundle that
+// would result from a change
 type PermissionsBundler interface {
 	Get(ctx context.Context) (models.Bundle, error)
+	Preview(ctx context.Context, change models.BundleChange) (models.Bundle, error)
+}
+
+// BundleSigner defines the functions used by the API to sign permissions bundles
+type BundleSigner interface {
+	KeyID() string
+	Sign(payload []byte) string
+	JWKS() models.JSONWebKeySet
+}
+
+// EntityResolver defines the functions used by the API to check that policy entities exist in the identity service
+type EntityResolver interface {
+	GetUnknownEntities(ctx context.Context, entities []string) ([]string, error)
+}
+
+// ServiceTokenChecker defines the function used by the API to identify the caller of a service auth token
+type ServiceTokenChecker interface {
+	CheckTokenIdentity(ctx context.Context, token string) (*request.IdentityResponse, error)
 }
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const overrideQueryParameter = "override"
+
+// checkProtectedPolicy checks that a protected policy is only changed if the override query parameter is true.
+// Refusals are audited, as they may be attempts to lock everyone out of the API.
+func (api *API) checkProtectedPolicy(ctx context.Context, req *http.Request, policyID string, authEntityData *authorisation.AuthEntityData) *models.ErrorResponse {
+	if !slices.Contains(api.protectedPolicies, policyID) {
+		return nil
+	}
+
+	override := false
+	if overrideParameter := req.URL.Query().Get(overrideQueryParameter); overrideParameter != "" {
+		var err error
+		override, err = strconv.ParseBool(overrideParameter)
+		if err != nil {
+			return handleInvalidQueryParameterError(ctx, err, overrideQueryParameter, overrideParameter)
+		}
+	}
+	if override {
+		log.Info(ctx, "protected policy is changed with override", log.Data{policyIDKey: policyID})
+		return nil
+	}
+
+	logAuditEvent(ctx, "refused to change protected policy audit event", authEntityData, auditAction(req), req.URL.Path, models.OutcomeFailure, models.ProtectedPolicyDescription)
+	return models.NewErrorResponse(http.StatusConflict,
+		nil,
+		models.NewError(ctx, apierrors.ErrProtectedPolicy, models.ProtectedPolicyError, models.ProtectedPolicyDescription, log.Data{policyIDKey: policyID}),
+	)
+}
+
+// checkLockout checks that the permissions bundle that would result from a change to a policy or a role still grants
+// every policy administration permission to an entity, so that the change cannot leave nobody able to repair the
+// policies through the API. Refusals are audited.
+//
+// The check is not atomic with the change: the bundle is previewed from the stored policies and roles before the change
+// is written, so two concurrent changes, each leaving an administrator on its own, can together leave none. Serialising
+// the changes in the API would not prevent this when several instances are running, so this is a known limitation, and
+// the protected policies are relied on to keep an administrator.
+func (api *API) checkLockout(ctx context.Context, req *http.Request, change models.BundleChange, authEntityData *authorisation.AuthEntityData) *models.ErrorResponse {
+	if !api.lockoutProtection {
+		return nil
+	}
+
+	bundle, err := api.bundler.Preview(ctx, change)
+	if err != nil {
+		return models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.CheckLockoutError, models.CheckLockoutErrorDescription, nil),
+		)
+	}
+
+	unheld := bundle.UnheldPermissions(models.PolicyAdministrationPermissions)
+	if len(unheld) == 0 {
+		return nil
+	}
+
+	description := "the change would leave no entity holding: " + strings.Join(unheld, ", ")
+	logAuditEvent(ctx, "refused change that would lock everyone out audit event", authEntityData, auditAction(req), req.URL.Path, models.OutcomeFailure, description)
+	return models.NewErrorResponse(http.StatusConflict,
+		nil,
+		models.NewError(ctx, apierrors.ErrLockout, models.LockoutError, description, log.Data{"unheld_permissions": unheld}),
+	)
+}
+
+// auditAction returns the audit action of a request that changes a policy or a role
+func auditAction(req *http.Request) models.Action {
+	if req.Method == http.MethodDelete {
+		return models.ActionDelete
+	}
+	return models.ActionUpdate
+}
@@ -0,0 +1,253 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/api"
+	"github.com/ONSdigital/dp-permissions-api/api/mock"
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
+	"github.com/ONSdigital/dp-permissions-api/config"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testProtectedPolicyID = "protected-policy"
+
+var lockoutCfg = &config.Config{
+	DefaultLimit:        20,
+	DefaultOffset:       0,
+	MaximumDefaultLimit: 1000,
+	LockoutProtection:   true,
+	ProtectedPolicies:   []string{testProtectedPolicyID},
+}
+
+func setupAPIWithLockoutProtection(permissionsStore api.PermissionsStore, bundler api.PermissionsBundler) *api.API {
+	return api.Setup(lockoutCfg, mux.NewRouter(), permissionsStore, bundler, nil, nil, nil, newAuthMiddlwareMock())
+}
+
+// adminBundle returns a bundle in which the given entity holds every policy administration permission
+func adminBundle(entity string) models.Bundle {
+	bundle := models.Bundle{}
+	for _, permission := range models.PolicyAdministrationPermissions {
+		bundle[permission] = models.EntityIDToPolicies{entity: {{ID: "admin", Role: "admin"}}}
+	}
+	return bundle
+}
+
+func newLockoutPermissionsStore() *mock.PermissionsStoreMock {
+	return &mock.PermissionsStoreMock{
+		GetPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+			return &models.Policy{ID: id, Entities: []string{"groups/admin", "groups/other"}, Role: "admin"}, nil
+		},
+		UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+			return &models.UpdateResult{ModifiedCount: 1}, nil
+		},
+		DeletePolicyFunc: func(ctx context.Context, id, deletedBy string) error {
+			return nil
+		},
+		AddPolicyEntityFunc: func(ctx context.Context, id, entity, updatedBy string) (bool, error) {
+			return true, nil
+		},
+		RemovePolicyEntityFunc: func(ctx context.Context, id, entity, updatedBy string) (bool, error) {
+			return true, nil
+		},
+		GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+			return dbRole(id), nil
+		},
+		GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+			return []*models.Role{dbRole("admin")}, nil
+		},
+		GetUnknownPermissionsFunc: func(ctx context.Context, permissions []string) ([]string, error) {
+			return nil, nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, role *models.Role) error {
+			return nil
+		},
+		DeletePoliciesByRoleFunc: func(ctx context.Context, roleID string) ([]string, error) {
+			return []string{"policy1"}, nil
+		},
+		DeleteRoleFunc: func(ctx context.Context, id string) error {
+			return nil
+		},
+	}
+}
+
+func TestLockoutProtection(t *testing.T) {
+	Convey("Given an API with lockout protection, and a change that would leave the bundle without policy administrators", t, func() {
+		mockedPermissionsStore := newLockoutPermissionsStore()
+		bundler := &mock.PermissionsBundlerMock{
+			PreviewFunc: func(ctx context.Context, change models.BundleChange) (models.Bundle, error) {
+				return models.Bundle{}, nil
+			},
+		}
+		permissionsAPI := setupAPIWithLockoutProtection(mockedPermissionsStore, bundler)
+
+		Convey("When a policy is deleted, then the deletion is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/policy1", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.LockoutError)
+			So(w.Body.String(), ShouldContainSubstring, models.PoliciesDelete)
+			So(bundler.PreviewCalls(), ShouldHaveLength, 1)
+			So(bundler.PreviewCalls()[0].Change, ShouldResemble, models.BundleChange{DeletedPolicyID: "policy1"})
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a policy is updated, then the update is refused with status code 409", func() {
+			reader := strings.NewReader(`{"entities": ["groups/other"], "role": "admin"}`)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/policy1", reader))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.LockoutError)
+			So(bundler.PreviewCalls()[0].Change.Policy, ShouldResemble, &models.BundlePolicy{ID: "policy1", Entities: []string{"groups/other"}, Role: "admin"})
+			So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When an entity is removed from a policy, then the removal is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/policy1/entities/groups/admin", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(bundler.PreviewCalls()[0].Change.Policy.Entities, ShouldResemble, []string{"groups/other"})
+			So(mockedPermissionsStore.RemovePolicyEntityCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is updated, then the update is refused with status code 409", func() {
+			reader := strings.NewReader(`{"name": "ReadOnly", "permissions": ["read"]}`)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/roles/admin", reader))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(bundler.PreviewCalls()[0].Change.Role.ID, ShouldEqual, "admin")
+			So(mockedPermissionsStore.UpdateRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is deleted with its policies, then the deletion is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1?cascade=true", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(bundler.PreviewCalls()[0].Change, ShouldResemble, models.BundleChange{DeletedRoleID: testRoleID1})
+			So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+			So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When an entity is added to a policy, then the bundle is not checked", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/policy1/entities/groups/new", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(bundler.PreviewCalls(), ShouldHaveLength, 0)
+		})
+	})
+
+	Convey("Given an API with lockout protection, and a change that leaves an entity holding the policy administration permissions", t, func() {
+		mockedPermissionsStore := newLockoutPermissionsStore()
+		bundler := &mock.PermissionsBundlerMock{
+			PreviewFunc: func(ctx context.Context, change models.BundleChange) (models.Bundle, error) {
+				return adminBundle("groups/admin"), nil
+			},
+		}
+		permissionsAPI := setupAPIWithLockoutProtection(mockedPermissionsStore, bundler)
+
+		Convey("When a policy is deleted, then the policy is deleted", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/policy1", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When a protected policy is deleted without override, then the deletion is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/"+testProtectedPolicyID, http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.ProtectedPolicyError)
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a protected policy is deleted with override, then the policy is deleted", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/"+testProtectedPolicyID+"?override=true", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When a protected policy is deleted with an invalid override value, then status code 400 is returned", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/"+testProtectedPolicyID+"?override=yes", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When an entity is added to a protected policy without override, then the addition is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/"+testProtectedPolicyID+"/entities/groups/new", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.ProtectedPolicyError)
+			So(mockedPermissionsStore.AddPolicyEntityCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is deleted with its policies, and its protected policies cannot be checked, then status code 500 is returned", func() {
+			mockedPermissionsStore.GetPolicyFunc = func(ctx context.Context, id string) (*models.Policy, error) {
+				return nil, errors.New("store error")
+			}
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/admin?cascade=true", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+			So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is deleted with its policies, and a protected policy does not exist, then the role is deleted", func() {
+			mockedPermissionsStore.GetPolicyFunc = func(ctx context.Context, id string) (*models.Policy, error) {
+				return nil, apierrors.ErrPolicyNotFound
+			}
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/admin?cascade=true", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+			So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When a role with a protected policy is deleted with its policies without override, then the deletion is refused with status code 409", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/admin?cascade=true", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.ProtectedPolicyError)
+			So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+		})
+	})
+
+	Convey("Given an API with lockout protection, and a bundler that fails to preview a change", t, func() {
+		mockedPermissionsStore := newLockoutPermissionsStore()
+		bundler := &mock.PermissionsBundlerMock{
+			PreviewFunc: func(ctx context.Context, change models.BundleChange) (models.Bundle, error) {
+				return nil, errors.New("bundler error")
+			},
+		}
+		permissionsAPI := setupAPIWithLockoutProtection(mockedPermissionsStore, bundler)
+
+		Convey("When a policy is deleted, then status code 500 is returned, and the policy is not deleted", func() {
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/policy1", http.NoBody))
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(mockedPermissionsStore.DeletePolicyCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
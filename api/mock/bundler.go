@@ -16,23 +16,29 @@ var _ api.PermissionsBundler = &PermissionsBundlerMock{}
 
 // PermissionsBundlerMock is a mock implementation of api.PermissionsBundler.
 //
-// 	func TestSomethingThatUsesPermissionsBundler(t *testing.T) {
+//	func TestSomethingThatUsesPermissionsBundler(t *testing.T) {
 //
-// 		// make and configure a mocked api.PermissionsBundler
-// 		mockedPermissionsBundler := &PermissionsBundlerMock{
-// 			GetFunc: func(ctx context.Context) (models.Bundle, error) {
-// 				panic("mock out the Get method")
-// 			},
-// 		}
+//		// make and configure a mocked api.PermissionsBundler
+//		mockedPermissionsBundler := &PermissionsBundlerMock{
+//			GetFunc: func(ctx context.Context) (models.Bundle, error) {
+//				panic("mock out the Get method")
+//			},
+//			PreviewFunc: func(ctx context.Context, change models.BundleChange) (models.Bundle, error) {
+//				panic("mock out the Preview method")
+//			},
+//		}
 //
-// 		// use mockedPermissionsBundler in code that requires api.PermissionsBundler
-// 		// and then make assertions.
+//		// use mockedPermissionsBundler in code that requires api.PermissionsBundler
+//		// and then make assertions.
 //
-// 	}
+//	}
 type PermissionsBundlerMock struct {
 	// GetFunc mocks the Get method.
 	GetFunc func(ctx context.Context) (models.Bundle, error)
 
+	// PreviewFunc mocks the Preview method.
+	PreviewFunc func(ctx context.Context, change models.BundleChange) (models.Bundle, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Get holds details about calls to the Get method.
@@ -40,8 +46,16 @@ type PermissionsBundlerMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// Preview holds details about calls to the Preview method.
+		Preview []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Change is the change argument value.
+			Change models.BundleChange
+		}
 	}
-	lockGet sync.RWMutex
+	lockGet     sync.RWMutex
+	lockPreview sync.RWMutex
 }
 
 // Get calls GetFunc.
@@ -62,7 +76,8 @@ func (mock *PermissionsBundlerMock) Get(ctx context.Context) (models.Bundle, err
 
 // GetCalls gets all the calls that were made to Get.
 // Check the length with:
-//     len(mockedPermissionsBundler.GetCalls())
+//
+//	len(mockedPermissionsBundler.GetCalls())
 func (mock *PermissionsBundlerMock) GetCalls() []struct {
 	Ctx context.Context
 } {
@@ -74,3 +89,39 @@ func (mock *PermissionsBundlerMock) GetCalls() []struct {
 	mock.lockGet.RUnlock()
 	return calls
 }
+
+// Preview calls PreviewFunc.
+func (mock *PermissionsBundlerMock) Preview(ctx context.Context, change models.BundleChange) (models.Bundle, error) {
+	if mock.PreviewFunc == nil {
+		panic("PermissionsBundlerMock.PreviewFunc: method is nil but PermissionsBundler.Preview was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Change models.BundleChange
+	}{
+		Ctx:    ctx,
+		Change: change,
+	}
+	mock.lockPreview.Lock()
+	mock.calls.Preview = append(mock.calls.Preview, callInfo)
+	mock.lockPreview.Unlock()
+	return mock.PreviewFunc(ctx, change)
+}
+
+// PreviewCalls gets all the calls that were made to Preview.
+// Check the length with:
+//
+//	len(mockedPermissionsBundler.PreviewCalls())
+func (mock *PermissionsBundlerMock) PreviewCalls() []struct {
+	Ctx    context.Context
+	Change models.BundleChange
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Change models.BundleChange
+	}
+	mock.lockPreview.RLock()
+	calls = mock.calls.Preview
+	mock.lockPreview.RUnlock()
+	return calls
+}
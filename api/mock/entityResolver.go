@@ -0,0 +1,82 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/ONSdigital/dp-permissions-api/api"
+	"sync"
+)
+
+// Ensure, that EntityResolverMock does implement api.EntityResolver.
+// If this is not the case, regenerate this file with moq.
+var _ api.EntityResolver = &EntityResolverMock{}
+
+// EntityResolverMock is a mock implementation of api.EntityResolver.
+//
+//	func TestSomethingThatUsesEntityResolver(t *testing.T) {
+//
+//		// make and configure a mocked api.EntityResolver
+//		mockedEntityResolver := &EntityResolverMock{
+//			GetUnknownEntitiesFunc: func(ctx context.Context, entities []string) ([]string, error) {
+//				panic("mock out the GetUnknownEntities method")
+//			},
+//		}
+//
+//		// use mockedEntityResolver in code that requires api.EntityResolver
+//		// and then make assertions.
+//
+//	}
+type EntityResolverMock struct {
+	// GetUnknownEntitiesFunc mocks the GetUnknownEntities method.
+	GetUnknownEntitiesFunc func(ctx context.Context, entities []string) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetUnknownEntities holds details about calls to the GetUnknownEntities method.
+		GetUnknownEntities []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entities is the entities argument value.
+			Entities []string
+		}
+	}
+	lockGetUnknownEntities sync.RWMutex
+}
+
+// GetUnknownEntities calls GetUnknownEntitiesFunc.
+func (mock *EntityResolverMock) GetUnknownEntities(ctx context.Context, entities []string) ([]string, error) {
+	if mock.GetUnknownEntitiesFunc == nil {
+		panic("EntityResolverMock.GetUnknownEntitiesFunc: method is nil but EntityResolver.GetUnknownEntities was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Entities []string
+	}{
+		Ctx:      ctx,
+		Entities: entities,
+	}
+	mock.lockGetUnknownEntities.Lock()
+	mock.calls.GetUnknownEntities = append(mock.calls.GetUnknownEntities, callInfo)
+	mock.lockGetUnknownEntities.Unlock()
+	return mock.GetUnknownEntitiesFunc(ctx, entities)
+}
+
+// GetUnknownEntitiesCalls gets all the calls that were made to GetUnknownEntities.
+// Check the length with:
+//
+//	len(mockedEntityResolver.GetUnknownEntitiesCalls())
+func (mock *EntityResolverMock) GetUnknownEntitiesCalls() []struct {
+	Ctx      context.Context
+	Entities []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Entities []string
+	}
+	mock.lockGetUnknownEntities.RLock()
+	calls = mock.calls.GetUnknownEntities
+	mock.lockGetUnknownEntities.RUnlock()
+	return calls
+}
@@ -0,0 +1,83 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-permissions-api/api"
+	"sync"
+)
+
+// Ensure, that ServiceTokenCheckerMock does implement api.ServiceTokenChecker.
+// If this is not the case, regenerate this file with moq.
+var _ api.ServiceTokenChecker = &ServiceTokenCheckerMock{}
+
+// ServiceTokenCheckerMock is a mock implementation of api.ServiceTokenChecker.
+//
+//	func TestSomethingThatUsesServiceTokenChecker(t *testing.T) {
+//
+//		// make and configure a mocked api.ServiceTokenChecker
+//		mockedServiceTokenChecker := &ServiceTokenCheckerMock{
+//			CheckTokenIdentityFunc: func(ctx context.Context, token string) (*request.IdentityResponse, error) {
+//				panic("mock out the CheckTokenIdentity method")
+//			},
+//		}
+//
+//		// use mockedServiceTokenChecker in code that requires api.ServiceTokenChecker
+//		// and then make assertions.
+//
+//	}
+type ServiceTokenCheckerMock struct {
+	// CheckTokenIdentityFunc mocks the CheckTokenIdentity method.
+	CheckTokenIdentityFunc func(ctx context.Context, token string) (*request.IdentityResponse, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CheckTokenIdentity holds details about calls to the CheckTokenIdentity method.
+		CheckTokenIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+	}
+	lockCheckTokenIdentity sync.RWMutex
+}
+
+// CheckTokenIdentity calls CheckTokenIdentityFunc.
+func (mock *ServiceTokenCheckerMock) CheckTokenIdentity(ctx context.Context, token string) (*request.IdentityResponse, error) {
+	if mock.CheckTokenIdentityFunc == nil {
+		panic("ServiceTokenCheckerMock.CheckTokenIdentityFunc: method is nil but ServiceTokenChecker.CheckTokenIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockCheckTokenIdentity.Lock()
+	mock.calls.CheckTokenIdentity = append(mock.calls.CheckTokenIdentity, callInfo)
+	mock.lockCheckTokenIdentity.Unlock()
+	return mock.CheckTokenIdentityFunc(ctx, token)
+}
+
+// CheckTokenIdentityCalls gets all the calls that were made to CheckTokenIdentity.
+// Check the length with:
+//
+//	len(mockedServiceTokenChecker.CheckTokenIdentityCalls())
+func (mock *ServiceTokenCheckerMock) CheckTokenIdentityCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockCheckTokenIdentity.RLock()
+	calls = mock.calls.CheckTokenIdentity
+	mock.lockCheckTokenIdentity.RUnlock()
+	return calls
+}
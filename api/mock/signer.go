@@ -0,0 +1,150 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"github.com/ONSdigital/dp-permissions-api/api"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"sync"
+)
+
+// Ensure, that BundleSignerMock does implement api.BundleSigner.
+// If this is not the case, regenerate this file with moq.
+var _ api.BundleSigner = &BundleSignerMock{}
+
+// BundleSignerMock is a mock implementation of api.BundleSigner.
+//
+//	func TestSomethingThatUsesBundleSigner(t *testing.T) {
+//
+//		// make and configure a mocked api.BundleSigner
+//		mockedBundleSigner := &BundleSignerMock{
+//			JWKSFunc: func() models.JSONWebKeySet {
+//				panic("mock out the JWKS method")
+//			},
+//			KeyIDFunc: func() string {
+//				panic("mock out the KeyID method")
+//			},
+//			SignFunc: func(payload []byte) string {
+//				panic("mock out the Sign method")
+//			},
+//		}
+//
+//		// use mockedBundleSigner in code that requires api.BundleSigner
+//		// and then make assertions.
+//
+//	}
+type BundleSignerMock struct {
+	// JWKSFunc mocks the JWKS method.
+	JWKSFunc func() models.JSONWebKeySet
+
+	// KeyIDFunc mocks the KeyID method.
+	KeyIDFunc func() string
+
+	// SignFunc mocks the Sign method.
+	SignFunc func(payload []byte) string
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// JWKS holds details about calls to the JWKS method.
+		JWKS []struct {
+		}
+		// KeyID holds details about calls to the KeyID method.
+		KeyID []struct {
+		}
+		// Sign holds details about calls to the Sign method.
+		Sign []struct {
+			// Payload is the payload argument value.
+			Payload []byte
+		}
+	}
+	lockJWKS  sync.RWMutex
+	lockKeyID sync.RWMutex
+	lockSign  sync.RWMutex
+}
+
+// JWKS calls JWKSFunc.
+func (mock *BundleSignerMock) JWKS() models.JSONWebKeySet {
+	if mock.JWKSFunc == nil {
+		panic("BundleSignerMock.JWKSFunc: method is nil but BundleSigner.JWKS was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockJWKS.Lock()
+	mock.calls.JWKS = append(mock.calls.JWKS, callInfo)
+	mock.lockJWKS.Unlock()
+	return mock.JWKSFunc()
+}
+
+// JWKSCalls gets all the calls that were made to JWKS.
+// Check the length with:
+//
+//	len(mockedBundleSigner.JWKSCalls())
+func (mock *BundleSignerMock) JWKSCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockJWKS.RLock()
+	calls = mock.calls.JWKS
+	mock.lockJWKS.RUnlock()
+	return calls
+}
+
+// KeyID calls KeyIDFunc.
+func (mock *BundleSignerMock) KeyID() string {
+	if mock.KeyIDFunc == nil {
+		panic("BundleSignerMock.KeyIDFunc: method is nil but BundleSigner.KeyID was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockKeyID.Lock()
+	mock.calls.KeyID = append(mock.calls.KeyID, callInfo)
+	mock.lockKeyID.Unlock()
+	return mock.KeyIDFunc()
+}
+
+// KeyIDCalls gets all the calls that were made to KeyID.
+// Check the length with:
+//
+//	len(mockedBundleSigner.KeyIDCalls())
+func (mock *BundleSignerMock) KeyIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockKeyID.RLock()
+	calls = mock.calls.KeyID
+	mock.lockKeyID.RUnlock()
+	return calls
+}
+
+// Sign calls SignFunc.
+func (mock *BundleSignerMock) Sign(payload []byte) string {
+	if mock.SignFunc == nil {
+		panic("BundleSignerMock.SignFunc: method is nil but BundleSigner.Sign was just called")
+	}
+	callInfo := struct {
+		Payload []byte
+	}{
+		Payload: payload,
+	}
+	mock.lockSign.Lock()
+	mock.calls.Sign = append(mock.calls.Sign, callInfo)
+	mock.lockSign.Unlock()
+	return mock.SignFunc(payload)
+}
+
+// SignCalls gets all the calls that were made to Sign.
+// Check the length with:
+//
+//	len(mockedBundleSigner.SignCalls())
+func (mock *BundleSignerMock) SignCalls() []struct {
+	Payload []byte
+} {
+	var calls []struct {
+		Payload []byte
+	}
+	mock.lockSign.RLock()
+	calls = mock.calls.Sign
+	mock.lockSign.RUnlock()
+	return calls
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+const permissionIDKey = "permission_id"
+
+// GetPermissionHandler is a handler that gets a permission from the catalogue by its ID
+func (api *API) GetPermissionHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	permissionID := vars["id"]
+
+	permission, err := api.permissionsStore.GetPermission(ctx, permissionID)
+	if err != nil {
+		return nil, handleGetPermissionError(ctx, err, permissionID)
+	}
+
+	b, err := json.Marshal(permission)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "permission", permission)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+func handleGetPermissionError(ctx context.Context, err error, permissionID string) *models.ErrorResponse {
+	logData := log.Data{permissionIDKey: permissionID}
+	if err == apierrors.ErrPermissionNotFound {
+		return models.NewErrorResponse(http.StatusNotFound,
+			nil,
+			models.NewError(ctx, err, models.PermissionNotFoundError, models.PermissionNotFoundDescription, logData),
+		)
+	}
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.GetPermissionError, models.GetPermissionErrorDescription, logData),
+	)
+}
+
+// GetPermissionsHandler is a handler that gets the permissions registered in the catalogue
+func (api *API) GetPermissionsHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	offset, limit, errorResponse := api.getPaginationParameters(ctx, req)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	listOfPermissions, err := api.permissionsStore.GetPermissions(ctx, offset, limit)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.GetPermissionsError, models.GetPermissionsErrorDescription, nil),
+		)
+	}
+
+	b, err := json.Marshal(listOfPermissions)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "list_of_permissions", listOfPermissions)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// PutPermissionHandler is a handler that registers a permission in the catalogue, or updates an already registered one.
+// Services call it on startup for each permission that they enforce, so it is idempotent.
+func (api *API) PutPermissionHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	permissionID := vars["id"]
+	logData := log.Data{permissionIDKey: permissionID}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "putPermission endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	permission, err := models.CreatePermission(req.Body)
+	if err != nil {
+		return nil, handleBodyUnmarshalError(ctx, err)
+	}
+
+	if err := permission.ValidatePermission(); err != nil {
+		logData["permission_parameters"] = *permission
+		return nil, models.NewErrorResponse(http.StatusBadRequest,
+			nil,
+			models.NewError(ctx, err, models.InvalidPermissionError, err.Error(), logData),
+		)
+	}
+
+	newPermission := permission.GetPermission(permissionID)
+	updateResult, err := api.permissionsStore.UpsertPermission(ctx, newPermission)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.RegisterPermissionError, models.RegisterPermissionErrorDescription, logData),
+		)
+	}
+
+	b, err := json.Marshal(newPermission)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "permission", newPermission)
+	}
+
+	logAuditEvent(ctx, "successfully registered permission audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+
+	if updateResult.UpsertedCount > 0 {
+		return models.NewSuccessResponse(b, http.StatusCreated, nil), nil
+	}
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// GetPermissionRolesHandler is a handler that gets the roles that grant a permission, whether of their own, with a
+// matching permission pattern, or by inheriting it from a parent role. Each role includes the number of policies that
+// bind it, so that it is known who has the permission. The permission does not need to be registered in the catalogue.
+func (api *API) GetPermissionRolesHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	permissionID := vars["id"]
+	logData := log.Data{permissionIDKey: permissionID}
+
+	if err := models.ValidatePermissionPattern(permissionID); err != nil {
+		return nil, models.NewErrorResponse(http.StatusBadRequest,
+			nil,
+			models.NewError(ctx, err, models.InvalidPermissionError, err.Error(), logData),
+		)
+	}
+
+	roles, err := api.permissionsStore.GetRolesGrantingPermission(ctx, permissionID)
+	if err != nil {
+		return nil, handleGetRolesError(ctx, err)
+	}
+
+	roleIDs := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+
+	policyCounts := map[string]int{}
+	if len(roleIDs) > 0 {
+		policyCounts, err = api.permissionsStore.CountPoliciesByRole(ctx, roleIDs)
+		if err != nil {
+			return nil, models.NewErrorResponse(http.StatusInternalServerError,
+				nil,
+				models.NewError(ctx, err, models.CountPoliciesError, models.CountPoliciesErrorDescription, logData),
+			)
+		}
+	}
+
+	permissionRoles := models.PermissionRoles{
+		Permission: permissionID,
+		Count:      len(roles),
+		Items:      make([]models.PermissionRole, 0, len(roles)),
+	}
+	for _, role := range roles {
+		permissionRoles.Items = append(permissionRoles.Items, models.PermissionRole{
+			Role:        *role,
+			Inherited:   !grantsPermission(role.Permissions, permissionID),
+			PolicyCount: policyCounts[role.ID],
+		})
+	}
+
+	b, err := json.Marshal(permissionRoles)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "permission_roles", permissionRoles)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// grantsPermission returns true if one of the permissions, or permission patterns, matches the permission
+func grantsPermission(permissions []string, permission string) bool {
+	for _, pattern := range permissions {
+		if models.MatchesPermission(pattern, permission) {
+			return true
+		}
+	}
+	return false
+}
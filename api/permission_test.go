@@ -0,0 +1,286 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/api/mock"
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testCataloguePermission = "datasets:read"
+
+func TestGetPermissionHandler(t *testing.T) {
+	Convey("Given a GetPermission Handler", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetPermissionFunc: func(ctx context.Context, id string) (*models.Permission, error) {
+				switch id {
+				case testCataloguePermission:
+					return &models.Permission{ID: testCataloguePermission, Description: "read datasets", Service: "dp-dataset-api"}, nil
+				case "broken":
+					return nil, errors.New("something went wrong")
+				default:
+					return nil, apierrors.ErrPermissionNotFound
+				}
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When an existing permission is requested with its ID", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/"+testCataloguePermission, http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The matched permission is returned with status code 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedPermission := models.Permission{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedPermission)
+				So(err, ShouldBeNil)
+				So(returnedPermission, ShouldResemble, models.Permission{ID: testCataloguePermission, Description: "read datasets", Service: "dp-dataset-api"})
+			})
+		})
+
+		Convey("When a non existing permission is requested a Not Found response is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/inexistent", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(w.Body.String(), ShouldContainSubstring, models.PermissionNotFoundDescription)
+		})
+
+		Convey("When the permissions store fails an Internal Server Error response is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/broken", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestGetPermissionsHandler(t *testing.T) {
+	Convey("Given a GetPermissions Handler", t, func() {
+		expectedPermissions := models.Permissions{
+			Count:      1,
+			Offset:     1,
+			Limit:      1,
+			Items:      []models.Permission{{ID: testCataloguePermission, Description: "read datasets", Service: "dp-dataset-api"}},
+			TotalCount: 2,
+		}
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetPermissionsFunc: func(ctx context.Context, offset, limit int) (*models.Permissions, error) {
+				return &expectedPermissions, nil
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When permissions are requested using valid offset and limit values", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions?offset=1&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The paginated list of permissions is returned with status code 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedPermissions := models.Permissions{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedPermissions)
+				So(err, ShouldBeNil)
+				So(returnedPermissions, ShouldResemble, expectedPermissions)
+			})
+
+			Convey("The store is called with the requested offset and limit", func() {
+				So(mockedPermissionsStore.GetPermissionsCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.GetPermissionsCalls()[0].Offset, ShouldEqual, 1)
+				So(mockedPermissionsStore.GetPermissionsCalls()[0].Limit, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When permissions are requested with an invalid limit a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions?limit=-1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.GetPermissionsCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestPutPermissionHandler(t *testing.T) {
+	Convey("Given a permissions store", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			UpsertPermissionFunc: func(ctx context.Context, permission *models.Permission) (*models.UpdateResult, error) {
+				switch permission.ID {
+				case "existing:read":
+					return &models.UpdateResult{ModifiedCount: 1}, nil
+				case "new:read":
+					return &models.UpdateResult{UpsertedCount: 1}, nil
+				default:
+					return nil, errors.New("something went wrong")
+				}
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a new permission is registered", func() {
+			reader := strings.NewReader(`{"description": "read new things", "service": "dp-new-api"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/permissions/new:read", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the permission is upserted in the store", func() {
+				So(mockedPermissionsStore.UpsertPermissionCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.UpsertPermissionCalls()[0].Permission, ShouldResemble,
+					&models.Permission{ID: "new:read", Description: "read new things", Service: "dp-new-api"})
+			})
+
+			Convey("Then the response is 201 created, with the registered permission", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusCreated)
+				So(responseWriter.Body.String(), ShouldContainSubstring, `"id":"new:read"`)
+			})
+		})
+
+		Convey("When an already registered permission is registered again", func() {
+			reader := strings.NewReader(`{"description": "read existing things", "service": "dp-existing-api", "deprecated": true}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/permissions/existing:read", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 200 OK", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.UpsertPermissionCalls()[0].Permission.Deprecated, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a permission is registered without its mandatory fields", func() {
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/permissions/new:read", strings.NewReader(`{}`))
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request, and the store is not called", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, "missing mandatory fields: description, service")
+				So(mockedPermissionsStore.UpsertPermissionCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a permission is registered with an invalid JSON body", func() {
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/permissions/new:read", strings.NewReader(`{`))
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.UnmarshalFailedDescription)
+			})
+		})
+
+		Convey("When the permissions store fails to register the permission", func() {
+			reader := strings.NewReader(`{"description": "read broken things", "service": "dp-broken-api"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/permissions/broken:read", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 500 internal server error", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestGetPermissionRolesHandler(t *testing.T) {
+	Convey("Given a GetPermissionRoles Handler and a store with roles granting a permission", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetRolesGrantingPermissionFunc: func(ctx context.Context, permission string) ([]*models.Role, error) {
+				switch permission {
+				case testCataloguePermission:
+					return []*models.Role{
+						{ID: "admin", Name: "Admin", Permissions: []string{"datasets:*"}},
+						{ID: "publisher", Name: "Publisher", Permissions: []string{"datasets:update"}, Parents: []string{"viewer"}},
+						{ID: "viewer", Name: "Viewer", Permissions: []string{testCataloguePermission}},
+					}, nil
+				case "broken:read":
+					return nil, errors.New("something went wrong")
+				default:
+					return []*models.Role{}, nil
+				}
+			},
+			CountPoliciesByRoleFunc: func(ctx context.Context, roleIDs []string) (map[string]int, error) {
+				return map[string]int{"admin": 2, "viewer": 5}, nil
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When the roles granting the permission are requested", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/"+testCataloguePermission+"/roles", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the roles are returned with their policy counts and whether they inherit the permission", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedRoles := models.PermissionRoles{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedRoles)
+				So(err, ShouldBeNil)
+				So(returnedRoles.Permission, ShouldEqual, testCataloguePermission)
+				So(returnedRoles.Count, ShouldEqual, 3)
+				So(returnedRoles.Items, ShouldHaveLength, 3)
+
+				So(returnedRoles.Items[0].ID, ShouldEqual, "admin")
+				So(returnedRoles.Items[0].Inherited, ShouldBeFalse)
+				So(returnedRoles.Items[0].PolicyCount, ShouldEqual, 2)
+
+				So(returnedRoles.Items[1].ID, ShouldEqual, "publisher")
+				So(returnedRoles.Items[1].Inherited, ShouldBeTrue)
+				So(returnedRoles.Items[1].PolicyCount, ShouldEqual, 0)
+
+				So(returnedRoles.Items[2].ID, ShouldEqual, "viewer")
+				So(returnedRoles.Items[2].Inherited, ShouldBeFalse)
+				So(returnedRoles.Items[2].PolicyCount, ShouldEqual, 5)
+
+				So(mockedPermissionsStore.CountPoliciesByRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.CountPoliciesByRoleCalls()[0].RoleIDs, ShouldResemble, []string{"admin", "publisher", "viewer"})
+			})
+		})
+
+		Convey("When no roles grant the permission", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/unused:read/roles", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then an empty list is returned without counting policies", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldEqual, `{"permission":"unused:read","count":0,"items":[]}`)
+				So(mockedPermissionsStore.CountPoliciesByRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the permission is an invalid pattern a Bad Request response is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/data*:read/roles", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, models.InvalidPermissionError)
+			So(mockedPermissionsStore.GetRolesGrantingPermissionCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the permissions store fails an Internal Server Error response is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/permissions/broken:read/roles", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
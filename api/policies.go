@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	"github.com/ONSdigital/dp-permissions-api/apierrors"
@@ -14,7 +17,10 @@ import (
 	"github.com/gorilla/mux"
 )
 
-const policyIDKey = "policy_id"
+const (
+	policyIDKey          = "policy_id"
+	strictQueryParameter = "strict"
+)
 
 // GetPolicyHandler is a handler that gets policy by its ID from DB
 func (api *API) GetPolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
@@ -56,7 +62,8 @@ func handleGetPolicyError(ctx context.Context, err error, policyID string) *mode
 	)
 }
 
-// DeletePolicyHandler is a handler that deletes policy by its ID from DB
+// DeletePolicyHandler is a handler that deletes policy by its ID from DB. The policy is only marked as deleted, so that
+// it can be restored until it is purged after the retention period.
 func (api *API) DeletePolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
 	vars := mux.Vars(req)
 	policyID := vars["id"]
@@ -68,7 +75,15 @@ func (api *API) DeletePolicyHandler(ctx context.Context, w http.ResponseWriter,
 		// Don't fail the request here if we can't get the auth entity data, just log it and continue
 	}
 
-	err := api.permissionsStore.DeletePolicy(ctx, policyID)
+	if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if errorResponse := api.checkLockout(ctx, req, models.BundleChange{DeletedPolicyID: policyID}, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	err := api.permissionsStore.DeletePolicy(ctx, policyID, updatedBy(authEntityData))
 	if err != nil {
 		return nil, handleDeletePolicyError(ctx, err, policyID)
 	}
@@ -91,6 +106,99 @@ func handleDeletePolicyError(ctx context.Context, err error, policyID string) *m
 	)
 }
 
+// GetDeletedPoliciesHandler is a handler that lists the deleted policies that have not been purged, so that they can
+// be restored
+func (api *API) GetDeletedPoliciesHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	offset, limit, errorResponse := api.getPaginationParameters(ctx, req)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	policies, err := api.permissionsStore.GetDeletedPolicies(ctx, offset, limit)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.GetPoliciesError, models.GetPoliciesErrorDescription, nil),
+		)
+	}
+
+	b, err := json.Marshal(policies)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "deleted_policies", policies)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// RestorePolicyHandler is a handler that restores a deleted policy by its ID
+func (api *API) RestorePolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	policyID := vars["id"]
+	logData := log.Data{policyIDKey: policyID}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "restorePolicy endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	policy, err := api.permissionsStore.GetDeletedPolicy(ctx, policyID)
+	if err != nil {
+		return nil, handleRestorePolicyError(ctx, err, policyID)
+	}
+
+	// the role may have been deleted since the policy was, and a restored policy must not bind a role that does not exist
+	logData[roleIDKey] = policy.Role
+	if _, err := api.permissionsStore.GetRole(ctx, policy.Role); err != nil {
+		if err == apierrors.ErrRoleNotFound {
+			logAuditEvent(ctx, "refused to restore policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeFailure, models.PolicyRoleNotFoundDescription)
+			return nil, models.NewErrorResponse(http.StatusConflict,
+				nil,
+				models.NewError(ctx, err, models.RoleNotFoundError, models.PolicyRoleNotFoundDescription, logData),
+			)
+		}
+		return nil, models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.GetRoleError, models.GetRoleErrorDescription, logData),
+		)
+	}
+
+	if err := api.permissionsStore.RestorePolicy(ctx, policyID, updatedBy(authEntityData)); err != nil {
+		return nil, handleRestorePolicyError(ctx, err, policyID)
+	}
+
+	logAuditEvent(ctx, "successfully restored policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(nil, http.StatusNoContent, nil), nil
+}
+
+func handleRestorePolicyError(ctx context.Context, err error, policyID string) *models.ErrorResponse {
+	logData := log.Data{policyIDKey: policyID}
+	switch err {
+	case apierrors.ErrPolicyNotFound:
+		return models.NewErrorResponse(http.StatusNotFound,
+			nil,
+			models.NewError(ctx, err, models.PolicyNotFoundError, models.PolicyNotFoundDescription, logData),
+		)
+	case apierrors.ErrPolicyNotDeleted:
+		return models.NewErrorResponse(http.StatusConflict,
+			nil,
+			models.NewError(ctx, err, models.PolicyNotDeletedError, models.PolicyNotDeletedDescription, logData),
+		)
+	}
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.RestorePolicyError, models.RestorePolicyErrorDescription, logData),
+	)
+}
+
+func handlePolicyDeletedError(ctx context.Context, err error, policyID string) *models.ErrorResponse {
+	logData := log.Data{policyIDKey: policyID}
+	return models.NewErrorResponse(http.StatusConflict,
+		nil,
+		models.NewError(ctx, err, models.PolicyDeletedError, models.PolicyDeletedDescription, logData),
+	)
+}
+
 // PostPolicyHandler is a handler that creates a new policies in DB
 func (api *API) PostPolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
 	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
@@ -108,7 +216,11 @@ func (api *API) PostPolicyHandler(ctx context.Context, w http.ResponseWriter, re
 		return nil, handleValidatePolicyError(ctx, err, policy)
 	}
 
-	newPolicy, err := api.createNewPolicy(ctx, policy)
+	if errorResponse := api.checkPolicyEntities(ctx, policy); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	newPolicy, err := api.createNewPolicy(ctx, policy, updatedBy(authEntityData))
 	if err != nil {
 		return nil, handleCreateNewPolicyError(ctx, err)
 	}
@@ -131,14 +243,44 @@ func handleValidatePolicyError(ctx context.Context, err error, policy *models.Po
 	)
 }
 
-func (api *API) createNewPolicy(ctx context.Context, policy *models.PolicyInfo) (*models.Policy, error) {
+// checkPolicyEntities checks that the policy entities exist in the identity service, if an entity resolver is configured
+func (api *API) checkPolicyEntities(ctx context.Context, policy *models.PolicyInfo) *models.ErrorResponse {
+	if api.entityResolver == nil {
+		return nil
+	}
+
+	logData := log.Data{"policies_parameters": *policy}
+
+	unknownEntities, err := api.entityResolver.GetUnknownEntities(ctx, policy.Entities)
+	if err != nil {
+		return models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.ResolveEntitiesError, models.ResolveEntitiesErrorDescription, logData),
+		)
+	}
+	if len(unknownEntities) > 0 {
+		logData["unknown_entities"] = unknownEntities
+		description := "unknown entities: " + strings.Join(unknownEntities, ", ")
+		return models.NewErrorResponse(http.StatusBadRequest,
+			nil,
+			models.NewError(ctx, apierrors.ErrUnknownEntities, models.UnknownEntitiesError, description, logData),
+		)
+	}
+
+	return nil
+}
+
+func (api *API) createNewPolicy(ctx context.Context, policy *models.PolicyInfo, updatedBy string) (*models.Policy, error) {
 	policyuuid, err := uuid.NewV4()
 	if err != nil {
 		log.Error(ctx, "failed to create a new UUID for policies", err)
 		return nil, err
 	}
 
-	newPolicy, err := api.permissionsStore.AddPolicy(ctx, policy.GetPolicy(policyuuid.String()))
+	newPolicy := policy.GetPolicy(policyuuid.String())
+	newPolicy.UpdatedBy = updatedBy
+
+	newPolicy, err = api.permissionsStore.AddPolicy(ctx, newPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +315,11 @@ func (api *API) PostPolicyWithIDHandler(ctx context.Context, w http.ResponseWrit
 		return nil, handleValidatePolicyError(ctx, err, policy)
 	}
 
-	newPolicy, err := api.createPolicyWithID(ctx, policyID, policy)
+	if errorResponse := api.checkPolicyEntities(ctx, policy); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	newPolicy, err := api.createPolicyWithID(ctx, policyID, policy, updatedBy(authEntityData))
 	if err != nil {
 		return nil, handleCreatePolicyWithIDError(ctx, err, policyID)
 	}
@@ -187,7 +333,7 @@ func (api *API) PostPolicyWithIDHandler(ctx context.Context, w http.ResponseWrit
 	return models.NewSuccessResponse(b, http.StatusCreated, nil), nil
 }
 
-func (api *API) createPolicyWithID(ctx context.Context, policyID string, policy *models.PolicyInfo) (*models.Policy, error) {
+func (api *API) createPolicyWithID(ctx context.Context, policyID string, policy *models.PolicyInfo, updatedBy string) (*models.Policy, error) {
 	_, err := api.permissionsStore.GetPolicy(ctx, policyID)
 	if err == nil {
 		return nil, apierrors.ErrPolicyAlreadyExists
@@ -196,7 +342,10 @@ func (api *API) createPolicyWithID(ctx context.Context, policyID string, policy
 		return nil, err
 	}
 
-	newPolicy, err := api.permissionsStore.AddPolicy(ctx, policy.GetPolicy(policyID))
+	newPolicy := policy.GetPolicy(policyID)
+	newPolicy.UpdatedBy = updatedBy
+
+	newPolicy, err = api.permissionsStore.AddPolicy(ctx, newPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +361,9 @@ func handleCreatePolicyWithIDError(ctx context.Context, err error, policyID stri
 			models.NewError(ctx, err, models.PolicyAlreadyExistsError, models.PolicyAlreadyExistsDescription, logData),
 		)
 	}
+	if err == apierrors.ErrPolicyDeleted {
+		return handlePolicyDeletedError(ctx, err, policyID)
+	}
 
 	return models.NewErrorResponse(http.StatusInternalServerError,
 		nil,
@@ -219,12 +371,23 @@ func handleCreatePolicyWithIDError(ctx context.Context, err error, policyID stri
 	)
 }
 
-// UpdatePolicyHandler is a handler that updates policy by its ID from DB
+// UpdatePolicyHandler is a handler that updates policy by its ID from DB, creating it if it does not exist.
+// A deleted policy must be restored before it is updated.
+// If the strict query parameter is true, a policy that does not exist is not created.
 func (api *API) UpdatePolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
 	vars := mux.Vars(req)
 	policyID := vars["id"]
 	logData := log.Data{policyIDKey: policyID}
 
+	strict := false
+	if strictParameter := req.URL.Query().Get(strictQueryParameter); strictParameter != "" {
+		var err error
+		strict, err = strconv.ParseBool(strictParameter)
+		if err != nil {
+			return nil, handleInvalidQueryParameterError(ctx, err, strictQueryParameter, strictParameter)
+		}
+	}
+
 	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
 	if !ok {
 		log.Error(ctx, "updatePolicy endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
@@ -240,24 +403,266 @@ func (api *API) UpdatePolicyHandler(ctx context.Context, w http.ResponseWriter,
 		return nil, handleValidatePolicyError(ctx, err, updatePolicy)
 	}
 
-	updateResult, err := api.permissionsStore.UpdatePolicy(ctx, updatePolicy.GetPolicy(policyID))
+	if errorResponse := api.checkPolicyEntities(ctx, updatePolicy); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	policy := updatePolicy.GetPolicy(policyID)
+	policy.UpdatedBy = updatedBy(authEntityData)
+
+	if errorResponse := api.checkLockout(ctx, req, models.BundleChange{Policy: policy.GetBundlePolicy()}, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if strict {
+		if err := api.permissionsStore.ReplacePolicy(ctx, policy); err != nil {
+			if err == apierrors.ErrPolicyNotFound {
+				return nil, handleGetPolicyError(ctx, err, policyID)
+			}
+			return nil, handleUpdatePolicyError(ctx, err, policyID)
+		}
+
+		logAuditEvent(ctx, "successfully updated policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+		return models.NewSuccessResponse(nil, http.StatusOK, nil), nil
+	}
+
+	updateResult, err := api.permissionsStore.UpdatePolicy(ctx, policy)
 	if err != nil {
 		return nil, handleUpdatePolicyError(ctx, err, policyID)
 	}
 
 	logAuditEvent(ctx, "successfully updated policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
 
-	if updateResult.ModifiedCount > 0 {
-		return models.NewSuccessResponse(nil, http.StatusOK, nil), nil
-	} else {
+	// an existing policy that is updated with its current values is not modified, so only an upsert means it was created
+	if updateResult.UpsertedCount > 0 {
 		return models.NewSuccessResponse(nil, http.StatusCreated, nil), nil
 	}
+	return models.NewSuccessResponse(nil, http.StatusOK, nil), nil
 }
 
 func handleUpdatePolicyError(ctx context.Context, err error, policyID string) *models.ErrorResponse {
 	logData := log.Data{policyIDKey: policyID}
+	if err == apierrors.ErrPolicyDeleted {
+		return handlePolicyDeletedError(ctx, err, policyID)
+	}
 	return models.NewErrorResponse(http.StatusInternalServerError,
 		nil,
 		models.NewError(ctx, err, models.UpdatePolicyError, models.UpdatePolicyErrorDescription, logData),
 	)
 }
+
+// PatchPolicyHandler is a handler that changes an existing policy with a JSON merge patch or a JSON patch, according
+// to the Content-Type of the request. Unlike UpdatePolicyHandler, a policy that does not exist is not created.
+func (api *API) PatchPolicyHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	policyID := vars["id"]
+	logData := log.Data{policyIDKey: policyID}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "patchPolicy endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	policy, err := api.permissionsStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, handleGetPolicyError(ctx, err, policyID)
+	}
+
+	patchedPolicy, err := models.PatchPolicy(policy, req.Header.Get("Content-Type"), req.Body)
+	if err != nil {
+		return nil, handlePatchPolicyError(ctx, err, logData)
+	}
+
+	if err := patchedPolicy.ValidatePolicy(); err != nil {
+		return nil, handleValidatePolicyError(ctx, err, patchedPolicy)
+	}
+
+	if errorResponse := api.checkPolicyEntities(ctx, patchedPolicy); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	updatedPolicy := patchedPolicy.GetPolicy(policyID)
+	updatedPolicy.UpdatedBy = updatedBy(authEntityData)
+
+	if errorResponse := api.checkLockout(ctx, req, models.BundleChange{Policy: updatedPolicy.GetBundlePolicy()}, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	// the policy is only replaced if it is unchanged since it was read, so that a concurrent change is not lost
+	if err := api.permissionsStore.ReplacePolicyIfUnmodified(ctx, updatedPolicy, policy.Version); err != nil {
+		switch err {
+		case apierrors.ErrPolicyNotFound:
+			return nil, handleGetPolicyError(ctx, err, policyID)
+		case apierrors.ErrPolicyModified:
+			return nil, models.NewErrorResponse(http.StatusConflict,
+				nil,
+				models.NewError(ctx, err, models.PolicyModifiedError, models.PolicyModifiedDescription, logData),
+			)
+		}
+		return nil, handleUpdatePolicyError(ctx, err, policyID)
+	}
+	updatedPolicy.CreatedAt = policy.CreatedAt
+
+	b, err := json.Marshal(updatedPolicy)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "policy", updatedPolicy)
+	}
+
+	logAuditEvent(ctx, "successfully patched policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+func handlePatchPolicyError(ctx context.Context, err error, logData log.Data) *models.ErrorResponse {
+	switch {
+	case errors.Is(err, models.ErrUnsupportedPatchMediaType):
+		return models.NewErrorResponse(http.StatusUnsupportedMediaType,
+			nil,
+			models.NewError(ctx, err, models.UnsupportedMediaTypeError, err.Error(), logData),
+		)
+	case errors.Is(err, models.ErrPatchTestFailed):
+		return models.NewErrorResponse(http.StatusConflict,
+			nil,
+			models.NewError(ctx, err, models.PatchTestFailedError, models.PatchTestFailedDescription, logData),
+		)
+	case errors.Is(err, models.ErrInvalidPatch), errors.Is(err, models.ErrorReadingBody):
+		return models.NewErrorResponse(http.StatusBadRequest,
+			nil,
+			models.NewError(ctx, err, models.InvalidPatchError, err.Error(), logData),
+		)
+	default:
+		return models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.UpdatePolicyError, models.UpdatePolicyErrorDescription, logData),
+		)
+	}
+}
+
+// AddPolicyEntityHandler is a handler that adds an entity to a policy, without replacing the rest of the policy.
+// An entity that is already an entity of the policy is not added again.
+func (api *API) AddPolicyEntityHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	policyID := vars["id"]
+	entity := vars["entity"]
+	logData := log.Data{policyIDKey: policyID, "entity": entity}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "addPolicyEntity endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	if errorResponse := validatePolicyEntity(ctx, entity, logData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if errorResponse := api.checkPolicyEntities(ctx, &models.PolicyInfo{Entities: []string{entity}}); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	added, err := api.permissionsStore.AddPolicyEntity(ctx, policyID, entity, updatedBy(authEntityData))
+	if err != nil {
+		return nil, handlePolicyEntityError(ctx, err, logData)
+	}
+
+	logAuditEvent(ctx, "successfully added entity to policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+
+	if added {
+		return models.NewSuccessResponse(nil, http.StatusCreated, nil), nil
+	}
+	return models.NewSuccessResponse(nil, http.StatusOK, nil), nil
+}
+
+// RemovePolicyEntityHandler is a handler that removes an entity from a policy, without replacing the rest of the
+// policy. Removing an entity that is not an entity of the policy succeeds, so that the request can be retried.
+func (api *API) RemovePolicyEntityHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	policyID := vars["id"]
+	entity := vars["entity"]
+	logData := log.Data{policyIDKey: policyID, "entity": entity}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "removePolicyEntity endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	if errorResponse := validatePolicyEntity(ctx, entity, logData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	// the policy is only needed to check that removing the entity does not lock everyone out
+	if api.lockoutProtection {
+		policy, err := api.permissionsStore.GetPolicy(ctx, policyID)
+		if err != nil {
+			return nil, handlePolicyEntityError(ctx, err, logData)
+		}
+
+		bundlePolicy := policy.GetBundlePolicy()
+		bundlePolicy.Entities = slices.DeleteFunc(slices.Clone(policy.Entities), func(e string) bool { return e == entity })
+		if errorResponse := api.checkLockout(ctx, req, models.BundleChange{Policy: bundlePolicy}, authEntityData); errorResponse != nil {
+			return nil, errorResponse
+		}
+	}
+
+	if _, err := api.permissionsStore.RemovePolicyEntity(ctx, policyID, entity, updatedBy(authEntityData)); err != nil {
+		return nil, handlePolicyEntityError(ctx, err, logData)
+	}
+
+	logAuditEvent(ctx, "successfully removed entity from policy audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(nil, http.StatusNoContent, nil), nil
+}
+
+func validatePolicyEntity(ctx context.Context, entity string, logData log.Data) *models.ErrorResponse {
+	if models.IsValidEntity(entity) {
+		return nil
+	}
+	return models.NewErrorResponse(http.StatusBadRequest,
+		nil,
+		models.NewError(ctx, apierrors.ErrInvalidEntity, models.InvalidPolicyError, "invalid field values: entity "+entity, logData),
+	)
+}
+
+func handlePolicyEntityError(ctx context.Context, err error, logData log.Data) *models.ErrorResponse {
+	switch err {
+	case apierrors.ErrPolicyNotFound:
+		return models.NewErrorResponse(http.StatusNotFound,
+			nil,
+			models.NewError(ctx, err, models.PolicyNotFoundError, models.PolicyNotFoundDescription, logData),
+		)
+	case apierrors.ErrLastPolicyEntity:
+		return models.NewErrorResponse(http.StatusConflict,
+			nil,
+			models.NewError(ctx, err, models.LastPolicyEntityError, models.LastPolicyEntityDescription, logData),
+		)
+	default:
+		return models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.UpdatePolicyError, models.UpdatePolicyErrorDescription, logData),
+		)
+	}
+}
+
+// updatedBy returns the ID of the user or service that writes a policy, if it is known
+func updatedBy(authEntityData *authorisation.AuthEntityData) string {
+	if authEntityData == nil || authEntityData.EntityData == nil {
+		return ""
+	}
+	return authEntityData.EntityData.UserID
+}
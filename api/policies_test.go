@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	authorisation "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 	"github.com/ONSdigital/dp-permissions-api/api"
@@ -22,12 +23,14 @@ import (
 )
 
 var (
-	testPolicyID = "testPoliciesID"
+	testPolicyID    = "testPoliciesID"
+	testCreatedAt   = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testLastUpdated = time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
 )
 
 const (
-	testEntityE1 = "e1"
-	testEntityE2 = "e2"
+	testEntityE1 = "groups/e1"
+	testEntityE2 = "groups/e2"
 	testValueV1  = "v1"
 )
 
@@ -48,13 +51,14 @@ func TestSuccessfulAddPolicies(t *testing.T) {
 		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
 
 		Convey("When a POST request is made to the policies endpoint with all the policies properties", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
 
-			Convey("Then the permissions store is called to create a new policy", func() {
+			Convey("Then the permissions store is called to create a new policy, updated by the caller", func() {
 				So(len(mockedPermissionsStore.AddPolicyCalls()), ShouldEqual, 1)
+				So(mockedPermissionsStore.AddPolicyCalls()[0].Policy.UpdatedBy, ShouldEqual, testUserID)
 			})
 
 			Convey("Then the response is 201 created", func() {
@@ -82,7 +86,7 @@ func TestSuccessfulAddPolicies(t *testing.T) {
 		})
 
 		Convey("When a POST request is made to the policies endpoint without condition", func() {
-			reader := strings.NewReader(`{"entities": ["e1"], "role": "r1"}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -161,7 +165,7 @@ func TestFailedAddPoliciesWithInvalidPolicy(t *testing.T) {
 	Convey("When a POST request is made to the policies without a role", t, func() {
 		permissionsAPI := setupAPI()
 
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 		request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 		responseWriter := httptest.NewRecorder()
 		permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -181,7 +185,7 @@ func TestFailedAddPoliciesWithInvalidPolicy(t *testing.T) {
 	Convey("When a POST request is made to the policies with empty role", t, func() {
 		permissionsAPI := setupAPI()
 
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 		request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 		responseWriter := httptest.NewRecorder()
 		permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -201,7 +205,7 @@ func TestFailedAddPoliciesWithInvalidPolicy(t *testing.T) {
 	Convey("When a POST request is made to the policies with an invalid condition operator", t, func() {
 		permissionsAPI := setupAPI()
 
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "And", "values": ["v1"]}}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "And", "values": ["v1"]}}`)
 		request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 		responseWriter := httptest.NewRecorder()
 		permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -273,7 +277,7 @@ func TestFailedAddPoliciesWhenPermissionStoreFails(t *testing.T) {
 
 		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
 
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1"}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1"}`)
 		request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies", reader)
 		responseWriter := httptest.NewRecorder()
 		permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -329,7 +333,7 @@ func TestPostPolicyWithIDHandler(t *testing.T) {
 		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
 
 		Convey("When a valid request is made", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies/testPoliciesID", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -404,7 +408,7 @@ func TestPostPolicyWithIDHandler(t *testing.T) {
 		})
 
 		Convey("When a request is made but the id already exists", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies/ALREADYEXISTS", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -435,7 +439,7 @@ func TestPostPolicyWithIDHandler(t *testing.T) {
 		})
 
 		Convey("When a request is made but the datastore fails to GetPolicy", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies/GetPolicyFail", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -465,7 +469,7 @@ func TestPostPolicyWithIDHandler(t *testing.T) {
 		})
 
 		Convey("When a request is made but the datastore fails to AddPolicy", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("POST", "http://localhost:25400/v1/policies/UNIQUEID", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -496,6 +500,73 @@ func TestPostPolicyWithIDHandler(t *testing.T) {
 	})
 }
 
+func TestStrictUpdatePolicy(t *testing.T) {
+	Convey("Given a permissions store", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+				return &models.UpdateResult{UpsertedCount: 1}, nil
+			},
+			ReplacePolicyFunc: func(ctx context.Context, policy *models.Policy) error {
+				if policy.ID == "existing_policy" {
+					return nil
+				}
+				return apierrors.ErrPolicyNotFound
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When an existing policy is updated in strict mode", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/existing_policy?strict=true", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the policy is replaced by the user, without upserting it, and the response is 200", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.ReplacePolicyCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.ReplacePolicyCalls()[0].Policy.UpdatedBy, ShouldEqual, testUserID)
+				So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a policy that does not exist is updated in strict mode", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/mistyped_policy?strict=true", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 404 not found, and the policy is not created", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusNotFound)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.PolicyNotFoundError)
+				So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a policy is updated without strict mode, it is upserted by the user", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/mistyped_policy?strict=false", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusCreated)
+			So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 1)
+			So(mockedPermissionsStore.UpdatePolicyCalls()[0].Policy.UpdatedBy, ShouldEqual, testUserID)
+			So(mockedPermissionsStore.ReplacePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a policy is updated with an invalid strict query parameter, the response is 400 bad request", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/existing_policy?strict=maybe", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.ReplacePolicyCalls(), ShouldHaveLength, 0)
+			So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
 func TestGetPolicyHandler(t *testing.T) {
 	Convey("Given a GetPolicy Handler", t, func() {
 		mockedPermissionsStore := &mock.PermissionsStoreMock{
@@ -507,6 +578,14 @@ func TestGetPolicyHandler(t *testing.T) {
 						Entities:  []string{testEntityE1, testEntityE2},
 						Role:      "r1",
 						Condition: models.Condition{Attribute: "al", Operator: models.OperatorStringEquals, Values: []string{testValueV1}}}, nil
+				case "with_metadata":
+					return &models.Policy{
+						ID:          "with_metadata",
+						Entities:    []string{testEntityE1},
+						Role:        "r1",
+						CreatedAt:   testCreatedAt,
+						LastUpdated: testCreatedAt.Add(time.Hour),
+						UpdatedBy:   testUserID}, nil
 				case "NOTFOUND":
 					return nil, apierrors.ErrPolicyNotFound
 				default:
@@ -538,6 +617,26 @@ func TestGetPolicyHandler(t *testing.T) {
 			})
 		})
 
+		Convey("When a policy with metadata is requested, the metadata is returned", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/policies/with_metadata", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, `"created_at":"2024-01-02T03:04:05Z"`)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, `"last_updated":"2024-01-02T04:04:05Z"`)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, `"updated_by":"test-user"`)
+		})
+
+		Convey("When a policy without metadata is requested, the metadata is omitted", func() {
+			request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:25400/v1/policies/%s", testPolicyID), http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Body.String(), ShouldNotContainSubstring, "created_at")
+			So(responseRecorder.Body.String(), ShouldNotContainSubstring, "updated_by")
+		})
+
 		Convey("When a non existing policy id is requested a Not Found response with 404 status code is returned", func() {
 			request := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/policies/NOTFOUND", http.NoBody)
 			responseWriter := httptest.NewRecorder()
@@ -571,6 +670,8 @@ func TestSuccessfulUpdatePolicy(t *testing.T) {
 					return &models.UpdateResult{ModifiedCount: 1}, nil
 				case "new_policy":
 					return &models.UpdateResult{UpsertedCount: 1}, nil
+				case "unchanged_policy":
+					return &models.UpdateResult{}, nil
 				default:
 					return nil, fmt.Errorf("unknown policy id %q", policy.ID)
 				}
@@ -580,7 +681,7 @@ func TestSuccessfulUpdatePolicy(t *testing.T) {
 		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
 
 		Convey("When a PUT request is made to the update policies endpoint to update an existing policy", func() {
-			reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 			request, _ := http.NewRequest("PUT", "http://localhost:25400/v1/policies/existing_policy", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -601,7 +702,7 @@ func TestSuccessfulUpdatePolicy(t *testing.T) {
 		})
 
 		Convey("When a PUT request is made to the update policies endpoint with a non-existing policy id", func() {
-			reader := strings.NewReader(`{"entities": ["e1"], "role": "r1"}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
 			request, _ := http.NewRequest("PUT", "http://localhost:25400/v1/policies/new_policy", reader)
 			responseWriter := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -620,6 +721,17 @@ func TestSuccessfulUpdatePolicy(t *testing.T) {
 				So(err, ShouldEqual, io.EOF)
 			})
 		})
+
+		Convey("When a PUT request is made to the update policies endpoint with the current values of an existing policy", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request, _ := http.NewRequest("PUT", "http://localhost:25400/v1/policies/unchanged_policy", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 200, as the policy was not created", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+			})
+		})
 	})
 }
 
@@ -677,7 +789,7 @@ func TestFailedUpdatePoliciesWhenPermissionStoreFails(t *testing.T) {
 
 		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
 
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1"}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1"}`)
 		request, _ := http.NewRequest("PUT", "http://localhost:25400/v1/policies/policyid", reader)
 		responseWriter := httptest.NewRecorder()
 		permissionsAPI.Router.ServeHTTP(responseWriter, request)
@@ -704,7 +816,7 @@ func TestFailedUpdatePoliciesWhenPermissionStoreFails(t *testing.T) {
 func TestDeletePolicyHandler(t *testing.T) {
 	Convey("Given a DeletePolicy Handler", t, func() {
 		mockedPermissionsStore := &mock.PermissionsStoreMock{
-			DeletePolicyFunc: func(ctx context.Context, id string) error {
+			DeletePolicyFunc: func(ctx context.Context, id, deletedBy string) error {
 				switch id {
 				case testPolicyID:
 					return nil
@@ -737,8 +849,9 @@ func TestDeletePolicyHandler(t *testing.T) {
 			responseRecorder := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
 
-			Convey("Then the permissions store is called to delete a policy", func() {
+			Convey("Then the permissions store is called to delete a policy, deleted by the caller", func() {
 				So(len(mockedPermissionsStore.DeletePolicyCalls()), ShouldEqual, 1)
+				So(mockedPermissionsStore.DeletePolicyCalls()[0].DeletedBy, ShouldEqual, testUserID)
 			})
 
 			Convey("The matched policy is returned with status code 204", func() {
@@ -768,6 +881,185 @@ func TestDeletePolicyHandler(t *testing.T) {
 	})
 }
 
+func TestGetDeletedPoliciesHandler(t *testing.T) {
+	Convey("Given a permissions store with a deleted policy", t, func() {
+		deletedPolicy := models.Policy{
+			ID:        testPolicyID,
+			Entities:  []string{testEntityE1},
+			Role:      "r1",
+			DeletedAt: testCreatedAt,
+			DeletedBy: testUserID,
+		}
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetDeletedPoliciesFunc: func(ctx context.Context, offset, limit int) (*models.Policies, error) {
+				return &models.Policies{Count: 1, Offset: offset, Limit: limit, Items: []models.Policy{deletedPolicy}, TotalCount: 1}, nil
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When the deleted policies are requested", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/deleted-policies?offset=0&limit=10", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			Convey("Then the deleted policies are returned, with when and by whom they were deleted", func() {
+				So(responseRecorder.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.GetDeletedPoliciesCalls()[0].Limit, ShouldEqual, 10)
+
+				var policies models.Policies
+				So(json.Unmarshal(responseRecorder.Body.Bytes(), &policies), ShouldBeNil)
+				So(policies.Items, ShouldResemble, []models.Policy{deletedPolicy})
+				So(responseRecorder.Body.String(), ShouldContainSubstring, `"deleted_at":"2024-01-02T03:04:05Z"`)
+			})
+		})
+
+		Convey("When the deleted policies are requested with an invalid limit, then the response is 400 bad request", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/deleted-policies?limit=-1", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.GetDeletedPoliciesCalls(), ShouldHaveLength, 0)
+		})
+	})
+
+	Convey("Given a permissions store that fails to get the deleted policies", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetDeletedPoliciesFunc: func(ctx context.Context, offset, limit int) (*models.Policies, error) {
+				return nil, errors.New("Something went wrong")
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When the deleted policies are requested, then the response is 500 internal server error", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/deleted-policies", http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestRestorePolicyHandler(t *testing.T) {
+	Convey("Given a permissions store with a deleted policy", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetDeletedPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+				switch id {
+				case "active_policy":
+					return nil, apierrors.ErrPolicyNotDeleted
+				case "NOTFOUND":
+					return nil, apierrors.ErrPolicyNotFound
+				case "orphaned_policy":
+					return &models.Policy{ID: id, Entities: []string{"groups/e1"}, Role: "deleted_role"}, nil
+				case "get_error":
+					return nil, errors.New("Something went wrong")
+				default:
+					return &models.Policy{ID: id, Entities: []string{"groups/e1"}, Role: "r1"}, nil
+				}
+			},
+			GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+				if id == "deleted_role" {
+					return nil, apierrors.ErrRoleNotFound
+				}
+				return &models.Role{ID: id}, nil
+			},
+			RestorePolicyFunc: func(ctx context.Context, id, updatedBy string) error {
+				if id == "deleted_policy" {
+					return nil
+				}
+				return errors.New("Something went wrong")
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		restore := func(policyID string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:25400/v1/policies/%s/restore", policyID), http.NoBody)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+			return responseRecorder
+		}
+
+		Convey("When the deleted policy is restored, then it is restored by the caller and the response is 204", func() {
+			responseRecorder := restore("deleted_policy")
+			So(responseRecorder.Code, ShouldEqual, http.StatusNoContent)
+			So(mockedPermissionsStore.GetRoleCalls(), ShouldHaveLength, 1)
+			So(mockedPermissionsStore.GetRoleCalls()[0].ID, ShouldEqual, "r1")
+			So(mockedPermissionsStore.RestorePolicyCalls(), ShouldHaveLength, 1)
+			So(mockedPermissionsStore.RestorePolicyCalls()[0].UpdatedBy, ShouldEqual, testUserID)
+		})
+
+		Convey("When a policy that is not deleted is restored, then the response is 409 conflict", func() {
+			responseRecorder := restore("active_policy")
+			So(responseRecorder.Code, ShouldEqual, http.StatusConflict)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.PolicyNotDeletedError)
+			So(mockedPermissionsStore.RestorePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a policy that does not exist is restored, then the response is 404 not found", func() {
+			responseRecorder := restore("NOTFOUND")
+			So(responseRecorder.Code, ShouldEqual, http.StatusNotFound)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.PolicyNotFoundError)
+		})
+
+		Convey("When a policy whose role was deleted is restored, then the response is 409 conflict and it is not restored", func() {
+			responseRecorder := restore("orphaned_policy")
+			So(responseRecorder.Code, ShouldEqual, http.StatusConflict)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.RoleNotFoundError)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.PolicyRoleNotFoundDescription)
+			So(mockedPermissionsStore.RestorePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the store fails to get the deleted policy, then the response is 500 internal server error", func() {
+			responseRecorder := restore("get_error")
+			So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+			So(mockedPermissionsStore.RestorePolicyCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the store fails to restore a policy, then the response is 500 internal server error", func() {
+			responseRecorder := restore("XYZ")
+			So(responseRecorder.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestWriteDeletedPolicy(t *testing.T) {
+	Convey("Given a permissions store with a deleted policy", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+				return nil, apierrors.ErrPolicyNotFound
+			},
+			AddPolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.Policy, error) {
+				return nil, apierrors.ErrPolicyDeleted
+			},
+			UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+				return nil, apierrors.ErrPolicyDeleted
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a policy is created with the ID of the deleted policy, then the response is 409 conflict", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/deleted_policy", reader)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Code, ShouldEqual, http.StatusConflict)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.PolicyDeletedError)
+		})
+
+		Convey("When the deleted policy is updated, then the response is 409 conflict", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/deleted_policy", reader)
+			responseRecorder := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
+
+			So(responseRecorder.Code, ShouldEqual, http.StatusConflict)
+			So(responseRecorder.Body.String(), ShouldContainSubstring, models.PolicyDeletedError)
+		})
+	})
+}
+
 func setupAPIWithStoreWithoutAuthEntity(permissionsStore api.PermissionsStore) *api.API {
 	authMiddleware := &authorisation.MiddlewareMock{
 		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
@@ -775,7 +1067,7 @@ func setupAPIWithStoreWithoutAuthEntity(permissionsStore api.PermissionsStore) *
 		},
 	}
 
-	return api.Setup(cfg, mux.NewRouter(), permissionsStore, &mock.PermissionsBundlerMock{}, authMiddleware)
+	return api.Setup(cfg, mux.NewRouter(), permissionsStore, &mock.PermissionsBundlerMock{}, nil, nil, nil, authMiddleware)
 }
 
 func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
@@ -794,7 +1086,7 @@ func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
 			UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
 				return &models.UpdateResult{ModifiedCount: 1}, nil
 			},
-			DeletePolicyFunc: func(ctx context.Context, id string) error {
+			DeletePolicyFunc: func(ctx context.Context, id, deletedBy string) error {
 				return nil
 			},
 		}
@@ -819,7 +1111,7 @@ func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
 		})
 
 		Convey("POST /v1/policies should not return 500", func() {
-			reader := strings.NewReader(`{"entities": ["e1"], "role": "r1"}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
 			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies", reader)
 			responseRecorder := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
@@ -829,7 +1121,7 @@ func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
 		})
 
 		Convey("POST /v1/policies/{id} should not return 500", func() {
-			reader := strings.NewReader(`{"entities": ["e1"], "role": "r1"}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
 			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/new-id", reader)
 			responseRecorder := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
@@ -840,7 +1132,7 @@ func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
 		})
 
 		Convey("PUT /v1/policies/{id} should not return 500", func() {
-			reader := strings.NewReader(`{"entities": ["e1"], "role": "r1"}`)
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
 			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/policyid", reader)
 			responseRecorder := httptest.NewRecorder()
 			permissionsAPI.Router.ServeHTTP(responseRecorder, request)
@@ -850,3 +1142,371 @@ func TestPoliciesHandlersWhenAuthEntityDataMissing(t *testing.T) {
 		})
 	})
 }
+
+func TestPoliciesHandlersWithEntityResolver(t *testing.T) {
+	Convey("Given the policies handlers with an entity resolver", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			AddPolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.Policy, error) {
+				return policy, nil
+			},
+			UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+				return &models.UpdateResult{ModifiedCount: 1}, nil
+			},
+		}
+		entityResolver := &mock.EntityResolverMock{
+			GetUnknownEntitiesFunc: func(ctx context.Context, entities []string) ([]string, error) {
+				var unknown []string
+				for _, entity := range entities {
+					if entity != testEntityE1 {
+						unknown = append(unknown, entity)
+					}
+				}
+				return unknown, nil
+			},
+		}
+		permissionsAPI := setupAPIWithEntityResolver(mockedPermissionsStore, entityResolver)
+
+		Convey("When a policy is created with entities that exist", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the entities are resolved and the policy is created", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusCreated)
+				So(entityResolver.GetUnknownEntitiesCalls(), ShouldHaveLength, 1)
+				So(entityResolver.GetUnknownEntitiesCalls()[0].Entities, ShouldResemble, []string{"groups/e1"})
+			})
+		})
+
+		Convey("When a policy is created with entities that do not exist", func() {
+			reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/new-policy", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request listing the unknown entities, and the policy is not created", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.UnknownEntitiesError)
+				So(responseWriter.Body.String(), ShouldContainSubstring, "unknown entities: groups/e2")
+				So(mockedPermissionsStore.AddPolicyCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a policy is updated with entities that do not exist", func() {
+			reader := strings.NewReader(`{"entities": ["users/e2"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/policies/policyid", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request, and the policy is not updated", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(mockedPermissionsStore.UpdatePolicyCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an entity that does not exist is added to a policy", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/policyid/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request, and the entity is not added", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, "unknown entities: groups/e2")
+				So(mockedPermissionsStore.AddPolicyEntityCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a policy with invalid entity syntax is created", func() {
+			reader := strings.NewReader(`{"entities": ["group/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 400 bad request without looking up the entities", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, "invalid field values: entity group/e1")
+				So(entityResolver.GetUnknownEntitiesCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the entity resolver fails", func() {
+			entityResolver.GetUnknownEntitiesFunc = func(ctx context.Context, entities []string) ([]string, error) {
+				return nil, errors.New("identity service is unavailable")
+			}
+			reader := strings.NewReader(`{"entities": ["groups/e1"], "role": "r1"}`)
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies", reader)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the response is 500 internal server error, and the policy is not created", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusInternalServerError)
+				So(mockedPermissionsStore.AddPolicyCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestPolicyEntityHandlers(t *testing.T) {
+	Convey("Given the policy entity handlers", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			AddPolicyEntityFunc: func(ctx context.Context, policyID, entity, updatedBy string) (bool, error) {
+				switch {
+				case policyID == "NOTFOUND":
+					return false, apierrors.ErrPolicyNotFound
+				case policyID != testPolicyID:
+					return false, errors.New("Something went wrong")
+				}
+				return entity != testEntityE1, nil
+			},
+			RemovePolicyEntityFunc: func(ctx context.Context, policyID, entity, updatedBy string) (bool, error) {
+				switch {
+				case policyID == "NOTFOUND":
+					return false, apierrors.ErrPolicyNotFound
+				case policyID != testPolicyID:
+					return false, errors.New("Something went wrong")
+				case entity == testEntityE1:
+					return false, apierrors.ErrLastPolicyEntity
+				}
+				return true, nil
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a new entity is added to a policy, with its slash escaped", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/testPoliciesID/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the entity is added to the policy and status code 201 is returned", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusCreated)
+				So(mockedPermissionsStore.AddPolicyEntityCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.AddPolicyEntityCalls()[0].PolicyID, ShouldEqual, testPolicyID)
+				So(mockedPermissionsStore.AddPolicyEntityCalls()[0].Entity, ShouldEqual, testEntityE2)
+				So(mockedPermissionsStore.AddPolicyEntityCalls()[0].UpdatedBy, ShouldEqual, testUserID)
+			})
+		})
+
+		Convey("When an entity that is already an entity of the policy is added, without its slash escaped", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/testPoliciesID/entities/groups/e1", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then status code 200 is returned", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.AddPolicyEntityCalls()[0].Entity, ShouldEqual, testEntityE1)
+			})
+		})
+
+		Convey("When an invalid entity is added to a policy", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/testPoliciesID/entities/roles%2Fe1", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then status code 400 is returned, and the policy is not updated", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.InvalidPolicyError)
+				So(mockedPermissionsStore.AddPolicyEntityCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When an entity is added to a policy that does not exist, status code 404 is returned", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/NOTFOUND/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusNotFound)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.PolicyNotFoundDescription)
+		})
+
+		Convey("When adding an entity to a policy in the DB fails, status code 500 is returned", func() {
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/policies/XYZ/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("When an entity is removed from a policy", func() {
+			request := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/testPoliciesID/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then the entity is removed from the policy and status code 204 is returned", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusNoContent)
+				So(mockedPermissionsStore.RemovePolicyEntityCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.RemovePolicyEntityCalls()[0].PolicyID, ShouldEqual, testPolicyID)
+				So(mockedPermissionsStore.RemovePolicyEntityCalls()[0].Entity, ShouldEqual, testEntityE2)
+				So(mockedPermissionsStore.RemovePolicyEntityCalls()[0].UpdatedBy, ShouldEqual, testUserID)
+			})
+		})
+
+		Convey("When the last entity of a policy is removed", func() {
+			request := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/testPoliciesID/entities/groups%2Fe1", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			Convey("Then status code 409 is returned", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusConflict)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.LastPolicyEntityError)
+			})
+		})
+
+		Convey("When an entity is removed from a policy that does not exist, status code 404 is returned", func() {
+			request := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/NOTFOUND/entities/groups%2Fe2", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When an invalid entity is removed from a policy, status code 400 is returned", func() {
+			request := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/policies/testPoliciesID/entities/groups%2F", http.NoBody)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.RemovePolicyEntityCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestPatchPolicyHandler(t *testing.T) {
+	Convey("Given a PatchPolicy Handler", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+				switch id {
+				case testPolicyID:
+					return &models.Policy{
+						ID:          testPolicyID,
+						Entities:    []string{testEntityE1},
+						Role:        "r1",
+						Condition:   models.Condition{Attribute: "a1", Operator: models.OperatorStringEquals, Values: []string{testValueV1}},
+						CreatedAt:   testCreatedAt,
+						LastUpdated: testLastUpdated,
+						Version:     3}, nil
+				case "NOTFOUND":
+					return nil, apierrors.ErrPolicyNotFound
+				default:
+					return nil, errors.New("Something went wrong")
+				}
+			},
+			ReplacePolicyIfUnmodifiedFunc: func(ctx context.Context, policy *models.Policy, version int) error {
+				policy.Version = version + 1
+				return nil
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		patchRequest := func(id, contentType, body string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPatch, "http://localhost:25400/v1/policies/"+id, strings.NewReader(body))
+			request.Header.Set("Content-Type", contentType)
+			responseWriter := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(responseWriter, request)
+			return responseWriter
+		}
+
+		Convey("When the condition of a policy is changed with a merge patch", func() {
+			responseWriter := patchRequest(testPolicyID, models.MergePatchMediaType, `{"condition": {"values": ["v2"]}}`)
+
+			Convey("Then the policy is replaced if its version is unchanged, and returned with its new version and status code 200", func() {
+				expectedPolicy := &models.Policy{
+					ID:        testPolicyID,
+					Entities:  []string{testEntityE1},
+					Role:      "r1",
+					Condition: models.Condition{Attribute: "a1", Operator: models.OperatorStringEquals, Values: []string{"v2"}},
+					CreatedAt: testCreatedAt,
+					UpdatedBy: testUserID,
+					Version:   4,
+				}
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls()[0].Policy.UpdatedBy, ShouldEqual, testUserID)
+				So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls()[0].Version, ShouldEqual, 3)
+
+				policy := &models.Policy{}
+				So(json.Unmarshal(responseWriter.Body.Bytes(), policy), ShouldBeNil)
+				So(policy, ShouldResemble, expectedPolicy)
+			})
+		})
+
+		Convey("When an entity is added to a policy with a JSON patch", func() {
+			responseWriter := patchRequest(testPolicyID, models.JSONPatchMediaType, `[{"op": "add", "path": "/entities/-", "value": "groups/e2"}]`)
+
+			Convey("Then the policy is replaced with the entity added", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls()[0].Policy.Entities, ShouldResemble, []string{testEntityE1, testEntityE2})
+			})
+		})
+
+		Convey("When a patch makes the policy invalid", func() {
+			responseWriter := patchRequest(testPolicyID, models.MergePatchMediaType, `{"role": null}`)
+
+			Convey("Then status code 400 is returned, and the policy is not replaced", func() {
+				So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+				So(responseWriter.Body.String(), ShouldContainSubstring, models.InvalidPolicyError)
+				So(responseWriter.Body.String(), ShouldContainSubstring, "missing mandatory fields: role")
+				So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a JSON patch cannot be applied, status code 400 is returned", func() {
+			responseWriter := patchRequest(testPolicyID, models.JSONPatchMediaType, `[{"op": "remove", "path": "/entities/3"}]`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusBadRequest)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.InvalidPatchError)
+			So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a test operation of a JSON patch fails, status code 409 is returned", func() {
+			responseWriter := patchRequest(testPolicyID, models.JSONPatchMediaType, `[{"op": "test", "path": "/role", "value": "r2"}]`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusConflict)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.PatchTestFailedError)
+			So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the patch is not a merge patch or a JSON patch, status code 415 is returned", func() {
+			responseWriter := patchRequest(testPolicyID, "application/json", `{"role": "r2"}`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusUnsupportedMediaType)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.UnsupportedMediaTypeError)
+		})
+
+		Convey("When a policy that does not exist is patched, status code 404 is returned and no policy is created", func() {
+			responseWriter := patchRequest("NOTFOUND", models.MergePatchMediaType, `{"role": "r2"}`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusNotFound)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.PolicyNotFoundDescription)
+			So(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a policy is deleted while it is patched, status code 404 is returned", func() {
+			mockedPermissionsStore.ReplacePolicyIfUnmodifiedFunc = func(ctx context.Context, policy *models.Policy, version int) error {
+				return apierrors.ErrPolicyNotFound
+			}
+			responseWriter := patchRequest(testPolicyID, models.MergePatchMediaType, `{"role": "r2"}`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When a policy is changed by another request while it is patched, status code 409 is returned", func() {
+			mockedPermissionsStore.ReplacePolicyIfUnmodifiedFunc = func(ctx context.Context, policy *models.Policy, version int) error {
+				return apierrors.ErrPolicyModified
+			}
+			responseWriter := patchRequest(testPolicyID, models.MergePatchMediaType, `{"role": "r2"}`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusConflict)
+			So(responseWriter.Body.String(), ShouldContainSubstring, models.PolicyModifiedError)
+		})
+
+		Convey("When replacing the policy in the DB fails, status code 500 is returned", func() {
+			mockedPermissionsStore.ReplacePolicyIfUnmodifiedFunc = func(ctx context.Context, policy *models.Policy, version int) error {
+				return errors.New("Something went wrong")
+			}
+			responseWriter := patchRequest(testPolicyID, models.MergePatchMediaType, `{"role": "r2"}`)
+
+			So(responseWriter.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
@@ -3,8 +3,13 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	"github.com/ONSdigital/dp-permissions-api/apierrors"
 	"github.com/ONSdigital/dp-permissions-api/models"
 	"github.com/ONSdigital/dp-permissions-api/utils"
@@ -12,7 +17,18 @@ import (
 	"github.com/gorilla/mux"
 )
 
-// GetRoleHandler is a handler that gets a role by its ID from MongoDB
+const (
+	roleIDKey                           = "role_id"
+	deprecatedPermissionsQueryParameter = "deprecated_permissions"
+	nameQueryParameter                  = "name"
+	permissionQueryParameter            = "permission"
+	searchQueryParameter                = "q"
+	sortQueryParameter                  = "sort"
+	cascadeQueryParameter               = "cascade"
+)
+
+// GetRoleHandler is a handler that gets a role by its ID from MongoDB, including the effective permissions that it
+// has directly or inherits from its parent roles
 func (api *API) GetRoleHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
 	vars := mux.Vars(req)
 	roleID := vars["id"]
@@ -23,6 +39,11 @@ func (api *API) GetRoleHandler(ctx context.Context, w http.ResponseWriter, req *
 		return nil, handleGetRoleError(ctx, err, roleID)
 	}
 
+	role.EffectivePermissions, err = api.getEffectivePermissions(ctx, role)
+	if err != nil {
+		return nil, handleGetRoleError(ctx, err, roleID)
+	}
+
 	b, err := json.Marshal(role)
 	if err != nil {
 		return nil, handleBodyMarshalError(ctx, err, "role", role)
@@ -31,6 +52,23 @@ func (api *API) GetRoleHandler(ctx context.Context, w http.ResponseWriter, req *
 	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
 }
 
+// getEffectivePermissions resolves the permissions of the role, including those inherited from its parent roles
+func (api *API) getEffectivePermissions(ctx context.Context, role *models.Role) ([]string, error) {
+	if len(role.Parents) == 0 {
+		return role.Permissions, nil
+	}
+
+	roles, err := api.permissionsStore.GetAllRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy := models.NewRoleHierarchy(roles)
+	hierarchy[role.ID] = role
+
+	return hierarchy.EffectivePermissions(role.ID)
+}
+
 func handleGetRoleError(ctx context.Context, err error, roleID string) *models.ErrorResponse {
 	logData := log.Data{"role_id": roleID}
 	if err == apierrors.ErrRoleNotFound {
@@ -45,46 +83,102 @@ func handleGetRoleError(ctx context.Context, err error, roleID string) *models.E
 	)
 }
 
-// GetRolesHandler is a handler that gets all roles from MongoDB
+// GetRolesHandler is a handler that gets all roles from MongoDB.
+// If the deprecated_permissions query parameter is true, only roles that reference deprecated permissions are returned.
+// Otherwise, the roles can be filtered by name, permission and free text search, and sorted, with query parameters.
 func (api *API) GetRolesHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	offset, limit, errorResponse := api.getPaginationParameters(ctx, req)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	deprecatedOnly := false
+	if deprecatedParameter := req.URL.Query().Get(deprecatedPermissionsQueryParameter); deprecatedParameter != "" {
+		var err error
+		deprecatedOnly, err = strconv.ParseBool(deprecatedParameter)
+		if err != nil {
+			return nil, handleInvalidQueryParameterError(ctx, err, deprecatedPermissionsQueryParameter, deprecatedParameter)
+		}
+	}
+
+	filter, errorResponse := getRoleFilter(ctx, req)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+	if deprecatedOnly && !filter.IsEmpty() {
+		return nil, handleInvalidQueryParameterError(ctx, apierrors.ErrDeprecatedPermissionsFilter, deprecatedPermissionsQueryParameter,
+			req.URL.Query().Get(deprecatedPermissionsQueryParameter))
+	}
+
+	// get roles from MongoDB
+	var listOfRoles *models.Roles
+	var err error
+	switch {
+	case deprecatedOnly:
+		listOfRoles, err = api.permissionsStore.GetRolesWithDeprecatedPermissions(ctx, offset, limit)
+	case !filter.IsEmpty():
+		listOfRoles, err = api.permissionsStore.FindRoles(ctx, filter, offset, limit)
+	default:
+		listOfRoles, err = api.permissionsStore.GetRoles(ctx, offset, limit)
+	}
+	if err != nil {
+		return nil, handleGetRolesError(ctx, err)
+	}
+
+	b, err := json.Marshal(listOfRoles)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "list_of_roles", listOfRoles)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// getRoleFilter gets the filter and sort order of a list of roles from the query parameters
+func getRoleFilter(ctx context.Context, req *http.Request) (models.RoleFilter, *models.ErrorResponse) {
+	query := req.URL.Query()
+	filter := models.RoleFilter{
+		Name:       strings.TrimSpace(query.Get(nameQueryParameter)),
+		Permission: strings.TrimSpace(query.Get(permissionQueryParameter)),
+		Search:     strings.TrimSpace(query.Get(searchQueryParameter)),
+		Sort:       models.RoleSort(query.Get(sortQueryParameter)),
+	}
+
+	if !filter.Sort.IsValid() {
+		return models.RoleFilter{}, handleInvalidQueryParameterError(ctx, apierrors.ErrInvalidRoleSort, sortQueryParameter, string(filter.Sort))
+	}
+
+	return filter, nil
+}
+
+// getPaginationParameters validates the offset and limit query parameters, falling back to the configured defaults
+func (api *API) getPaginationParameters(ctx context.Context, req *http.Request) (offset, limit int, errorResponse *models.ErrorResponse) {
 	offsetParameter := req.URL.Query().Get("offset")
 	limitParameter := req.URL.Query().Get("limit")
 
-	offset := api.defaultOffset
-	limit := api.defaultLimit
+	offset = api.defaultOffset
+	limit = api.defaultLimit
 	var err error
 
 	if limitParameter != "" {
 		limit, err = utils.ValidatePositiveInteger(limitParameter)
 		if err != nil {
-			return nil, handleInvalidQueryParameterError(ctx, err, "limit", limitParameter)
+			return 0, 0, handleInvalidQueryParameterError(ctx, err, "limit", limitParameter)
 		}
 	}
 
 	if offsetParameter != "" {
 		offset, err = utils.ValidatePositiveInteger(offsetParameter)
 		if err != nil {
-			return nil, handleInvalidQueryParameterError(ctx, err, "offset", offsetParameter)
+			return 0, 0, handleInvalidQueryParameterError(ctx, err, "offset", offsetParameter)
 		}
 	}
 
 	if limit > api.maximumDefaultLimit {
 		err = apierrors.ErrorMaximumLimitReached(api.maximumDefaultLimit)
-		return nil, handleInvalidLimitQueryParameterMaxExceededError(ctx, err, limit, api.maximumDefaultLimit)
-	}
-
-	// get roles from MongoDB
-	listOfRoles, err := api.permissionsStore.GetRoles(ctx, offset, limit)
-	if err != nil {
-		return nil, handleGetRolesError(ctx, err)
+		return 0, 0, handleInvalidLimitQueryParameterMaxExceededError(ctx, err, limit, api.maximumDefaultLimit)
 	}
 
-	b, err := json.Marshal(listOfRoles)
-	if err != nil {
-		return nil, handleBodyMarshalError(ctx, err, "list_of_roles", listOfRoles)
-	}
-
-	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+	return offset, limit, nil
 }
 
 func handleInvalidLimitQueryParameterMaxExceededError(ctx context.Context, err error, value, maxLimit int) *models.ErrorResponse {
@@ -104,3 +198,320 @@ func handleGetRolesError(ctx context.Context, err error) *models.ErrorResponse {
 		models.NewError(ctx, err, models.GetRolesError, models.GetRolesErrorDescription, nil),
 	)
 }
+
+// PostRoleHandler is a handler that creates a new role, with an ID of its lowercased name.
+// All of the role's permissions must be registered in the permissions catalogue.
+func (api *API) PostRoleHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "postRole endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription))
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	role, err := models.CreateRole(req.Body)
+	if err != nil {
+		return nil, handleBodyUnmarshalError(ctx, err)
+	}
+
+	if errorResponse := api.validateRole(ctx, role.RoleID(), role); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	newRole, err := api.createRole(ctx, role.GetRole(role.RoleID()))
+	if err != nil {
+		return nil, handleCreateRoleError(ctx, err, role.RoleID())
+	}
+
+	b, err := json.Marshal(newRole)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "new_role", newRole)
+	}
+
+	logAuditEvent(ctx, "successfully created role audit event", authEntityData, models.ActionCreate, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(b, http.StatusCreated, nil), nil
+}
+
+func (api *API) createRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	_, err := api.permissionsStore.GetRole(ctx, role.ID)
+	if err == nil {
+		return nil, apierrors.ErrRoleAlreadyExists
+	}
+	if err != apierrors.ErrRoleNotFound {
+		return nil, err
+	}
+
+	return api.permissionsStore.AddRole(ctx, role)
+}
+
+func handleCreateRoleError(ctx context.Context, err error, roleID string) *models.ErrorResponse {
+	logData := log.Data{roleIDKey: roleID}
+
+	if err == apierrors.ErrRoleAlreadyExists {
+		return models.NewErrorResponse(http.StatusConflict,
+			nil,
+			models.NewError(ctx, err, models.RoleAlreadyExistsError, models.RoleAlreadyExistsDescription, logData),
+		)
+	}
+
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.CreateRoleError, models.CreateRoleErrorDescription, logData),
+	)
+}
+
+// UpdateRoleHandler is a handler that replaces the name and permissions of an existing role.
+// All of the role's permissions must be registered in the permissions catalogue.
+func (api *API) UpdateRoleHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	roleID := vars["id"]
+	logData := log.Data{roleIDKey: roleID}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "updateRole endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	role, err := models.CreateRole(req.Body)
+	if err != nil {
+		return nil, handleBodyUnmarshalError(ctx, err)
+	}
+
+	if errorResponse := api.validateRole(ctx, roleID, role); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	updatedRole := role.GetRole(roleID)
+	if errorResponse := api.checkLockout(ctx, req, models.BundleChange{Role: updatedRole}, authEntityData); errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if err := api.permissionsStore.UpdateRole(ctx, updatedRole); err != nil {
+		return nil, handleUpdateRoleError(ctx, err, roleID)
+	}
+
+	logAuditEvent(ctx, "successfully updated role audit event", authEntityData, models.ActionUpdate, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(nil, http.StatusOK, nil), nil
+}
+
+func handleUpdateRoleError(ctx context.Context, err error, roleID string) *models.ErrorResponse {
+	logData := log.Data{roleIDKey: roleID}
+	if err == apierrors.ErrRoleNotFound {
+		return models.NewErrorResponse(http.StatusNotFound,
+			nil,
+			models.NewError(ctx, err, models.RoleNotFoundError, models.RoleNotFoundDescription, logData),
+		)
+	}
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.UpdateRoleError, models.UpdateRoleErrorDescription, logData),
+	)
+}
+
+// validateRole checks the mandatory role fields, that every permission is either a valid wildcard pattern or registered
+// in the permissions catalogue, and that the parent roles exist without forming an inheritance cycle
+func (api *API) validateRole(ctx context.Context, roleID string, role *models.RoleInfo) *models.ErrorResponse {
+	logData := log.Data{roleIDKey: roleID, "role_parameters": *role}
+
+	if err := role.ValidateRole(); err != nil {
+		return handleInvalidRoleError(ctx, err, logData)
+	}
+
+	var concretePermissions []string
+	for _, permission := range role.Permissions {
+		if !models.IsPermissionPattern(permission) {
+			concretePermissions = append(concretePermissions, permission)
+			continue
+		}
+		if err := models.ValidatePermissionPattern(permission); err != nil {
+			return handleInvalidRoleError(ctx, err, logData)
+		}
+	}
+
+	if len(concretePermissions) > 0 {
+		unknownPermissions, err := api.permissionsStore.GetUnknownPermissions(ctx, concretePermissions)
+		if err != nil {
+			return models.NewErrorResponse(http.StatusInternalServerError,
+				nil,
+				models.NewError(ctx, err, models.GetPermissionsError, models.GetPermissionsErrorDescription, logData),
+			)
+		}
+		if len(unknownPermissions) > 0 {
+			logData["unknown_permissions"] = unknownPermissions
+			description := "unknown permissions: " + strings.Join(unknownPermissions, ", ")
+			return models.NewErrorResponse(http.StatusBadRequest,
+				nil,
+				models.NewError(ctx, apierrors.ErrUnknownPermissions, models.UnknownPermissionsError, description, logData),
+			)
+		}
+	}
+
+	if _, err := api.getEffectivePermissions(ctx, role.GetRole(roleID)); err != nil {
+		if errors.Is(err, models.ErrUnknownParentRole) || errors.Is(err, models.ErrRoleInheritanceCycle) {
+			return handleInvalidRoleError(ctx, err, logData)
+		}
+		return models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.GetRolesError, models.GetRolesErrorDescription, logData),
+		)
+	}
+
+	return nil
+}
+
+func handleInvalidRoleError(ctx context.Context, err error, logData log.Data) *models.ErrorResponse {
+	return models.NewErrorResponse(http.StatusBadRequest,
+		nil,
+		models.NewError(ctx, err, models.InvalidRoleError, err.Error(), logData),
+	)
+}
+
+// GetRolePoliciesHandler is a handler that gets the policies that bind a role to users and groups, ordered by ID
+func (api *API) GetRolePoliciesHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	roleID := vars["id"]
+	logData := log.Data{roleIDKey: roleID}
+
+	offset, limit, errorResponse := api.getPaginationParameters(ctx, req)
+	if errorResponse != nil {
+		return nil, errorResponse
+	}
+
+	if _, err := api.permissionsStore.GetRole(ctx, roleID); err != nil {
+		return nil, handleGetRoleError(ctx, err, roleID)
+	}
+
+	policies, err := api.permissionsStore.GetPoliciesByRole(ctx, roleID, offset, limit)
+	if err != nil {
+		return nil, models.NewErrorResponse(http.StatusInternalServerError,
+			nil,
+			models.NewError(ctx, err, models.GetPoliciesError, models.GetPoliciesErrorDescription, logData),
+		)
+	}
+
+	b, err := json.Marshal(policies)
+	if err != nil {
+		return nil, handleBodyMarshalError(ctx, err, "role_policies", policies)
+	}
+
+	return models.NewSuccessResponse(b, http.StatusOK, nil), nil
+}
+
+// DeleteRoleHandler is a handler that deletes a role by its ID. A role that is the parent of other roles cannot be
+// deleted. A role that is bound by policies is only deleted with its policies if the cascade query parameter is true.
+func (api *API) DeleteRoleHandler(ctx context.Context, w http.ResponseWriter, req *http.Request) (*models.SuccessResponse, *models.ErrorResponse) {
+	vars := mux.Vars(req)
+	roleID := vars["id"]
+	logData := log.Data{roleIDKey: roleID}
+
+	authEntityData, ok := authorisation.AuthEntityDataFromContext(req.Context())
+	if !ok {
+		log.Error(ctx, "deleteRole endpoint: failed to parse auth entity data", errors.New(models.EntityDataErrorDescription), logData)
+		// Don't fail the request here if we can't get the auth entity data, just log it and continue
+	}
+
+	cascade := false
+	if cascadeParameter := req.URL.Query().Get(cascadeQueryParameter); cascadeParameter != "" {
+		var err error
+		cascade, err = strconv.ParseBool(cascadeParameter)
+		if err != nil {
+			return nil, handleInvalidQueryParameterError(ctx, err, cascadeQueryParameter, cascadeParameter)
+		}
+	}
+	logData["cascade"] = cascade
+
+	if _, err := api.permissionsStore.GetRole(ctx, roleID); err != nil {
+		return nil, handleGetRoleError(ctx, err, roleID)
+	}
+
+	roles, err := api.permissionsStore.GetAllRoles(ctx)
+	if err != nil {
+		return nil, handleGetRolesError(ctx, err)
+	}
+	if childRoles := models.NewRoleHierarchy(roles).Children(roleID); len(childRoles) > 0 {
+		description := "role is a parent of roles: " + strings.Join(childRoles, ", ")
+		logAuditEvent(ctx, "refused to delete role audit event", authEntityData, models.ActionDelete, req.URL.Path, models.OutcomeFailure, description)
+		logData["child_roles"] = childRoles
+		return nil, models.NewErrorResponse(http.StatusConflict,
+			nil,
+			models.NewError(ctx, apierrors.ErrRoleHasChildRoles, models.RoleInUseError, description, logData),
+		)
+	}
+
+	if !cascade {
+		counts, err := api.permissionsStore.CountPoliciesByRole(ctx, []string{roleID})
+		if err != nil {
+			return nil, models.NewErrorResponse(http.StatusInternalServerError,
+				nil,
+				models.NewError(ctx, err, models.GetPoliciesError, models.GetPoliciesErrorDescription, logData),
+			)
+		}
+		if policyCount := counts[roleID]; policyCount > 0 {
+			description := fmt.Sprintf("role is referenced by %d policies, set cascade=true to delete them with the role", policyCount)
+			logAuditEvent(ctx, "refused to delete role audit event", authEntityData, models.ActionDelete, req.URL.Path, models.OutcomeFailure, description)
+			logData["policy_count"] = policyCount
+			return nil, models.NewErrorResponse(http.StatusConflict,
+				nil,
+				models.NewError(ctx, apierrors.ErrRoleHasPolicies, models.RoleInUseError, description, logData),
+			)
+		}
+	} else {
+		for _, policyID := range api.protectedPolicies {
+			policy, err := api.permissionsStore.GetPolicy(ctx, policyID)
+			if err == apierrors.ErrPolicyNotFound {
+				continue
+			}
+			if err != nil {
+				logData[policyIDKey] = policyID
+				return nil, models.NewErrorResponse(http.StatusInternalServerError,
+					nil,
+					models.NewError(ctx, err, models.GetPolicyError, models.GetPolicyErrorDescription, logData),
+				)
+			}
+			if policy.Role != roleID {
+				continue
+			}
+			if errorResponse := api.checkProtectedPolicy(ctx, req, policyID, authEntityData); errorResponse != nil {
+				return nil, errorResponse
+			}
+		}
+
+		if errorResponse := api.checkLockout(ctx, req, models.BundleChange{DeletedRoleID: roleID}, authEntityData); errorResponse != nil {
+			return nil, errorResponse
+		}
+
+		// the policies are deleted before the role, so that a failure leaves a role that can be deleted again
+		policyIDs, err := api.permissionsStore.DeletePoliciesByRole(ctx, roleID)
+		if err != nil {
+			return nil, models.NewErrorResponse(http.StatusInternalServerError,
+				nil,
+				models.NewError(ctx, err, models.DeletePolicyError, models.DeletePolicyErrorDescription, logData),
+			)
+		}
+		for _, policyID := range policyIDs {
+			logAuditEvent(ctx, "successfully deleted policy of deleted role audit event", authEntityData, models.ActionDelete,
+				"/v1/policies/"+policyID, models.OutcomeSuccess, "")
+		}
+	}
+
+	if err := api.permissionsStore.DeleteRole(ctx, roleID); err != nil {
+		return nil, handleDeleteRoleError(ctx, err, roleID)
+	}
+
+	logAuditEvent(ctx, "successfully deleted role audit event", authEntityData, models.ActionDelete, req.URL.Path, models.OutcomeSuccess, "")
+	return models.NewSuccessResponse(nil, http.StatusNoContent, nil), nil
+}
+
+func handleDeleteRoleError(ctx context.Context, err error, roleID string) *models.ErrorResponse {
+	logData := log.Data{roleIDKey: roleID}
+	if err == apierrors.ErrRoleNotFound {
+		return models.NewErrorResponse(http.StatusNotFound,
+			nil,
+			models.NewError(ctx, err, models.RoleNotFoundError, models.RoleNotFoundDescription, logData),
+		)
+	}
+	return models.NewErrorResponse(http.StatusInternalServerError,
+		nil,
+		models.NewError(ctx, err, models.DeleteRoleError, models.DeleteRoleErrorDescription, logData),
+	)
+}
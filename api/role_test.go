@@ -3,10 +3,12 @@ package api_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ONSdigital/dp-permissions-api/api/mock"
@@ -83,7 +85,9 @@ func TestGetRoleHandler(t *testing.T) {
 				returnedRole := models.Role{}
 				err = json.Unmarshal(payload, &returnedRole)
 				So(err, ShouldBeNil)
-				So(returnedRole, ShouldResemble, *dbRole(testRoleID1))
+				expectedRole := *dbRole(testRoleID1)
+				expectedRole.EffectivePermissions = []string{testPermission}
+				So(returnedRole, ShouldResemble, expectedRole)
 			})
 		})
 
@@ -264,3 +268,588 @@ func TestGetRolesHandler(t *testing.T) {
 		})
 	})
 }
+
+func TestGetRolesHandlerWithDeprecatedPermissions(t *testing.T) {
+	Convey("Given a GetRoles Handler and a store with roles referencing deprecated permissions", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetRolesWithDeprecatedPermissionsFunc: func(ctx context.Context, offset int, limit int) (*models.Roles, error) {
+				return &paginatedImageList, nil
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When roles with deprecated permissions are requested", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?deprecated_permissions=true&offset=1&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The roles referencing deprecated permissions are returned with status code 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedRoles := models.Roles{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedRoles)
+				So(err, ShouldBeNil)
+				So(returnedRoles, ShouldResemble, paginatedImageList)
+				So(mockedPermissionsStore.GetRolesWithDeprecatedPermissionsCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the deprecated_permissions query parameter is not a boolean a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?deprecated_permissions=maybe", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.GetRolesWithDeprecatedPermissionsCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestGetRolesHandlerWithFilter(t *testing.T) {
+	Convey("Given a GetRoles Handler and a store that finds roles", t, func() {
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			FindRolesFunc: func(ctx context.Context, filter models.RoleFilter, offset int, limit int) (*models.Roles, error) {
+				return &paginatedImageList, nil
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When roles are requested with a name, permission, search text and sort order", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?name=readonly&permission=read&q=only&sort=-name&offset=1&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the roles matching the filter are returned with status code 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedRoles := models.Roles{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedRoles)
+				So(err, ShouldBeNil)
+				So(returnedRoles, ShouldResemble, paginatedImageList)
+
+				So(mockedPermissionsStore.FindRolesCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.FindRolesCalls()[0].Filter, ShouldResemble, models.RoleFilter{
+					Name:       "readonly",
+					Permission: "read",
+					Search:     "only",
+					Sort:       models.RoleSortNameDesc,
+				})
+				So(mockedPermissionsStore.FindRolesCalls()[0].Offset, ShouldEqual, 1)
+				So(mockedPermissionsStore.FindRolesCalls()[0].Limit, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When roles are only sorted", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?sort=name", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the roles are found with the sort order", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.FindRolesCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.FindRolesCalls()[0].Filter, ShouldResemble, models.RoleFilter{Sort: models.RoleSortName})
+			})
+		})
+
+		Convey("When the sort order is not supported a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?sort=permissions", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, models.InvalidQueryParameterError)
+			So(mockedPermissionsStore.FindRolesCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When deprecated permissions are requested with a filter a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles?deprecated_permissions=true&name=readonly", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.FindRolesCalls(), ShouldHaveLength, 0)
+			So(mockedPermissionsStore.GetRolesWithDeprecatedPermissionsCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func newRoleWritePermissionsStore() *mock.PermissionsStoreMock {
+	return &mock.PermissionsStoreMock{
+		GetUnknownPermissionsFunc: func(ctx context.Context, permissions []string) ([]string, error) {
+			var unknown []string
+			for _, permission := range permissions {
+				if permission != testPermission {
+					unknown = append(unknown, permission)
+				}
+			}
+			return unknown, nil
+		},
+		GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+			if id == strings.ToLower(testRoleName) {
+				return dbRole(id), nil
+			}
+			return nil, apierrors.ErrRoleNotFound
+		},
+		AddRoleFunc: func(ctx context.Context, role *models.Role) (*models.Role, error) {
+			return role, nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, role *models.Role) error {
+			if role.ID == strings.ToLower(testRoleName) {
+				return nil
+			}
+			return apierrors.ErrRoleNotFound
+		},
+	}
+}
+
+func TestPostRoleHandler(t *testing.T) {
+	Convey("Given a PostRole Handler", t, func() {
+		mockedPermissionsStore := newRoleWritePermissionsStore()
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a role with registered permissions is created", func() {
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is stored with its lowercased name as ID and returned with status code 201", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.AddRoleCalls()[0].Role, ShouldResemble, &models.Role{ID: "editor", Name: "Editor", Permissions: []string{testPermission}})
+				So(w.Body.String(), ShouldContainSubstring, `"id":"editor"`)
+			})
+		})
+
+		Convey("When a role with unknown permissions is created", func() {
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read", "raed", "wirte"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("A status code of 400 is returned listing the unknown permissions, and the role is not stored", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, models.UnknownPermissionsError)
+				So(w.Body.String(), ShouldContainSubstring, "unknown permissions: raed, wirte")
+				So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a role without its mandatory fields is created a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", strings.NewReader(`{}`))
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, "missing mandatory fields: name, permissions")
+			So(mockedPermissionsStore.GetUnknownPermissionsCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role that already exists is created a status code of 409 is returned", func() {
+			reader := strings.NewReader(`{"name": "ReadOnly", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.RoleAlreadyExistsDescription)
+			So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is created concurrently with the same ID a status code of 409 is returned", func() {
+			mockedPermissionsStore.AddRoleFunc = func(ctx context.Context, role *models.Role) (*models.Role, error) {
+				return nil, apierrors.ErrRoleAlreadyExists
+			}
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusConflict)
+			So(w.Body.String(), ShouldContainSubstring, models.RoleAlreadyExistsDescription)
+			So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When the permissions catalogue cannot be queried a status code of 500 is returned", func() {
+			mockedPermissionsStore.GetUnknownPermissionsFunc = func(ctx context.Context, permissions []string) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			}
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestUpdateRoleHandler(t *testing.T) {
+	Convey("Given an UpdateRole Handler", t, func() {
+		mockedPermissionsStore := newRoleWritePermissionsStore()
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When an existing role is updated with registered permissions", func() {
+			reader := strings.NewReader(`{"name": "ReadOnly", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/roles/readonly", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is updated and status code 200 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedPermissionsStore.UpdateRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.UpdateRoleCalls()[0].Role, ShouldResemble, &models.Role{ID: "readonly", Name: testRoleName, Permissions: []string{testPermission}})
+			})
+		})
+
+		Convey("When an existing role is updated with unknown permissions", func() {
+			reader := strings.NewReader(`{"name": "ReadOnly", "permissions": ["read", "raed"]}`)
+			r := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/roles/readonly", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("A status code of 400 is returned, and the role is not updated", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, "unknown permissions: raed")
+				So(mockedPermissionsStore.UpdateRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a non existing role is updated a status code of 404 is returned", func() {
+			reader := strings.NewReader(`{"name": "Inexistent", "permissions": ["read"]}`)
+			r := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/roles/inexistent", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(w.Body.String(), ShouldContainSubstring, models.RoleNotFoundDescription)
+		})
+	})
+}
+
+func TestGetRoleHandlerWithParentRoles(t *testing.T) {
+	Convey("Given a GetRole Handler and roles that inherit permissions from parent roles", t, func() {
+		roles := []*models.Role{
+			{ID: "previewer", Name: "previewer", Permissions: []string{"legacy:read"}},
+			{ID: "author", Name: "author", Permissions: []string{"legacy:edit"}, Parents: []string{"previewer"}},
+			{ID: "cyclic", Name: "cyclic", Permissions: []string{"legacy:edit"}, Parents: []string{"cyclic"}},
+		}
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+				for _, role := range roles {
+					if role.ID == id {
+						copied := *role
+						return &copied, nil
+					}
+				}
+				return nil, apierrors.ErrRoleNotFound
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return roles, nil
+			},
+		}
+
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a role with a parent role is requested", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/author", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is returned with both its direct and effective permissions", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				returnedRole := models.Role{}
+				err := json.Unmarshal(w.Body.Bytes(), &returnedRole)
+				So(err, ShouldBeNil)
+				So(returnedRole, ShouldResemble, models.Role{
+					ID:                   "author",
+					Name:                 "author",
+					Permissions:          []string{"legacy:edit"},
+					Parents:              []string{"previewer"},
+					EffectivePermissions: []string{"legacy:edit", "legacy:read"},
+				})
+			})
+		})
+
+		Convey("When a role whose inheritance forms a cycle is requested, a status code of 500 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/cyclic", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestRoleHandlersWithParentRoles(t *testing.T) {
+	Convey("Given role write handlers and a store with existing roles", t, func() {
+		mockedPermissionsStore := newRoleWritePermissionsStore()
+		mockedPermissionsStore.GetAllRolesFunc = func(ctx context.Context) ([]*models.Role, error) {
+			return []*models.Role{
+				{ID: "readonly", Name: testRoleName, Permissions: []string{testPermission}},
+				{ID: "child", Name: "child", Parents: []string{"readonly"}},
+			}, nil
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a role that only inherits permissions from an existing parent role is created", func() {
+			reader := strings.NewReader(`{"name": "Editor", "parents": ["readonly"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is created with status code 201", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mockedPermissionsStore.AddRoleCalls()[0].Role.Parents, ShouldResemble, []string{"readonly"})
+				So(mockedPermissionsStore.GetUnknownPermissionsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a role with a parent role that does not exist is created, a status code of 400 is returned", func() {
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read"], "parents": ["inexistent"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, models.InvalidRoleError)
+			So(w.Body.String(), ShouldContainSubstring, "parent role not found: inexistent")
+			So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a role is updated to inherit from one of its child roles, a status code of 400 is returned", func() {
+			reader := strings.NewReader(`{"name": "ReadOnly", "permissions": ["read"], "parents": ["child"]}`)
+			r := httptest.NewRequest(http.MethodPut, "http://localhost:25400/v1/roles/readonly", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			errorResponse := struct {
+				Errors []models.Error `json:"errors"`
+			}{}
+			So(json.Unmarshal(w.Body.Bytes(), &errorResponse), ShouldBeNil)
+			So(errorResponse.Errors[0].Description, ShouldEqual, "role inherits from itself: readonly -> child -> readonly")
+			So(mockedPermissionsStore.UpdateRoleCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestRoleHandlersWithWildcardPermissions(t *testing.T) {
+	Convey("Given role write handlers", t, func() {
+		mockedPermissionsStore := newRoleWritePermissionsStore()
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a role with wildcard permissions is created", func() {
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["read", "datasets:*", "*:read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is created, and only the concrete permissions are checked against the catalogue", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mockedPermissionsStore.GetUnknownPermissionsCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.GetUnknownPermissionsCalls()[0].Permissions, ShouldResemble, []string{testPermission})
+				So(mockedPermissionsStore.AddRoleCalls()[0].Role.Permissions, ShouldResemble, []string{testPermission, "datasets:*", "*:read"})
+			})
+		})
+
+		Convey("When a role with a wildcard that does not replace a whole segment is created, a status code of 400 is returned", func() {
+			reader := strings.NewReader(`{"name": "Editor", "permissions": ["data*:read"]}`)
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:25400/v1/roles", reader)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, models.InvalidRoleError)
+			So(mockedPermissionsStore.AddRoleCalls(), ShouldHaveLength, 0)
+		})
+	})
+}
+
+func TestGetRolePoliciesHandler(t *testing.T) {
+	Convey("Given a GetRolePolicies Handler", t, func() {
+		rolePolicies := &models.Policies{
+			Items: []models.Policy{
+				{ID: "policy1", Entities: []string{"groups/publishers"}, Role: testRoleID1},
+				{ID: "policy2", Entities: []string{"users/user1"}, Role: testRoleID1},
+			},
+			Count:      2,
+			Limit:      20,
+			TotalCount: 2,
+		}
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+				if id == testRoleID1 {
+					return dbRole(testRoleID1), nil
+				}
+				return nil, apierrors.ErrRoleNotFound
+			},
+			GetPoliciesByRoleFunc: func(ctx context.Context, roleID string, offset, limit int) (*models.Policies, error) {
+				return rolePolicies, nil
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When the policies of an existing role are requested", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/testRoleID1/policies?offset=10&limit=5", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The page of policies that bind the role is returned with status code 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				policies := models.Policies{}
+				So(json.Unmarshal(w.Body.Bytes(), &policies), ShouldBeNil)
+				So(policies, ShouldResemble, *rolePolicies)
+				So(mockedPermissionsStore.GetPoliciesByRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.GetPoliciesByRoleCalls()[0].RoleID, ShouldEqual, testRoleID1)
+				So(mockedPermissionsStore.GetPoliciesByRoleCalls()[0].Offset, ShouldEqual, 10)
+				So(mockedPermissionsStore.GetPoliciesByRoleCalls()[0].Limit, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When the policies of a non existing role are requested a status code of 404 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/inexistent/policies", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(w.Body.String(), ShouldContainSubstring, models.RoleNotFoundDescription)
+			So(mockedPermissionsStore.GetPoliciesByRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When the policies are requested with an invalid limit a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/testRoleID1/policies?limit=-1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("When getting the policies from the DB fails a status code of 500 is returned", func() {
+			mockedPermissionsStore.GetPoliciesByRoleFunc = func(ctx context.Context, roleID string, offset, limit int) (*models.Policies, error) {
+				return nil, errors.New("database is broken")
+			}
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:25400/v1/roles/testRoleID1/policies", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
+
+func TestDeleteRoleHandler(t *testing.T) {
+	Convey("Given a DeleteRole Handler", t, func() {
+		policyCount := 2
+		childRole := &models.Role{ID: "child", Name: "Child", Parents: []string{"parent"}}
+		mockedPermissionsStore := &mock.PermissionsStoreMock{
+			GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+				switch id {
+				case testRoleID1, "parent":
+					return dbRole(id), nil
+				default:
+					return nil, apierrors.ErrRoleNotFound
+				}
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{dbRole(testRoleID1), dbRole("parent"), childRole}, nil
+			},
+			CountPoliciesByRoleFunc: func(ctx context.Context, roleIDs []string) (map[string]int, error) {
+				return map[string]int{testRoleID1: policyCount}, nil
+			},
+			DeletePoliciesByRoleFunc: func(ctx context.Context, roleID string) ([]string, error) {
+				return []string{"policy1", "policy2"}, nil
+			},
+			DeleteRoleFunc: func(ctx context.Context, id string) error {
+				return nil
+			},
+		}
+		permissionsAPI := setupAPIWithStore(mockedPermissionsStore)
+
+		Convey("When a role that is not referenced by any policy is deleted", func() {
+			policyCount = 0
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The role is deleted and status code 204 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.DeleteRoleCalls()[0].ID, ShouldEqual, testRoleID1)
+				So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a role that is referenced by policies is deleted without cascade", func() {
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("A status code of 409 is returned, and neither the role nor its policies are deleted", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, models.RoleInUseError)
+				So(w.Body.String(), ShouldContainSubstring, "role is referenced by 2 policies")
+				So(mockedPermissionsStore.CountPoliciesByRoleCalls()[0].RoleIDs, ShouldResemble, []string{testRoleID1})
+				So(mockedPermissionsStore.GetPoliciesByRoleCalls(), ShouldHaveLength, 0)
+				So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 0)
+				So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a role that is referenced by policies is deleted with cascade", func() {
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1?cascade=true", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("The policies of the role are deleted, then the role, and status code 204 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 1)
+				So(mockedPermissionsStore.DeletePoliciesByRoleCalls()[0].RoleID, ShouldEqual, testRoleID1)
+				So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a role that is the parent of another role is deleted, even with cascade", func() {
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/parent?cascade=true", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			Convey("A status code of 409 is returned, and nothing is deleted", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, "role is a parent of roles: child")
+				So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 0)
+				So(mockedPermissionsStore.DeletePoliciesByRoleCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a role is deleted with an invalid cascade a status code of 400 is returned", func() {
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1?cascade=maybe", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mockedPermissionsStore.DeleteRoleCalls(), ShouldHaveLength, 0)
+		})
+
+		Convey("When a non existing role is deleted a status code of 404 is returned", func() {
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/inexistent", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(w.Body.String(), ShouldContainSubstring, models.RoleNotFoundDescription)
+		})
+
+		Convey("When deleting the role from the DB fails a status code of 500 is returned", func() {
+			mockedPermissionsStore.DeleteRoleFunc = func(ctx context.Context, id string) error {
+				return errors.New("database is broken")
+			}
+			r := httptest.NewRequest(http.MethodDelete, "http://localhost:25400/v1/roles/testRoleID1?cascade=true", http.NoBody)
+			w := httptest.NewRecorder()
+			permissionsAPI.Router.ServeHTTP(w, r)
+
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
@@ -13,6 +13,25 @@ var (
 	ErrLimitAndOffset         = errors.New("offset and limit must be positive or zero")
 	ErrPolicyNotFound         = errors.New("policy not found")
 	ErrPolicyAlreadyExists    = errors.New("policy with given id already exists")
+	ErrEmptyEntity            = errors.New("entity must not be empty")
+	ErrMissingAuthToken       = errors.New("no authorisation token provided")
+	ErrEntityNotPermitted     = errors.New("caller is not permitted to view the requested entity")
+	ErrPermissionNotFound     = errors.New("permission not found")
+	ErrRoleAlreadyExists      = errors.New("role with given id already exists")
+	ErrUnknownPermissions     = errors.New("role references permissions that are not registered in the catalogue")
+	ErrUnknownEntities        = errors.New("policy references entities that do not exist")
+	ErrInvalidRoleSort        = errors.New("sort must be one of id, -id, name or -name")
+	ErrRoleHasPolicies        = errors.New("role is referenced by policies")
+	ErrRoleHasChildRoles      = errors.New("role is a parent of other roles")
+	ErrInvalidEntity          = errors.New("entity must be a user or group, e.g. groups/role-admin")
+	ErrLastPolicyEntity       = errors.New("the last entity of a policy cannot be removed")
+	ErrPolicyDeleted          = errors.New("policy with given id is deleted")
+	ErrPolicyNotDeleted       = errors.New("policy is not deleted")
+	ErrLockout                = errors.New("change would leave no entity able to change policies")
+	ErrProtectedPolicy        = errors.New("policy is protected")
+	ErrPolicyModified         = errors.New("policy was changed since it was read")
+
+	ErrDeprecatedPermissionsFilter = errors.New("deprecated_permissions cannot be combined with the name, permission, q or sort query parameters")
 )
 
 // ErrorMaximumLimitReached creates a unique error
@@ -20,6 +20,20 @@ type Config struct {
 	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
 	DefaultOffset              int           `envconfig:"DEFAULT_OFFSET"`
 	MaximumDefaultLimit        int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	BundleSigningKey           string        `envconfig:"BUNDLE_SIGNING_KEY" json:"-"`
+	BundleSigningKeyID         string        `envconfig:"BUNDLE_SIGNING_KEY_ID"`
+	IdentityAPIURL             string        `envconfig:"IDENTITY_API_URL"`
+	ServiceAuthToken           string        `envconfig:"SERVICE_AUTH_TOKEN" json:"-"`
+	ServiceEntities            []string      `envconfig:"SERVICE_ENTITIES"`
+	OtelEnabled                bool          `envconfig:"OTEL_ENABLED"`
+	OTExporterOTLPEndpoint     string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTExporterOTLPInsecure     bool          `envconfig:"OTEL_EXPORTER_OTLP_INSECURE"`
+	OTServiceName              string        `envconfig:"OTEL_SERVICE_NAME"`
+	OTBatchTimeout             time.Duration `envconfig:"OTEL_BATCH_TIMEOUT"`
+	PolicyRetentionPeriod      time.Duration `envconfig:"POLICY_RETENTION_PERIOD"`
+	PolicyPurgeInterval        time.Duration `envconfig:"POLICY_PURGE_INTERVAL"`
+	LockoutProtection          bool          `envconfig:"LOCKOUT_PROTECTION"`
+	ProtectedPolicies          []string      `envconfig:"PROTECTED_POLICIES"`
 	AuthorisationConfig        *authorisation.Config
 	MongoDB
 }
@@ -27,8 +41,9 @@ type Config struct {
 var cfg *Config
 
 const (
-	RolesCollection    = "RolesCollection"
-	PoliciesCollection = "PoliciesCollection"
+	RolesCollection       = "RolesCollection"
+	PoliciesCollection    = "PoliciesCollection"
+	PermissionsCollection = "PermissionsCollection"
 )
 
 // Get returns the default config with any modifications through environment
@@ -48,7 +63,7 @@ func Get() (*Config, error) {
 			Username:                      "",
 			Password:                      "",
 			Database:                      "permissions",
-			Collections:                   map[string]string{RolesCollection: "roles", PoliciesCollection: "policies"},
+			Collections:                   map[string]string{RolesCollection: "roles", PoliciesCollection: "policies", PermissionsCollection: "permissions"},
 			ReplicaSet:                    "",
 			IsStrongReadConcernEnabled:    false,
 			IsWriteConcernMajorityEnabled: true,
@@ -58,10 +73,20 @@ func Get() (*Config, error) {
 				IsSSL: false,
 			},
 		},
-		DefaultLimit:        20,
-		DefaultOffset:       0,
-		MaximumDefaultLimit: 1000,
-		AuthorisationConfig: authorisation.NewDefaultConfig(),
+		DefaultLimit:           20,
+		DefaultOffset:          0,
+		MaximumDefaultLimit:    1000,
+		ServiceEntities:        []string{"users/zebedee", "users/dp-*", "users/dis-*"},
+		OtelEnabled:            false,
+		OTExporterOTLPEndpoint: "localhost:4318",
+		OTExporterOTLPInsecure: false,
+		OTServiceName:          "dp-permissions-api",
+		OTBatchTimeout:         5 * time.Second,
+		PolicyRetentionPeriod:  30 * 24 * time.Hour,
+		PolicyPurgeInterval:    time.Hour,
+		LockoutProtection:      true,
+		ProtectedPolicies:      []string{"default-admin-administrator"},
+		AuthorisationConfig:    authorisation.NewDefaultConfig(),
 	}
 
 	return cfg, envconfig.Process("", cfg)
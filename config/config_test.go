@@ -32,12 +32,24 @@ func TestConfig(t *testing.T) {
 
 				So(configuration.ClusterEndpoint, ShouldEqual, "localhost:27017")
 				So(configuration.Database, ShouldEqual, "permissions")
-				So(configuration.Collections, ShouldResemble, map[string]string{RolesCollection: "roles", PoliciesCollection: "policies"})
+				So(configuration.Collections, ShouldResemble, map[string]string{RolesCollection: "roles", PoliciesCollection: "policies", PermissionsCollection: "permissions"})
 				So(configuration.IsStrongReadConcernEnabled, ShouldEqual, false)
 				So(configuration.IsWriteConcernMajorityEnabled, ShouldEqual, true)
 				So(configuration.ConnectTimeout, ShouldEqual, 5*time.Second)
 				So(configuration.QueryTimeout, ShouldEqual, 15*time.Second)
 
+				So(configuration.ServiceEntities, ShouldResemble, []string{"users/zebedee", "users/dp-*", "users/dis-*"})
+				So(configuration.OtelEnabled, ShouldBeFalse)
+				So(configuration.OTExporterOTLPInsecure, ShouldBeFalse)
+				So(configuration.OTExporterOTLPEndpoint, ShouldEqual, "localhost:4318")
+				So(configuration.OTServiceName, ShouldEqual, "dp-permissions-api")
+				So(configuration.OTBatchTimeout, ShouldEqual, 5*time.Second)
+
+				So(configuration.PolicyRetentionPeriod, ShouldEqual, 30*24*time.Hour)
+				So(configuration.PolicyPurgeInterval, ShouldEqual, time.Hour)
+				So(configuration.LockoutProtection, ShouldBeTrue)
+				So(configuration.ProtectedPolicies, ShouldResemble, []string{"default-admin-administrator"})
+
 				So(configuration.AuthorisationConfig, ShouldResemble, authorisation.NewDefaultConfig())
 			})
 
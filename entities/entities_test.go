@@ -0,0 +1,96 @@
+package entities_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/entities"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStubResolver(t *testing.T) {
+	Convey("Given a stub resolver that knows some entities", t, func() {
+		resolver := entities.NewStubResolver("groups/role-admin", "users/zebedee")
+
+		Convey("When unknown entities are requested, only the entities that the stub does not know are returned", func() {
+			unknown, err := resolver.GetUnknownEntities(context.Background(), []string{"groups/role-admin", "groups/role-admn", "users/zebedee"})
+			So(err, ShouldBeNil)
+			So(unknown, ShouldResemble, []string{"groups/role-admn"})
+		})
+	})
+}
+
+func TestIdentityResolver(t *testing.T) {
+	Convey("Given an identity API that knows a user and a group", t, func() {
+		var (
+			mu            sync.Mutex
+			serviceTokens []string
+		)
+		identityAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			serviceTokens = append(serviceTokens, r.Header.Get("Authorization"))
+			mu.Unlock()
+			switch r.URL.Path {
+			case "/v1/users/zebedee", "/v1/groups/role-admin":
+				w.WriteHeader(http.StatusOK)
+			case "/v1/groups/broken":
+				w.WriteHeader(http.StatusInternalServerError)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer identityAPI.Close()
+
+		resolver := entities.NewIdentityResolver(identityAPI.URL+"/", "service-token", []string{"users/dp-*"})
+
+		Convey("When unknown entities are requested", func() {
+			unknown, err := resolver.GetUnknownEntities(context.Background(), []string{"users/zebedee", "groups/role-admin", "users/nobody", "groups/role-admn"})
+
+			Convey("Then the entities that the identity API does not know are returned", func() {
+				So(err, ShouldBeNil)
+				So(unknown, ShouldResemble, []string{"users/nobody", "groups/role-admn"})
+			})
+
+			Convey("Then the identity API is called with the service auth token", func() {
+				So(serviceTokens, ShouldHaveLength, 4)
+				So(serviceTokens[0], ShouldEqual, "Bearer service-token")
+			})
+		})
+
+		Convey("When service users are requested, they are known without calling the identity API", func() {
+			unknown, err := resolver.GetUnknownEntities(context.Background(), []string{"users/dp-dataset-api", "groups/dp-dataset-api"})
+			So(err, ShouldBeNil)
+			So(unknown, ShouldResemble, []string{"groups/dp-dataset-api"})
+			So(serviceTokens, ShouldHaveLength, 1)
+		})
+
+		Convey("When many entities are requested, the unknown entities are returned in the order they are given", func() {
+			var requested, expected []string
+			for i := range 20 {
+				requested = append(requested, fmt.Sprintf("users/nobody%d", i), "users/zebedee")
+				expected = append(expected, fmt.Sprintf("users/nobody%d", i))
+			}
+			unknown, err := resolver.GetUnknownEntities(context.Background(), requested)
+			So(err, ShouldBeNil)
+			So(unknown, ShouldResemble, expected)
+			So(serviceTokens, ShouldHaveLength, 40)
+		})
+
+		Convey("When an entity without a known prefix is requested, it is unknown without calling the identity API", func() {
+			unknown, err := resolver.GetUnknownEntities(context.Background(), []string{"roles/admin"})
+			So(err, ShouldBeNil)
+			So(unknown, ShouldResemble, []string{"roles/admin"})
+			So(serviceTokens, ShouldBeEmpty)
+		})
+
+		Convey("When the identity API fails, an error is returned", func() {
+			unknown, err := resolver.GetUnknownEntities(context.Background(), []string{"groups/broken"})
+			So(err, ShouldNotBeNil)
+			So(unknown, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxConcurrentLookups limits the number of entities that are looked up in the identity API at the same time
+const maxConcurrentLookups = 8
+
+// IdentityResolver checks that user and group entities exist in the identity API
+type IdentityResolver struct {
+	client           dphttp.Clienter
+	identityAPIURL   string
+	serviceAuthToken string
+	serviceEntities  []string
+}
+
+// NewIdentityResolver creates an IdentityResolver for the identity API at the given URL, which is called with the given
+// service auth token. Entities matching the service entity patterns, e.g. users/dp-*, are the users of services,
+// which are not identity API users, so they are known without being looked up.
+func NewIdentityResolver(identityAPIURL, serviceAuthToken string, serviceEntities []string) *IdentityResolver {
+	return NewIdentityResolverWithClienter(dphttp.NewClient(), identityAPIURL, serviceAuthToken, serviceEntities)
+}
+
+// NewIdentityResolverWithClienter creates an IdentityResolver that uses the given HTTP client
+func NewIdentityResolverWithClienter(client dphttp.Clienter, identityAPIURL, serviceAuthToken string, serviceEntities []string) *IdentityResolver {
+	return &IdentityResolver{
+		client:           client,
+		identityAPIURL:   strings.TrimSuffix(identityAPIURL, "/"),
+		serviceAuthToken: serviceAuthToken,
+		serviceEntities:  serviceEntities,
+	}
+}
+
+// GetUnknownEntities returns the given entities that do not exist in the identity API, in the order they are given.
+// Users are looked up with GET /v1/users/{id} and groups with GET /v1/groups/{id}, concurrently.
+func (r *IdentityResolver) GetUnknownEntities(ctx context.Context, entities []string) ([]string, error) {
+	exists := make([]bool, len(entities))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentLookups)
+	for i, entity := range entities {
+		if r.isServiceEntity(entity) {
+			exists[i] = true
+			continue
+		}
+		g.Go(func() (err error) {
+			exists[i], err = r.entityExists(gctx, entity)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	for i, entity := range entities {
+		if !exists[i] {
+			unknown = append(unknown, entity)
+		}
+	}
+	return unknown, nil
+}
+
+func (r *IdentityResolver) isServiceEntity(entity string) bool {
+	for _, pattern := range r.serviceEntities {
+		if matched, _ := path.Match(pattern, entity); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *IdentityResolver) entityExists(ctx context.Context, entity string) (bool, error) {
+	var path string
+	if id, found := strings.CutPrefix(entity, models.EntityPrefixUsers); found {
+		path = "/v1/users/" + url.PathEscape(id)
+	} else if id, found := strings.CutPrefix(entity, models.EntityPrefixGroups); found {
+		path = "/v1/groups/" + url.PathEscape(id)
+	} else {
+		return false, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.identityAPIURL+path, http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	dprequest.AddServiceTokenHeader(req, r.serviceAuthToken)
+
+	resp, err := r.client.Do(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d looking up entity %s", resp.StatusCode, entity)
+	}
+}
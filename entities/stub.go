@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"context"
+)
+
+// StubResolver is an in-memory entity resolver that knows a fixed list of entities, for use in tests and local development
+type StubResolver struct {
+	entities map[string]struct{}
+}
+
+// NewStubResolver creates a StubResolver that knows the given entities, e.g. groups/role-admin
+func NewStubResolver(entities ...string) *StubResolver {
+	known := make(map[string]struct{}, len(entities))
+	for _, entity := range entities {
+		known[entity] = struct{}{}
+	}
+	return &StubResolver{entities: known}
+}
+
+// GetUnknownEntities returns the given entities that the stub does not know
+func (r *StubResolver) GetUnknownEntities(_ context.Context, entities []string) ([]string, error) {
+	var unknown []string
+	for _, entity := range entities {
+		if _, ok := r.entities[entity]; !ok {
+			unknown = append(unknown, entity)
+		}
+	}
+	return unknown, nil
+}
@@ -64,3 +64,55 @@ Feature: Behaviour of application when performing requests against /v1/policies
     When I DELETE "/v1/policies/notFound"
     Then the HTTP status code should be "404"
 
+
+  Scenario: [Test #6] A deleted policy is not found
+    Given I am an admin user
+    And I DELETE "/v1/policies/publisher"
+    When I GET "/v1/policies/publisher"
+    Then the HTTP status code should be "404"
+
+  Scenario: [Test #7] A deleted policy is listed in the deleted policies
+    Given I am an admin user
+    And I DELETE "/v1/policies/publisher"
+    When I GET "/v1/deleted-policies"
+    Then the HTTP status code should be "200"
+
+  Scenario: [Test #8] A deleted policy can be restored
+    Given I have these roles:
+      """
+      [
+          {
+              "id": "publisher",
+              "name": "Publisher",
+              "permissions": [
+                "Edit"
+              ]
+          }
+      ]
+      """
+    And I am an admin user
+    And I DELETE "/v1/policies/publisher"
+    And I POST "/v1/policies/publisher/restore"
+      """
+      """
+    When I GET "/v1/policies/publisher"
+    Then the HTTP status code should be "200"
+
+  Scenario: [Test #9] Receive conflict when restoring a policy that is not deleted
+    Given I am an admin user
+    When I POST "/v1/policies/publisher/restore"
+      """
+      """
+    Then the HTTP status code should be "409"
+
+  Scenario: [Test #10] Receive conflict when updating a deleted policy
+    Given I am an admin user
+    And I DELETE "/v1/policies/publisher"
+    When I PUT "/v1/policies/publisher"
+      """
+      {
+        "entities": ["groups/publisher"],
+        "role": "publisher"
+      }
+      """
+    Then the HTTP status code should be "409"
@@ -0,0 +1,112 @@
+Feature: Behaviour of application when doing the DELETE /v1/roles/{id} and GET /v1/roles/{id}/policies endpoints
+
+  Background:
+    Given I have these roles:
+      """
+      [
+          {
+              "id": "admin",
+              "name": "Admin",
+              "permissions": [
+                "Edit",
+                "ReadOnly"
+              ]
+          },
+          {
+              "id": "publisher",
+              "name": "Publisher",
+              "permissions": [
+                "Edit"
+              ]
+          },
+          {
+              "id": "viewer",
+              "name": "Viewer",
+              "permissions": [
+                "ReadOnly"
+              ]
+          }
+      ]
+      """
+    And I have these policies:
+      """
+      [
+          {
+              "id": "publisher-group",
+              "role": "publisher",
+              "entities": [
+                "groups/publisher"
+              ],
+              "condition": {}
+          },
+          {
+              "id": "publisher-user",
+              "role": "publisher",
+              "entities": [
+                "users/publisher@ons.gov.uk"
+              ],
+              "condition": {}
+          }
+      ]
+      """
+
+  Scenario: [Test #1] GET /v1/roles/publisher/policies returns the policies of the role
+    Given I am an admin user
+    When I GET "/v1/roles/publisher/policies"
+    Then the HTTP status code should be "200"
+    And I should receive the following JSON response:
+      """
+      {
+        "count": 2,
+        "offset": 0,
+        "limit": 20,
+        "items": [
+          {
+            "id": "publisher-group",
+            "entities": ["groups/publisher"],
+            "role": "publisher",
+            "condition": {}
+          },
+          {
+            "id": "publisher-user",
+            "entities": ["users/publisher@ons.gov.uk"],
+            "role": "publisher",
+            "condition": {}
+          }
+        ],
+        "total_count": 2
+      }
+      """
+
+  Scenario: [Test #2] DELETE /v1/roles/viewer deletes a role that is not referenced by any policy
+    Given I am an admin user
+    When I DELETE "/v1/roles/viewer"
+    Then the HTTP status code should be "204"
+
+  Scenario: [Test #3] DELETE /v1/roles/publisher is refused while policies reference the role
+    Given I am an admin user
+    When I DELETE "/v1/roles/publisher"
+    Then the HTTP status code should be "409"
+
+  Scenario: [Test #4] DELETE /v1/roles/publisher?cascade=true deletes the role and its policies
+    Given I am an admin user
+    When I DELETE "/v1/roles/publisher?cascade=true"
+    Then the HTTP status code should be "204"
+
+  Scenario: [Test #5] A policy of a role deleted with cascade=true cannot be restored
+    Given I am an admin user
+    And I DELETE "/v1/roles/publisher?cascade=true"
+    When I POST "/v1/policies/publisher-group/restore"
+      """
+      """
+    Then the HTTP status code should be "409"
+
+  Scenario: [Test #6] Receive not found when doing a DELETE for a non existent role
+    Given I am an admin user
+    When I DELETE "/v1/roles/unknown"
+    Then the HTTP status code should be "404"
+
+  Scenario: [Test #7] DELETE /v1/roles/viewer with incorrect permissions - the response status is 403 (forbidden)
+    Given I am a basic user
+    When I DELETE "/v1/roles/viewer"
+    Then the HTTP status code should be "403"
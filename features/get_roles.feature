@@ -101,3 +101,90 @@ Feature: Behaviour of application when doing the GET /v1/roles endpoint, using a
     Given I am a basic user
     When I GET "/v1/roles"
     Then the HTTP status code should be "403"
+
+  Scenario: [Test #4] GET /v1/roles filtered by name, case-insensitively
+    Given I am an admin user
+    When I GET "/v1/roles?name=PUBLISHER"
+    Then the HTTP status code should be "200"
+    And I should receive the following JSON response:
+      """
+      {
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "items": [
+          {
+            "id": "publisher",
+            "name": "Publisher",
+            "permissions": [
+              "Edit",
+              "ReadOnly"
+            ]
+          }
+        ],
+        "total_count": 1
+      }
+      """
+
+  Scenario: [Test #5] GET /v1/roles filtered by permission and sorted by name descending
+    Given I am an admin user
+    When I GET "/v1/roles?permission=Edit&sort=-name"
+    Then the HTTP status code should be "200"
+    And I should receive the following JSON response:
+      """
+      {
+        "count": 2,
+        "offset": 0,
+        "limit": 20,
+        "items": [
+          {
+            "id": "publisher",
+            "name": "Publisher",
+            "permissions": [
+              "Edit",
+              "ReadOnly"
+            ]
+          },
+          {
+            "id": "admin",
+            "name": "Admin",
+            "permissions": [
+              "CreateRole",
+              "Edit",
+              "ReadOnly"
+            ]
+          }
+        ],
+        "total_count": 2
+      }
+      """
+
+  Scenario: [Test #6] GET /v1/roles with free text search
+    Given I am an admin user
+    When I GET "/v1/roles?q=create"
+    Then the HTTP status code should be "200"
+    And I should receive the following JSON response:
+      """
+      {
+        "count": 1,
+        "offset": 0,
+        "limit": 20,
+        "items": [
+          {
+            "id": "admin",
+            "name": "Admin",
+            "permissions": [
+              "CreateRole",
+              "Edit",
+              "ReadOnly"
+            ]
+          }
+        ],
+        "total_count": 1
+      }
+      """
+
+  Scenario: [Test #7] GET /v1/roles with an unsupported sort order - the response status is 400 (bad request)
+    Given I am an admin user
+    When I GET "/v1/roles?sort=permissions"
+    Then the HTTP status code should be "400"
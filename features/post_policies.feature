@@ -6,8 +6,8 @@ Feature: Behaviour of application when doing the POST /v1/policies endpoint, usi
       """
       {
           "entities": [
-            "e1",
-            "e2"
+            "groups/e1",
+            "groups/e2"
           ],
           "role": "r1",
           "condition": {
@@ -27,8 +27,8 @@ Feature: Behaviour of application when doing the POST /v1/policies endpoint, usi
       """
       {
           "entities": [
-            "e1",
-            "e2"
+            "groups/e1",
+            "groups/e2"
           ],
           "role": "r1"
       }
@@ -76,8 +76,8 @@ Feature: Behaviour of application when doing the POST /v1/policies endpoint, usi
       """
       {
           "entities": [
-            "e1",
-            "e2"
+            "groups/e1",
+            "groups/e2"
           ],
           "condition": {
               "attribute": "a1",
@@ -95,7 +95,7 @@ Feature: Behaviour of application when doing the POST /v1/policies endpoint, usi
     When I POST "/v1/policies"
       """
       {
-          "entities": ["e1"],
+          "entities": ["groups/e1"],
           "role": "",
           "condition": {
               "attribute": "a1",
@@ -113,7 +113,7 @@ Feature: Behaviour of application when doing the POST /v1/policies endpoint, usi
     When I POST "/v1/policies"
       """
       {
-          "entities": ["e1"],
+          "entities": ["groups/e1"],
           "role": "",
           "condition": {
               "attribute": "a1",
@@ -21,8 +21,8 @@ Feature: POST /v1/policies/{id} endpoint
             """
             {
                 "entities": [
-                    "e1",
-                    "e2"
+                    "groups/e1",
+                    "groups/e2"
                 ],
                 "role": "r1",
                 "condition": {
@@ -40,8 +40,8 @@ Feature: POST /v1/policies/{id} endpoint
             {
                 "id": "new-policy",
                 "entities": [
-                    "e1",
-                    "e2"
+                    "groups/e1",
+                    "groups/e2"
                 ],
                 "role": "r1",
                 "condition": {
@@ -88,8 +88,8 @@ Feature: POST /v1/policies/{id} endpoint
             """
             {
                 "entities": [
-                    "e1",
-                    "e2"
+                    "groups/e1",
+                    "groups/e2"
                 ],
                 "role": "r1",
                 "condition": {
@@ -109,8 +109,8 @@ Feature: POST /v1/policies/{id} endpoint
             """
             {
                 "entities": [
-                    "e1",
-                    "e2"
+                    "groups/e1",
+                    "groups/e2"
                 ],
                 "role": "r1",
                 "condition": {
@@ -130,8 +130,8 @@ Feature: POST /v1/policies/{id} endpoint
             """
             {
                 "entities": [
-                    "e1",
-                    "e2"
+                    "groups/e1",
+                    "groups/e2"
                 ],
                 "role": "r1",
                 "condition": {
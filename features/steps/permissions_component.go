@@ -112,6 +112,14 @@ func getPermissionsBundle() *permsdk.Bundle {
 				},
 			},
 		},
+		models.RolesDelete: { // role
+			groupsRoleAdmin: { // groups
+				permsdk.Policy{
+					ID:        "policy1",
+					Condition: permsdk.Condition{},
+				},
+			},
+		},
 	}
 }
 
@@ -142,6 +150,9 @@ func NewPermissionsComponent(mongoURI string) (*PermissionsComponent, error) {
 	// config.Get()
 	f.Config.Username, f.Config.Password = "", ""
 	f.Config.Username, f.Config.Password = createCredsInDB(f.Config.MongoDB)
+	// The policies of the scenarios do not grant the policy administration permissions, so lockout protection would
+	// refuse every change to them
+	f.Config.LockoutProtection = false
 
 	if f.MongoClient, err = mongo.NewMongoStore(context.Background(), f.Config.MongoDB); err != nil {
 		return nil, err
@@ -8,6 +8,7 @@ import (
 	dpMongoDriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/dp-permissions-api/config"
 	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/mongo"
 
 	"github.com/cucumber/godog"
 	"go.mongodb.org/mongo-driver/bson"
@@ -50,7 +51,7 @@ func (f *PermissionsComponent) iHaveTheseRoles(rolesWriteJSON *godog.DocString)
 
 func (f *PermissionsComponent) putRolesInDatabase(ctx context.Context, mongoCollection *dpMongoDriver.Collection, roleDoc models.Role) error {
 	update := bson.M{
-		"$set": roleDoc,
+		"$set": mongo.NewRoleDocument(&roleDoc),
 		"$setOnInsert": bson.M{
 			"last_updated": time.Now(),
 		},
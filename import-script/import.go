@@ -11,6 +11,7 @@ import (
 
 	dpMongodb "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/mongo"
 	"github.com/ONSdigital/log.go/v2/log"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -32,11 +33,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	importRoles(ctx, mongoConnection.Collection(cfg.Collections[config.RolesCollection]))
+	roles := importRoles(ctx, mongoConnection.Collection(cfg.Collections[config.RolesCollection]))
+	importPermissions(ctx, mongoConnection.Collection(cfg.Collections[config.PermissionsCollection]), roles)
 	importPolicies(ctx, mongoConnection.Collection(cfg.Collections[config.PoliciesCollection]))
 }
 
-func importRoles(ctx context.Context, mongoCollection *dpMongodb.Collection) {
+func importRoles(ctx context.Context, mongoCollection *dpMongodb.Collection) []models.Role {
 	filename := "roles.json"
 	fileLocation := "./" + filename
 	f, err := os.Open(fileLocation)
@@ -62,7 +64,7 @@ func importRoles(ctx context.Context, mongoCollection *dpMongodb.Collection) {
 		role.ID = strings.ToLower(role.Name)
 		logData := log.Data{"role": role}
 
-		_, err = mongoCollection.UpsertById(ctx, role.ID, bson.M{"$set": role})
+		_, err = mongoCollection.UpsertById(ctx, role.ID, bson.M{"$set": mongo.NewRoleDocument(&role)})
 		if err != nil {
 			log.Error(ctx, "failed to upsert role document", err, logData)
 			os.Exit(1)
@@ -70,6 +72,36 @@ func importRoles(ctx context.Context, mongoCollection *dpMongodb.Collection) {
 
 		log.Info(ctx, "successfully put role into mongo", logData)
 	}
+
+	return res
+}
+
+// importPermissions registers every permission referenced by the imported roles in the permissions catalogue.
+// Permissions that are already registered keep the description, service and deprecation set by their owning service.
+func importPermissions(ctx context.Context, mongoCollection *dpMongodb.Collection, roles []models.Role) {
+	for _, role := range roles {
+		for _, id := range role.Permissions {
+			// a wildcard permission is a pattern matching permissions of the catalogue, not a permission itself
+			if models.IsPermissionPattern(id) {
+				continue
+			}
+			service, _, _ := strings.Cut(id, ":")
+			permission := bson.M{
+				"description": "imported from roles.json",
+				"service":     service,
+				"deprecated":  false,
+			}
+			logData := log.Data{"permission_id": id}
+
+			_, err := mongoCollection.UpsertById(ctx, id, bson.M{"$setOnInsert": permission})
+			if err != nil {
+				log.Error(ctx, "failed to upsert permission document", err, logData)
+				os.Exit(1)
+			}
+		}
+	}
+
+	log.Info(ctx, "successfully put permissions into mongo")
 }
 
 func importPolicies(ctx context.Context, mongoCollection *dpMongodb.Collection) {
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/utils"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "permissions_api"
+
+// unmatchedRoute is the route label for requests that did not match a route, to keep the label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// otherMethod is the method label for requests with a non-standard method, to keep the label cardinality bounded
+const otherMethod = "other"
+
+// standardMethods are the HTTP methods that are used as method labels
+var standardMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodPost:    {},
+	http.MethodPut:     {},
+	http.MethodPatch:   {},
+	http.MethodDelete:  {},
+	http.MethodConnect: {},
+	http.MethodOptions: {},
+	http.MethodTrace:   {},
+}
+
+// routeKey is the context key of the route label of a request, which is set by the router when it matches a route
+type routeKey struct{}
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route template, method and status code",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by route template and method",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	bundleBuildDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "bundle_build_duration_seconds",
+		Help:      "Duration of building the permissions bundle",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	bundleBuildErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bundle_build_errors_total",
+		Help:      "Number of failed attempts to build the permissions bundle",
+	})
+
+	bundlePermissions = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bundle_permissions",
+		Help:      "Number of permissions in the last built permissions bundle",
+	})
+
+	bundleEntities = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bundle_entities",
+		Help:      "Number of distinct entities in the last built permissions bundle",
+	})
+
+	bundlePolicies = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bundle_policies",
+		Help:      "Number of distinct policies in the last built permissions bundle",
+	})
+
+	mongoOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Duration of Mongo operations by operation",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	mongoOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_errors_total",
+		Help:      "Number of failed Mongo operations by operation",
+	}, []string{"operation"})
+)
+
+// Handler returns the HTTP handler that exposes the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware records the number and duration of HTTP requests to the router. It wraps the whole router, rather than
+// being added to it with Use, so that requests that do not match a route are counted too. Requests are labelled with
+// the matched route template (e.g. /v1/policies/{id}) rather than the request path, which is recorded by the router
+// when it matches the request, so that the request is only matched once.
+func Middleware(router *mux.Router) http.Handler {
+	router.Use(recordRoute)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := utils.NewStatusRecorder(w)
+
+		route := unmatchedRoute
+		router.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), routeKey{}, &route)))
+
+		method := methodLabel(r.Method)
+		httpRequests.WithLabelValues(route, method, strconv.Itoa(recorder.Status)).Inc()
+		httpRequestDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+	})
+}
+
+// recordRoute is a router middleware that records the template of the route matched by the router as the route label
+// of the request. It is only called for requests that match a route.
+func recordRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+			if template := utils.RouteTemplate(r); template != "" {
+				*route = template
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// methodLabel returns the method label of a request method, which is otherMethod for a non-standard method, as any
+// method can be sent
+func methodLabel(method string) string {
+	if _, ok := standardMethods[method]; ok {
+		return method
+	}
+	return otherMethod
+}
+
+// ObserveBundle records the duration and outcome of building the permissions bundle, and the size of a built bundle
+func ObserveBundle(duration time.Duration, bundle models.Bundle, err error) {
+	bundleBuildDuration.Observe(duration.Seconds())
+	if err != nil {
+		bundleBuildErrors.Inc()
+		return
+	}
+
+	entities := map[string]struct{}{}
+	policies := map[string]struct{}{}
+	for _, entityLookup := range bundle {
+		for entity, entityPolicies := range entityLookup {
+			entities[entity] = struct{}{}
+			for _, policy := range entityPolicies {
+				policies[policy.ID] = struct{}{}
+			}
+		}
+	}
+
+	bundlePermissions.Set(float64(len(bundle)))
+	bundleEntities.Set(float64(len(entities)))
+	bundlePolicies.Set(float64(len(policies)))
+}
+
+// ObserveMongoOperation records the duration and outcome of a Mongo operation
+func ObserveMongoOperation(operation string, duration time.Duration, err error) {
+	mongoOperationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		mongoOperationErrors.WithLabelValues(operation).Inc()
+	}
+}
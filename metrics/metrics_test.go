@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router instrumented with the metrics middleware", t, func() {
+		matches := 0
+		r := mux.NewRouter()
+		r.HandleFunc("/v1/policies/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}).Methods(http.MethodGet).MatcherFunc(func(r *http.Request, match *mux.RouteMatch) bool {
+			matches++
+			return true
+		})
+		handler := Middleware(r)
+
+		counter := httpRequests.WithLabelValues("/v1/policies/{id}", http.MethodGet, "404")
+		before := testutil.ToFloat64(counter)
+
+		Convey("When requests are made to the route with different IDs", func() {
+			for _, id := range []string{"a", "b"} {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/policies/"+id, http.NoBody))
+			}
+
+			Convey("Then the requests are counted against the route template with the response status code", func() {
+				So(testutil.ToFloat64(counter)-before, ShouldEqual, 2)
+			})
+
+			Convey("Then each request is only matched to the route once", func() {
+				So(matches, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When requests are made that do not match a route", func() {
+			notFound := httpRequests.WithLabelValues(unmatchedRoute, http.MethodGet, "404")
+			methodNotAllowed := httpRequests.WithLabelValues(unmatchedRoute, http.MethodDelete, "405")
+			notFoundBefore, methodNotAllowedBefore := testutil.ToFloat64(notFound), testutil.ToFloat64(methodNotAllowed)
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/unknown", http.NoBody))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/v1/policies/a", http.NoBody))
+
+			Convey("Then the requests are counted as unmatched", func() {
+				So(testutil.ToFloat64(notFound)-notFoundBefore, ShouldEqual, 1)
+				So(testutil.ToFloat64(methodNotAllowed)-methodNotAllowedBefore, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When requests are made with non-standard methods", func() {
+			other := httpRequests.WithLabelValues(unmatchedRoute, otherMethod, "404")
+			otherBefore := testutil.ToFloat64(other)
+
+			for _, method := range []string{"FOO", "BAR"} {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/v1/unknown", http.NoBody))
+			}
+
+			Convey("Then the requests are counted with the other method label", func() {
+				So(testutil.ToFloat64(other)-otherBefore, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestObserveBundle(t *testing.T) {
+	Convey("Given a permissions bundle", t, func() {
+		bundle := models.Bundle{
+			"datasets:read": {
+				"groups/admin":  {{ID: "policy1"}},
+				"groups/viewer": {{ID: "policy2"}},
+			},
+			"datasets:edit": {
+				"groups/admin": {{ID: "policy1"}},
+			},
+		}
+
+		Convey("When the bundle is built successfully", func() {
+			ObserveBundle(time.Millisecond, bundle, nil)
+
+			Convey("Then the size of the bundle is recorded", func() {
+				So(testutil.ToFloat64(bundlePermissions), ShouldEqual, 2)
+				So(testutil.ToFloat64(bundleEntities), ShouldEqual, 2)
+				So(testutil.ToFloat64(bundlePolicies), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When building the bundle fails", func() {
+			ObserveBundle(time.Millisecond, bundle, nil)
+			before := testutil.ToFloat64(bundleBuildErrors)
+			ObserveBundle(time.Millisecond, nil, errors.New("mongo is down"))
+
+			Convey("Then the error is counted and the size of the last built bundle is kept", func() {
+				So(testutil.ToFloat64(bundleBuildErrors)-before, ShouldEqual, 1)
+				So(testutil.ToFloat64(bundlePermissions), ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestObserveMongoOperation(t *testing.T) {
+	Convey("Given the number of failed GetRole operations", t, func() {
+		errorsCounter := mongoOperationErrors.WithLabelValues("GetRole")
+		before := testutil.ToFloat64(errorsCounter)
+
+		Convey("When a successful and a failed operation are observed", func() {
+			ObserveMongoOperation("GetRole", time.Millisecond, nil)
+			ObserveMongoOperation("GetRole", time.Millisecond, errors.New("mongo is down"))
+
+			Convey("Then only the failed operation is counted as an error", func() {
+				So(testutil.ToFloat64(errorsCounter)-before, ShouldEqual, 1)
+				So(testutil.CollectAndCount(mongoOperationDuration), ShouldBeGreaterThan, 0)
+			})
+		})
+	})
+}
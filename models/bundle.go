@@ -1,5 +1,10 @@
 package models
 
+import (
+	"maps"
+	"slices"
+)
+
 // EntityIDToPolicies maps an entity ID to a slice of policies.
 type EntityIDToPolicies map[string][]*BundlePolicy
 
@@ -14,3 +19,138 @@ type BundlePolicy struct {
 	Role      string    `bson:"role"      json:"-"`
 	Condition Condition `bson:"condition" json:"condition,omitempty"`
 }
+
+// FilterByEntities returns a copy of the bundle that only contains the policies of the given entities.
+// Permissions that are not granted to any of the given entities are omitted.
+func (bundle Bundle) FilterByEntities(entities []string) Bundle {
+	filtered := Bundle{}
+
+	for permission, entityLookup := range bundle {
+		for _, entity := range entities {
+			policies, ok := entityLookup[entity]
+			if !ok {
+				continue
+			}
+
+			if filtered[permission] == nil {
+				filtered[permission] = EntityIDToPolicies{}
+			}
+			filtered[permission][entity] = policies
+		}
+	}
+
+	return filtered
+}
+
+// PolicyAdministrationPermissions are the permissions needed to repair the policies through the API. A change that
+// leaves none of them held by any entity would lock everyone out of the API.
+var PolicyAdministrationPermissions = []string{PoliciesCreate, PoliciesUpdate, PoliciesDelete}
+
+// UnheldPermissions returns the given permissions that no entity holds in the bundle without a condition, as a
+// permission that is only held with a condition cannot be relied on to repair the policies.
+func (bundle Bundle) UnheldPermissions(permissions []string) []string {
+	unheld := []string{}
+
+	for _, permission := range permissions {
+		entities := slices.Collect(maps.Keys(bundle[permission]))
+		if !bundle.HoldsUnconditionally(permission, entities) {
+			unheld = append(unheld, permission)
+		}
+	}
+
+	return unheld
+}
+
+// HoldsUnconditionally returns true if any of the given entities hold the permission through a policy without a
+// condition.
+func (bundle Bundle) HoldsUnconditionally(permission string, entities []string) bool {
+	entityLookup := bundle[permission]
+
+	for _, entity := range entities {
+		for _, policy := range entityLookup[entity] {
+			if policy.Condition.IsEmpty() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BundleChange is a change to a policy or a role that has not been stored yet, so that the permissions bundle that
+// would result from the change can be built before it is stored.
+type BundleChange struct {
+	Policy          *BundlePolicy // a created or replaced policy
+	DeletedPolicyID string
+	Role            *Role // a created or replaced role
+	DeletedRoleID   string
+}
+
+// Apply returns the policies and roles with the change applied. The policies of a deleted role are removed with it.
+func (change BundleChange) Apply(policies []*BundlePolicy, roles []*Role) ([]*BundlePolicy, []*Role) {
+	changedPolicies := make([]*BundlePolicy, 0, len(policies)+1)
+	for _, policy := range policies {
+		switch {
+		case change.Policy != nil && policy.ID == change.Policy.ID:
+		case policy.ID == change.DeletedPolicyID:
+		case change.DeletedRoleID != "" && policy.Role == change.DeletedRoleID:
+		default:
+			changedPolicies = append(changedPolicies, policy)
+		}
+	}
+	if change.Policy != nil {
+		changedPolicies = append(changedPolicies, change.Policy)
+	}
+
+	changedRoles := make([]*Role, 0, len(roles)+1)
+	for _, role := range roles {
+		switch {
+		case change.Role != nil && role.ID == change.Role.ID:
+		case change.DeletedRoleID != "" && role.ID == change.DeletedRoleID:
+		default:
+			changedRoles = append(changedRoles, role)
+		}
+	}
+	if change.Role != nil {
+		changedRoles = append(changedRoles, change.Role)
+	}
+
+	return changedPolicies, changedRoles
+}
+
+// NormalisedBundle is an alternative encoding of the permissions bundle that references policies by ID,
+// rather than repeating each policy for every permission and entity it applies to.
+type NormalisedBundle struct {
+	Policies    map[string]*BundlePolicy       `json:"policies"`
+	Permissions map[string]map[string][]string `json:"permissions"`
+}
+
+// Media types of the permissions bundle encodings
+const (
+	BundleMediaTypeJSON       = "application/json"
+	BundleMediaTypeNormalised = "application/vnd.ons.permissions-bundle.normalised+json"
+)
+
+// Normalise converts the bundle into its normalised encoding
+func (bundle Bundle) Normalise() NormalisedBundle {
+	normalised := NormalisedBundle{
+		Policies:    map[string]*BundlePolicy{},
+		Permissions: make(map[string]map[string][]string, len(bundle)),
+	}
+
+	for permission, entityLookup := range bundle {
+		entityPolicyIDs := make(map[string][]string, len(entityLookup))
+
+		for entity, policies := range entityLookup {
+			policyIDs := make([]string, 0, len(policies))
+			for _, policy := range policies {
+				normalised.Policies[policy.ID] = policy
+				policyIDs = append(policyIDs, policy.ID)
+			}
+			entityPolicyIDs[entity] = policyIDs
+		}
+
+		normalised.Permissions[permission] = entityPolicyIDs
+	}
+
+	return normalised
+}
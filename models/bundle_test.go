@@ -0,0 +1,197 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBundle_FilterByEntities(t *testing.T) {
+	Convey("Given a bundle with policies for several entities", t, func() {
+		adminPolicy := &BundlePolicy{ID: "admin"}
+		viewerPolicy := &BundlePolicy{ID: "viewer"}
+		bundle := Bundle{
+			"legacy:read": {
+				"groups/admin":  {adminPolicy},
+				"groups/viewer": {viewerPolicy},
+			},
+			"legacy:update": {
+				"groups/admin": {adminPolicy},
+			},
+		}
+
+		Convey("When the bundle is filtered by an entity", func() {
+			filtered := bundle.FilterByEntities([]string{"groups/viewer"})
+
+			Convey("Then only the permissions and policies of that entity are returned", func() {
+				So(filtered, ShouldResemble, Bundle{
+					"legacy:read": {
+						"groups/viewer": {viewerPolicy},
+					},
+				})
+			})
+
+			Convey("Then the original bundle is not modified", func() {
+				So(bundle["legacy:read"], ShouldHaveLength, 2)
+				So(bundle["legacy:update"], ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the bundle is filtered by an unknown entity", func() {
+			filtered := bundle.FilterByEntities([]string{"users/unknown"})
+
+			Convey("Then an empty bundle is returned", func() {
+				So(filtered, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestBundle_Normalise(t *testing.T) {
+	Convey("Given a bundle that repeats a policy for several permissions", t, func() {
+		adminPolicy := &BundlePolicy{ID: "admin"}
+		viewerPolicy := &BundlePolicy{
+			ID:        "viewer",
+			Condition: Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"c1"}},
+		}
+		bundle := Bundle{
+			"legacy:read": {
+				"groups/admin":  {adminPolicy},
+				"groups/viewer": {viewerPolicy},
+			},
+			"legacy:update": {
+				"groups/admin": {adminPolicy},
+			},
+		}
+
+		Convey("When the bundle is normalised", func() {
+			normalised := bundle.Normalise()
+
+			Convey("Then each policy is only included once", func() {
+				So(normalised.Policies, ShouldResemble, map[string]*BundlePolicy{
+					"admin":  adminPolicy,
+					"viewer": viewerPolicy,
+				})
+			})
+
+			Convey("Then the permissions reference the policies by ID", func() {
+				So(normalised.Permissions, ShouldResemble, map[string]map[string][]string{
+					"legacy:read": {
+						"groups/admin":  {"admin"},
+						"groups/viewer": {"viewer"},
+					},
+					"legacy:update": {
+						"groups/admin": {"admin"},
+					},
+				})
+			})
+		})
+	})
+}
+
+func TestBundle_UnheldPermissions(t *testing.T) {
+	Convey("Given a bundle in which a permission is only held with a condition", t, func() {
+		adminPolicy := &BundlePolicy{ID: "admin"}
+		collectionPolicy := &BundlePolicy{ID: "collection", Condition: Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"col1"}}}
+		bundle := Bundle{
+			PoliciesCreate: {
+				"groups/admin": {adminPolicy},
+			},
+			PoliciesUpdate: {
+				"groups/admin":     {adminPolicy},
+				"groups/publisher": {collectionPolicy},
+			},
+			PoliciesDelete: {
+				"groups/publisher": {collectionPolicy},
+			},
+		}
+
+		Convey("When the unheld policy administration permissions are requested", func() {
+			unheld := bundle.UnheldPermissions(PolicyAdministrationPermissions)
+
+			Convey("Then the permissions that are not held without a condition are returned", func() {
+				So(unheld, ShouldResemble, []string{PoliciesDelete})
+			})
+		})
+
+		Convey("When a permission that is held without a condition is requested, then no permissions are returned", func() {
+			So(bundle.UnheldPermissions([]string{PoliciesCreate, PoliciesUpdate}), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestBundle_HoldsUnconditionally(t *testing.T) {
+	Convey("Given a bundle in which a permission is held with and without a condition", t, func() {
+		bundle := Bundle{
+			PoliciesRead: {
+				"groups/admin":     {{ID: "admin"}},
+				"groups/publisher": {{ID: "collection", Condition: Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"col1"}}}},
+				"groups/viewer":    {{ID: "no-attribute", Condition: Condition{Operator: OperatorStringEquals, Values: []string{"col1"}}}},
+			},
+		}
+
+		Convey("Then the permission is held unconditionally by an entity with a policy without a condition", func() {
+			So(bundle.HoldsUnconditionally(PoliciesRead, []string{"groups/publisher", "groups/admin"}), ShouldBeTrue)
+		})
+
+		Convey("Then the permission is not held unconditionally by an entity with a policy whose condition has an attribute", func() {
+			So(bundle.HoldsUnconditionally(PoliciesRead, []string{"groups/publisher"}), ShouldBeFalse)
+		})
+
+		Convey("Then the permission is held unconditionally by an entity with a policy whose condition has no attribute, as it is always met", func() {
+			So(bundle.HoldsUnconditionally(PoliciesRead, []string{"groups/viewer"}), ShouldBeTrue)
+		})
+
+		Convey("Then the permission is not held unconditionally by no entities, or another permission", func() {
+			So(bundle.HoldsUnconditionally(PoliciesRead, nil), ShouldBeFalse)
+			So(bundle.HoldsUnconditionally(PoliciesDelete, []string{"groups/admin"}), ShouldBeFalse)
+		})
+	})
+}
+
+func TestBundleChange_Apply(t *testing.T) {
+	Convey("Given some policies and roles", t, func() {
+		adminRole := &Role{ID: "admin", Permissions: []string{PoliciesUpdate}}
+		viewerRole := &Role{ID: "viewer", Permissions: []string{PoliciesRead}}
+		adminPolicy := &BundlePolicy{ID: "admin", Entities: []string{"groups/admin"}, Role: "admin"}
+		viewerPolicy := &BundlePolicy{ID: "viewer", Entities: []string{"groups/viewer"}, Role: "viewer"}
+		policies := []*BundlePolicy{adminPolicy, viewerPolicy}
+		roles := []*Role{adminRole, viewerRole}
+
+		Convey("When a change replaces a policy, then the policy is replaced", func() {
+			replaced := &BundlePolicy{ID: "admin", Entities: []string{"groups/publisher"}, Role: "admin"}
+			changedPolicies, changedRoles := BundleChange{Policy: replaced}.Apply(policies, roles)
+			So(changedPolicies, ShouldResemble, []*BundlePolicy{viewerPolicy, replaced})
+			So(changedRoles, ShouldResemble, roles)
+		})
+
+		Convey("When a change creates a policy, then the policy is added", func() {
+			created := &BundlePolicy{ID: "new", Entities: []string{"groups/publisher"}, Role: "viewer"}
+			changedPolicies, _ := BundleChange{Policy: created}.Apply(policies, roles)
+			So(changedPolicies, ShouldResemble, []*BundlePolicy{adminPolicy, viewerPolicy, created})
+		})
+
+		Convey("When a change deletes a policy, then the policy is removed", func() {
+			changedPolicies, _ := BundleChange{DeletedPolicyID: "admin"}.Apply(policies, roles)
+			So(changedPolicies, ShouldResemble, []*BundlePolicy{viewerPolicy})
+		})
+
+		Convey("When a change replaces a role, then the role is replaced", func() {
+			replaced := &Role{ID: "admin", Permissions: []string{PoliciesRead}}
+			_, changedRoles := BundleChange{Role: replaced}.Apply(policies, roles)
+			So(changedRoles, ShouldResemble, []*Role{viewerRole, replaced})
+		})
+
+		Convey("When a change deletes a role, then the role and its policies are removed", func() {
+			changedPolicies, changedRoles := BundleChange{DeletedRoleID: "admin"}.Apply(policies, roles)
+			So(changedPolicies, ShouldResemble, []*BundlePolicy{viewerPolicy})
+			So(changedRoles, ShouldResemble, []*Role{viewerRole})
+		})
+
+		Convey("Then the original policies and roles are not modified", func() {
+			BundleChange{DeletedRoleID: "admin", DeletedPolicyID: "viewer"}.Apply(policies, roles)
+			So(policies, ShouldResemble, []*BundlePolicy{adminPolicy, viewerPolicy})
+			So(roles, ShouldResemble, []*Role{adminRole, viewerRole})
+		})
+	})
+}
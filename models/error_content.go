@@ -11,6 +11,7 @@ const (
 	GetRoleError                               = "GetRoleError"
 	GetRolesError                              = "GetRolesError"
 	GetPermissionBundleError                   = "GetPermissionBundleError"
+	CompressBundleError                        = "CompressBundleError"
 	PolicyNotFoundError                        = "PolicyNotFoundError"
 	PolicyAlreadyExistsError                   = "PolicyAlreadyExistsError"
 	GetPolicyError                             = "GetPolicyError"
@@ -20,6 +21,35 @@ const (
 	CreatePolicyWithIDError                    = "CreatePolicyWithIDError"
 	UpdatePolicyError                          = "UpdatePolicyError"
 	GetAuthEntityDataError                     = "GetAuthEntityDataError"
+	InvalidAuthTokenError                      = "InvalidAuthTokenError"
+	EntityNotPermittedError                    = "EntityNotPermittedError"
+	PermissionNotFoundError                    = "PermissionNotFoundError"
+	GetPermissionError                         = "GetPermissionError"
+	GetPermissionsError                        = "GetPermissionsError"
+	InvalidPermissionError                     = "InvalidPermissionError"
+	RegisterPermissionError                    = "RegisterPermissionError"
+	RoleAlreadyExistsError                     = "RoleAlreadyExistsError"
+	InvalidRoleError                           = "InvalidRoleError"
+	UnknownPermissionsError                    = "UnknownPermissionsError"
+	CreateRoleError                            = "CreateRoleError"
+	UpdateRoleError                            = "UpdateRoleError"
+	UnknownEntitiesError                       = "UnknownEntitiesError"
+	ResolveEntitiesError                       = "ResolveEntitiesError"
+	CountPoliciesError                         = "CountPoliciesError"
+	GetPoliciesError                           = "GetPoliciesError"
+	RoleInUseError                             = "RoleInUseError"
+	DeleteRoleError                            = "DeleteRoleError"
+	LastPolicyEntityError                      = "LastPolicyEntityError"
+	InvalidPatchError                          = "InvalidPatchError"
+	PatchTestFailedError                       = "PatchTestFailedError"
+	UnsupportedMediaTypeError                  = "UnsupportedMediaTypeError"
+	PolicyDeletedError                         = "PolicyDeletedError"
+	PolicyNotDeletedError                      = "PolicyNotDeletedError"
+	RestorePolicyError                         = "RestorePolicyError"
+	LockoutError                               = "LockoutError"
+	CheckLockoutError                          = "CheckLockoutError"
+	ProtectedPolicyError                       = "ProtectedPolicyError"
+	PolicyModifiedError                        = "PolicyModifiedError"
 )
 
 // API error descriptions
@@ -35,6 +65,7 @@ const (
 	GetRoleErrorDescription                          = "retrieving role from DB returned an error"
 	GetRolesErrorDescription                         = "retrieving roles from DB returned an error"
 	GetPermissionBundleErrorDescription              = "failed to get permissions bundle"
+	CompressBundleErrorDescription                   = "failed to compress permissions bundle"
 	PolicyAlreadyExistsDescription                   = "policy already exists with given ID"
 	PolicyNotFoundDescription                        = "policy not found"
 	GetPolicyErrorDescription                        = "retrieving policy from DB returned an error"
@@ -44,4 +75,26 @@ const (
 	UpdatePolicyErrorDescription                     = "failed to update policy"
 	GetAuthEntityDataErrorDescription                = "failed to get auth entity data from request"
 	EntityDataErrorDescription                       = "unable to parse entity data from request context"
+	InvalidAuthTokenDescription                      = "a valid authorisation token is required to request entities"
+	EntityNotPermittedDescription                    = "not permitted to view the permissions of the requested entity"
+	PermissionNotFoundDescription                    = "permission not found"
+	GetPermissionErrorDescription                    = "retrieving permission from DB returned an error"
+	GetPermissionsErrorDescription                   = "retrieving permissions from DB returned an error"
+	RegisterPermissionErrorDescription               = "failed to register permission"
+	RoleAlreadyExistsDescription                     = "role already exists with given ID"
+	CreateRoleErrorDescription                       = "failed to create role"
+	UpdateRoleErrorDescription                       = "failed to update role"
+	ResolveEntitiesErrorDescription                  = "failed to check that the policy entities exist"
+	CountPoliciesErrorDescription                    = "counting policies in DB returned an error"
+	GetPoliciesErrorDescription                      = "retrieving policies from DB returned an error"
+	DeleteRoleErrorDescription                       = "deleting role from DB returned an error"
+	LastPolicyEntityDescription                      = "the last entity of a policy cannot be removed, delete the policy instead"
+	PatchTestFailedDescription                       = "a test operation of the patch failed"
+	PolicyDeletedDescription                         = "policy with given ID is deleted, restore it before changing it"
+	PolicyNotDeletedDescription                      = "policy with given ID is not deleted"
+	PolicyRoleNotFoundDescription                    = "the role of the policy was deleted, so the policy cannot be restored"
+	RestorePolicyErrorDescription                    = "failed to restore policy"
+	CheckLockoutErrorDescription                     = "failed to check that the change leaves an entity able to change policies"
+	ProtectedPolicyDescription                       = "policy is protected, and is only changed with the override query parameter set to true"
+	PolicyModifiedDescription                        = "policy was changed by another request while it was patched, retry the patch"
 )
@@ -0,0 +1,30 @@
+package models
+
+// JSONWebKeySet represents a set of public keys that can be used to verify signed permissions bundles
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey represents a single public verification key, as defined by RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv,omitempty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	X         string `json:"x"`
+}
+
+// JSON web key values used for Ed25519 bundle signing keys
+const (
+	KeyTypeOctetKeyPair = "OKP"
+	CurveEd25519        = "Ed25519"
+	AlgorithmEdDSA      = "EdDSA"
+	KeyUseSignature     = "sig"
+)
+
+// Response headers that carry the detached signature of a signed permissions bundle
+const (
+	BundleSignatureHeader      = "X-Bundle-Signature"
+	BundleSignatureKeyIDHeader = "X-Bundle-Signature-Key-Id"
+)
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// Media types of the supported patch documents
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+// A list of patch errors
+var (
+	ErrUnsupportedPatchMediaType = errors.New("patch media type must be " + MergePatchMediaType + " or " + JSONPatchMediaType)
+	ErrInvalidPatch              = errors.New("invalid patch")
+	ErrPatchTestFailed           = errors.New("patch test operation failed")
+)
+
+// PatchPolicy applies a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) to the entities, role and condition of
+// a policy, according to the media type of the patch. The patched policy properties are returned without validation.
+func PatchPolicy(policy *Policy, mediaType string, reader io.Reader) (*PolicyInfo, error) {
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || (mediaType != MergePatchMediaType && mediaType != JSONPatchMediaType) {
+		return nil, ErrUnsupportedPatchMediaType
+	}
+
+	patch, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, ErrorReadingBody
+	}
+
+	document, err := json.Marshal(PolicyInfo{Entities: policy.Entities, Role: policy.Role, Condition: policy.Condition})
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	if mediaType == MergePatchMediaType {
+		patched, err = jsonpatch.MergePatch(document, patch)
+	} else {
+		patched, err = applyJSONPatch(document, patch)
+	}
+	if err != nil {
+		if errors.Is(err, ErrPatchTestFailed) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var patchedPolicy PolicyInfo
+	if err := json.Unmarshal(patched, &patchedPolicy); err != nil {
+		return nil, fmt.Errorf("%w: the patched policy is not a policy: %v", ErrInvalidPatch, err)
+	}
+
+	return &patchedPolicy, nil
+}
+
+func applyJSONPatch(document, patch []byte) ([]byte, error) {
+	operations, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := operations.Apply(document)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return nil, fmt.Errorf("%w: %v", ErrPatchTestFailed, err)
+	}
+	return patched, err
+}
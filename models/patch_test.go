@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPatchPolicy(t *testing.T) {
+	Convey("Given a policy with a condition", t, func() {
+		policy := &Policy{
+			ID:        "policy1",
+			Entities:  []string{"groups/e1", "groups/e2"},
+			Role:      "r1",
+			Condition: Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"col1"}},
+		}
+
+		Convey("When a merge patch changes the condition, then only the condition is changed", func() {
+			patched, err := PatchPolicy(policy, MergePatchMediaType, strings.NewReader(`{"condition": {"values": ["col2"]}}`))
+			So(err, ShouldBeNil)
+			So(patched, ShouldResemble, &PolicyInfo{
+				Entities:  []string{"groups/e1", "groups/e2"},
+				Role:      "r1",
+				Condition: Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"col2"}},
+			})
+		})
+
+		Convey("When a merge patch with a charset removes the condition, then the policy has no condition", func() {
+			patched, err := PatchPolicy(policy, MergePatchMediaType+"; charset=utf-8", strings.NewReader(`{"condition": null}`))
+			So(err, ShouldBeNil)
+			So(patched.Condition, ShouldResemble, Condition{})
+			So(patched.Role, ShouldEqual, "r1")
+		})
+
+		Convey("When a JSON patch tests the role and adds an entity, then the entity is added", func() {
+			patched, err := PatchPolicy(policy, JSONPatchMediaType, strings.NewReader(
+				`[{"op": "test", "path": "/role", "value": "r1"}, {"op": "add", "path": "/entities/-", "value": "users/u1"}]`))
+			So(err, ShouldBeNil)
+			So(patched.Entities, ShouldResemble, []string{"groups/e1", "groups/e2", "users/u1"})
+			So(patched.Condition, ShouldResemble, policy.Condition)
+		})
+
+		Convey("When a JSON patch test operation fails, then a patch test failed error is returned", func() {
+			patched, err := PatchPolicy(policy, JSONPatchMediaType, strings.NewReader(
+				`[{"op": "test", "path": "/role", "value": "r2"}, {"op": "replace", "path": "/role", "value": "r3"}]`))
+			So(errors.Is(err, ErrPatchTestFailed), ShouldBeTrue)
+			So(patched, ShouldBeNil)
+		})
+
+		Convey("When a JSON patch removes a value that does not exist, then an invalid patch error is returned", func() {
+			_, err := PatchPolicy(policy, JSONPatchMediaType, strings.NewReader(`[{"op": "remove", "path": "/entities/5"}]`))
+			So(errors.Is(err, ErrInvalidPatch), ShouldBeTrue)
+		})
+
+		Convey("When a patch is not valid JSON, then an invalid patch error is returned", func() {
+			_, err := PatchPolicy(policy, JSONPatchMediaType, strings.NewReader(`{"op": "remove"`))
+			So(errors.Is(err, ErrInvalidPatch), ShouldBeTrue)
+
+			_, err = PatchPolicy(policy, MergePatchMediaType, strings.NewReader(`{"role":`))
+			So(errors.Is(err, ErrInvalidPatch), ShouldBeTrue)
+		})
+
+		Convey("When a patch changes a property to a value of the wrong type, then an invalid patch error is returned", func() {
+			_, err := PatchPolicy(policy, MergePatchMediaType, strings.NewReader(`{"entities": "groups/e3"}`))
+			So(errors.Is(err, ErrInvalidPatch), ShouldBeTrue)
+		})
+
+		Convey("When the patch has an unsupported media type, then an unsupported media type error is returned", func() {
+			_, err := PatchPolicy(policy, "application/json", strings.NewReader(`{"role": "r2"}`))
+			So(err, ShouldEqual, ErrUnsupportedPatchMediaType)
+
+			_, err = PatchPolicy(policy, "", strings.NewReader(`{"role": "r2"}`))
+			So(err, ShouldEqual, ErrUnsupportedPatchMediaType)
+		})
+
+		Convey("When the patch cannot be read, then an error reading the body is returned", func() {
+			_, err := PatchPolicy(policy, MergePatchMediaType, reader{})
+			So(err, ShouldEqual, ErrorReadingBody)
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// PermissionWildcard matches any value of a permission segment, e.g. datasets:* or *:read
+	PermissionWildcard = "*"
+
+	permissionSegmentSeparator = ":"
+)
+
+// ErrInvalidPermissionPattern is returned when a wildcard does not replace a whole permission segment
+var ErrInvalidPermissionPattern = errors.New("wildcards must replace a whole permission segment, e.g. datasets:* or *:read")
+
+// permissions catalogue permissions
+const (
+	PermissionsRead   string = "permissions:read"
+	PermissionsUpdate string = "permissions:update"
+)
+
+// Permissions represents an array of the permission model
+type Permissions struct {
+	Count      int          `json:"count"`
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+	Items      []Permission `json:"items"`
+	TotalCount int          `json:"total_count"`
+}
+
+// Permission represents a permission registered in the catalogue by the service that enforces it
+type Permission struct {
+	ID          string `bson:"_id"         json:"id"`
+	Description string `bson:"description" json:"description"`
+	Service     string `bson:"service"     json:"service"`
+	Deprecated  bool   `bson:"deprecated"  json:"deprecated"`
+}
+
+// PermissionInfo contains properties required to register a permission
+type PermissionInfo struct {
+	Description string `json:"description"`
+	Service     string `json:"service"`
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// GetPermission creates a permission object with ID
+func (permission *PermissionInfo) GetPermission(id string) *Permission {
+	return &Permission{
+		ID:          id,
+		Description: permission.Description,
+		Service:     permission.Service,
+		Deprecated:  permission.Deprecated,
+	}
+}
+
+// ValidatePermission checks that all the mandatory fields are non-empty
+func (permission *PermissionInfo) ValidatePermission() error {
+	var missingFields []string
+
+	if permission.Description == "" {
+		missingFields = append(missingFields, "description")
+	}
+	if permission.Service == "" {
+		missingFields = append(missingFields, "service")
+	}
+	if len(missingFields) > 0 {
+		return fmt.Errorf("missing mandatory fields: %v", strings.Join(missingFields, ", "))
+	}
+	return nil
+}
+
+// CreatePermission manages the creation of a permission from reader
+func CreatePermission(reader io.Reader) (*PermissionInfo, error) {
+	bytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, ErrorReadingBody
+	}
+
+	var permission PermissionInfo
+	err = json.Unmarshal(bytes, &permission)
+	if err != nil {
+		return nil, ErrorParsingBody
+	}
+
+	return &permission, nil
+}
+
+// IsPermissionPattern returns true if the permission contains a wildcard
+func IsPermissionPattern(permission string) bool {
+	return strings.Contains(permission, PermissionWildcard)
+}
+
+// ValidatePermissionPattern checks that every wildcard in the permission replaces a whole segment
+func ValidatePermissionPattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, permissionSegmentSeparator) {
+		if segment != PermissionWildcard && strings.Contains(segment, PermissionWildcard) {
+			return fmt.Errorf("%w: %s", ErrInvalidPermissionPattern, pattern)
+		}
+	}
+	return nil
+}
+
+// MatchesPermission returns true if the permission matches the pattern. The permission must have the same number of
+// segments as the pattern, and each segment must either equal the pattern segment or the pattern segment is a wildcard.
+func MatchesPermission(pattern, permission string) bool {
+	patternSegments := strings.Split(pattern, permissionSegmentSeparator)
+	permissionSegments := strings.Split(permission, permissionSegmentSeparator)
+	if len(patternSegments) != len(permissionSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if segment != PermissionWildcard && segment != permissionSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchingPermissionPatterns returns the permission and every permission pattern that matches it, i.e. the
+// permission with any combination of its segments replaced by wildcards
+func MatchingPermissionPatterns(permission string) []string {
+	patterns := []string{""}
+	for i, segment := range strings.Split(permission, permissionSegmentSeparator) {
+		separator := permissionSegmentSeparator
+		if i == 0 {
+			separator = ""
+		}
+
+		next := make([]string, 0, len(patterns)*2)
+		for _, pattern := range patterns {
+			next = append(next, pattern+separator+segment)
+			if segment != PermissionWildcard {
+				next = append(next, pattern+separator+PermissionWildcard)
+			}
+		}
+		patterns = next
+	}
+	return patterns
+}
+
+// ExpandPermissions replaces the permission patterns in the list with the known permissions that they match.
+// Concrete permissions are kept as they are, whether they are known or not, and duplicates are removed.
+func ExpandPermissions(permissions, knownPermissions []string) []string {
+	expanded := []string{}
+	seen := map[string]struct{}{}
+
+	add := func(permission string) {
+		if _, ok := seen[permission]; !ok {
+			seen[permission] = struct{}{}
+			expanded = append(expanded, permission)
+		}
+	}
+
+	for _, permission := range permissions {
+		if !IsPermissionPattern(permission) {
+			add(permission)
+			continue
+		}
+		for _, known := range knownPermissions {
+			if !IsPermissionPattern(known) && MatchesPermission(permission, known) {
+				add(known)
+			}
+		}
+	}
+
+	return expanded
+}
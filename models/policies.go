@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 )
 
 // policies permissions
@@ -17,6 +18,9 @@ const (
 
 	OperatorStringEquals Operator = "StringEquals"
 	OperatorStartsWith   Operator = "StartsWith"
+
+	EntityPrefixUsers  = "users/"
+	EntityPrefixGroups = "groups/"
 )
 
 // A list of errors returned from package
@@ -34,12 +38,36 @@ type Condition struct {
 	Values    []string `bson:"Values" json:"values,omitempty"`
 }
 
-// Policy represent a structure for a policy in DB
+// IsEmpty returns true if the condition has no attribute, so that the policy applies unconditionally. The operator and
+// values are ignored without an attribute, as they are when the condition is enforced.
+func (condition Condition) IsEmpty() bool {
+	return condition.Attribute == ""
+}
+
+// Policies represents an array of the policy model
+type Policies struct {
+	Count      int      `json:"count"`
+	Offset     int      `json:"offset"`
+	Limit      int      `json:"limit"`
+	Items      []Policy `json:"items"`
+	TotalCount int      `json:"total_count"`
+}
+
+// Policy represent a structure for a policy in DB.
+// The metadata of the policy is set by the permissions API whenever the policy is written, and its version is incremented,
+// so that a change made since the policy was read can be detected. A deleted policy is only marked as deleted, so that
+// it can be restored, until it is purged after the retention period.
 type Policy struct {
-	ID        string    `bson:"_id"          json:"id,omitempty"`
-	Entities  []string  `bson:"entities"   json:"entities"`
-	Role      string    `bson:"role"      json:"role"`
-	Condition Condition `bson:"condition" json:"condition,omitempty"`
+	ID          string    `bson:"_id"          json:"id,omitempty"`
+	Entities    []string  `bson:"entities"   json:"entities"`
+	Role        string    `bson:"role"      json:"role"`
+	Condition   Condition `bson:"condition" json:"condition,omitempty"`
+	CreatedAt   time.Time `bson:"created_at,omitempty"   json:"created_at,omitzero"`
+	LastUpdated time.Time `bson:"last_updated,omitempty" json:"last_updated,omitzero"`
+	UpdatedBy   string    `bson:"updated_by,omitempty"   json:"updated_by,omitempty"`
+	Version     int       `bson:"version,omitempty"      json:"version,omitempty"`
+	DeletedAt   time.Time `bson:"deleted_at,omitempty"   json:"deleted_at,omitzero"`
+	DeletedBy   string    `bson:"deleted_by,omitempty"   json:"deleted_by,omitempty"`
 }
 
 // UpdateResult represent a result of the upsert policy
@@ -68,7 +96,17 @@ func (operator Operator) String() string {
 	return string(operator)
 }
 
-// GetPolicy creates a policy object with ID
+// IsValidEntity returns true if the entity has a known prefix followed by a non-empty ID, e.g. groups/role-admin
+func IsValidEntity(entity string) bool {
+	for _, prefix := range []string{EntityPrefixUsers, EntityPrefixGroups} {
+		if id, found := strings.CutPrefix(entity, prefix); found {
+			return strings.TrimSpace(id) != "" && !strings.Contains(id, "/")
+		}
+	}
+	return false
+}
+
+// GetPolicy creates a policy object with ID, without metadata
 func (policy *PolicyInfo) GetPolicy(id string) *Policy {
 	return &Policy{
 		ID:        id,
@@ -78,6 +116,16 @@ func (policy *PolicyInfo) GetPolicy(id string) *Policy {
 	}
 }
 
+// GetBundlePolicy creates a bundle policy object from a policy
+func (policy *Policy) GetBundlePolicy() *BundlePolicy {
+	return &BundlePolicy{
+		ID:        policy.ID,
+		Entities:  policy.Entities,
+		Role:      policy.Role,
+		Condition: policy.Condition,
+	}
+}
+
 // ValidatePolicy checks that all the mandatory fields are non-empty and non-empty fields contain valid values
 func (policy *PolicyInfo) ValidatePolicy() error {
 	var missingFields, invalidFields, validationErrors []string
@@ -92,6 +140,11 @@ func (policy *PolicyInfo) ValidatePolicy() error {
 		validationErrors = append(validationErrors, fmt.Sprintf("missing mandatory fields: %v", strings.Join(missingFields, ", ")))
 	}
 
+	for _, entity := range policy.Entities {
+		if !IsValidEntity(entity) {
+			invalidFields = append(invalidFields, "entity "+entity)
+		}
+	}
 	if len(policy.Condition.Operator) > 0 {
 		if !policy.Condition.Operator.IsValid() {
 			invalidFields = append(invalidFields, "condition operator "+policy.Condition.Operator.String())
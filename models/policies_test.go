@@ -17,12 +17,12 @@ func (f reader) Read(bytes []byte) (int, error) {
 
 func TestCreateNewPolicyWithValidJson(t *testing.T) {
 	Convey("When a policy has a valid json body, a new policy is returned", t, func() {
-		reader := strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
+		reader := strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "StringEquals", "values": ["v1"]}}`)
 
 		policy, err := CreatePolicy(reader)
 
 		So(err, ShouldBeNil)
-		So(policy.Entities, ShouldResemble, []string{"e1", "e2"})
+		So(policy.Entities, ShouldResemble, []string{"groups/e1", "groups/e2"})
 		So(policy.Role, ShouldResemble, "r1")
 		So(policy.Condition, ShouldResemble, Condition{
 			Attribute: "a1", Values: []string{"v1"}, Operator: OperatorStringEquals},
@@ -60,11 +60,47 @@ func TestCreateNewPolicyWithInvalidJson(t *testing.T) {
 	})
 
 	Convey("When a policy message has an invalid condition operator, an error is returned", t, func() {
-		policy, err := CreatePolicy(strings.NewReader(`{"entities": ["e1", "e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "And", "values": ["v1"]}}`))
+		policy, err := CreatePolicy(strings.NewReader(`{"entities": ["groups/e1", "groups/e2"], "role": "r1", "condition": {"attribute": "a1", "operator": "And", "values": ["v1"]}}`))
 		So(err, ShouldBeNil)
 
 		err = policy.ValidatePolicy()
 		So(err, ShouldNotBeNil)
 		So(err, ShouldResemble, fmt.Errorf("invalid field values: condition operator And"))
 	})
+
+	Convey("When a policy message has entities without a known prefix or ID, an error is returned", t, func() {
+		policy, err := CreatePolicy(strings.NewReader(`{"entities": ["groups/e1", "group/e2", "users/", "e3", "users/e4/e5"], "role": "r1"}`))
+		So(err, ShouldBeNil)
+
+		err = policy.ValidatePolicy()
+		So(err, ShouldNotBeNil)
+		So(err, ShouldResemble, fmt.Errorf("invalid field values: entity group/e2, entity users/, entity e3, entity users/e4/e5"))
+	})
+}
+
+func TestIsValidEntity(t *testing.T) {
+	Convey("Entities with a users/ or groups/ prefix and a non-empty ID are valid", t, func() {
+		So(IsValidEntity("users/zebedee"), ShouldBeTrue)
+		So(IsValidEntity("groups/role-admin"), ShouldBeTrue)
+	})
+
+	Convey("Entities without a known prefix or ID are invalid", t, func() {
+		So(IsValidEntity("roles/admin"), ShouldBeFalse)
+		So(IsValidEntity("groups/"), ShouldBeFalse)
+		So(IsValidEntity("groups/ "), ShouldBeFalse)
+		So(IsValidEntity("role-admin"), ShouldBeFalse)
+		So(IsValidEntity(""), ShouldBeFalse)
+	})
+}
+
+func TestCondition_IsEmpty(t *testing.T) {
+	Convey("A condition without an attribute is empty, whatever its operator and values", t, func() {
+		So(Condition{}.IsEmpty(), ShouldBeTrue)
+		So(Condition{Operator: OperatorStringEquals, Values: []string{"v1"}}.IsEmpty(), ShouldBeTrue)
+	})
+
+	Convey("A condition with an attribute is not empty", t, func() {
+		So(Condition{Attribute: "collection_id"}.IsEmpty(), ShouldBeFalse)
+		So(Condition{Attribute: "collection_id", Operator: OperatorStringEquals, Values: []string{"v1"}}.IsEmpty(), ShouldBeFalse)
+	})
 }
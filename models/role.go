@@ -1,5 +1,21 @@
 package models
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// A list of role inheritance errors
+var (
+	ErrRoleInheritanceCycle = errors.New("role inherits from itself")
+	ErrUnknownParentRole    = errors.New("parent role not found")
+)
+
 // Roles represents an array of the role model
 type Roles struct {
 	Count      int    `json:"count"`
@@ -9,13 +25,79 @@ type Roles struct {
 	TotalCount int    `json:"total_count"`
 }
 
-// Role represents the structure for a role
+// Role represents the structure for a role.
+// A role inherits the permissions of its parent roles, which are included in its effective permissions.
 type Role struct {
-	ID          string   `bson:"_id" json:"id"`
-	Name        string   `bson:"name" json:"name"`
-	Permissions []string `bson:"permissions" json:"permissions"`
+	ID                   string   `bson:"_id" json:"id"`
+	Name                 string   `bson:"name" json:"name"`
+	Permissions          []string `bson:"permissions" json:"permissions"`
+	Parents              []string `bson:"parents" json:"parents,omitempty"`
+	EffectivePermissions []string `bson:"-" json:"effective_permissions,omitempty"`
+}
+
+// RoleInfo contains properties required to create or update a role
+type RoleInfo struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	Parents     []string `json:"parents,omitempty"`
+}
+
+// PermissionRoles represents the roles that grant a permission
+type PermissionRoles struct {
+	Permission string           `json:"permission"`
+	Count      int              `json:"count"`
+	Items      []PermissionRole `json:"items"`
+}
+
+// PermissionRole is a role that grants a permission, with the number of policies that bind the role to users and groups
+type PermissionRole struct {
+	Role
+	// Inherited is true if the role only grants the permission because it inherits it from a parent role
+	Inherited   bool `json:"inherited"`
+	PolicyCount int  `json:"policy_count"`
+}
+
+// RoleFilter filters and sorts a list of roles. Empty fields do not filter the roles.
+type RoleFilter struct {
+	// Name matches roles with the name, case-insensitively
+	Name string
+	// Permission matches roles that have the permission of their own, not including inherited permissions
+	Permission string
+	// Search matches roles whose ID, name or one of whose own permissions contains the text, case-insensitively
+	Search string
+	// Sort orders the roles, by ID if it is not set
+	Sort RoleSort
 }
 
+// RoleSort is the order of a list of roles. A sort prefixed with "-" is descending.
+type RoleSort string
+
+// roles sort orders
+const (
+	RoleSortID       RoleSort = "id"
+	RoleSortIDDesc   RoleSort = "-id"
+	RoleSortName     RoleSort = "name"
+	RoleSortNameDesc RoleSort = "-name"
+)
+
+// IsValid checks that the sort order is supported. An empty sort order is valid, and sorts roles by ID.
+func (sort RoleSort) IsValid() bool {
+	switch sort {
+	case "", RoleSortID, RoleSortIDDesc, RoleSortName, RoleSortNameDesc:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsEmpty checks whether the filter neither filters nor sorts the roles
+func (filter RoleFilter) IsEmpty() bool {
+	return filter == RoleFilter{}
+}
+
+// RoleHierarchy maps role IDs to roles, to resolve the permissions that roles inherit from their parent roles
+type RoleHierarchy map[string]*Role
+
 // roles permissions
 const (
 	RolesRead   string = "roles:read"
@@ -23,3 +105,117 @@ const (
 	RolesUpdate string = "roles:update"
 	RolesDelete string = "roles:delete"
 )
+
+// GetRole creates a role object with ID
+func (role *RoleInfo) GetRole(id string) *Role {
+	return &Role{
+		ID:          id,
+		Name:        role.Name,
+		Permissions: role.Permissions,
+		Parents:     role.Parents,
+	}
+}
+
+// RoleID returns the ID of a new role, which is its lowercased name as generated by the import script
+func (role *RoleInfo) RoleID() string {
+	return strings.ToLower(role.Name)
+}
+
+// ValidateRole checks that all the mandatory fields are non-empty. A role without permissions of its own must have parent roles.
+func (role *RoleInfo) ValidateRole() error {
+	var missingFields []string
+
+	if role.Name == "" {
+		missingFields = append(missingFields, "name")
+	}
+	if len(role.Permissions) == 0 && len(role.Parents) == 0 {
+		missingFields = append(missingFields, "permissions")
+	}
+	if len(missingFields) > 0 {
+		return fmt.Errorf("missing mandatory fields: %v", strings.Join(missingFields, ", "))
+	}
+	return nil
+}
+
+// CreateRole manages the creation of a role from reader
+func CreateRole(reader io.Reader) (*RoleInfo, error) {
+	bytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, ErrorReadingBody
+	}
+
+	var role RoleInfo
+	err = json.Unmarshal(bytes, &role)
+	if err != nil {
+		return nil, ErrorParsingBody
+	}
+
+	return &role, nil
+}
+
+// NewRoleHierarchy creates a RoleHierarchy from a list of roles
+func NewRoleHierarchy(roles []*Role) RoleHierarchy {
+	hierarchy := make(RoleHierarchy, len(roles))
+	for _, role := range roles {
+		hierarchy[role.ID] = role
+	}
+	return hierarchy
+}
+
+// EffectivePermissions returns the permissions of the role and of all of its ancestor roles, without duplicates.
+// An error is returned if the role or one of its ancestors does not exist, or if the role hierarchy contains a cycle.
+func (hierarchy RoleHierarchy) EffectivePermissions(roleID string) ([]string, error) {
+	permissions := []string{}
+	seen := map[string]struct{}{}
+	resolved := map[string]struct{}{}
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		for _, ancestor := range path {
+			if ancestor == id {
+				return fmt.Errorf("%w: %s", ErrRoleInheritanceCycle, strings.Join(append(path, id), " -> "))
+			}
+		}
+		if _, ok := resolved[id]; ok {
+			return nil
+		}
+
+		role, ok := hierarchy[id]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownParentRole, id)
+		}
+
+		for _, permission := range role.Permissions {
+			if _, ok := seen[permission]; !ok {
+				seen[permission] = struct{}{}
+				permissions = append(permissions, permission)
+			}
+		}
+
+		for _, parent := range role.Parents {
+			if err := visit(parent, append(path, id)); err != nil {
+				return err
+			}
+		}
+
+		resolved[id] = struct{}{}
+		return nil
+	}
+
+	if err := visit(roleID, nil); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Children returns the IDs of the roles that inherit directly from the role, in order
+func (hierarchy RoleHierarchy) Children(roleID string) []string {
+	children := []string{}
+	for id, role := range hierarchy {
+		if slices.Contains(role.Parents, roleID) {
+			children = append(children, id)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateRole(t *testing.T) {
+	Convey("When a role has a valid json body, a valid role is returned with its lowercased name as ID", t, func() {
+		role, err := CreateRole(strings.NewReader(`{"name": "Collection-Author", "permissions": ["legacy:read", "legacy:edit"]}`))
+		So(err, ShouldBeNil)
+		So(role.ValidateRole(), ShouldBeNil)
+		So(role.RoleID(), ShouldEqual, "collection-author")
+		So(role.GetRole(role.RoleID()), ShouldResemble, &Role{
+			ID:          "collection-author",
+			Name:        "Collection-Author",
+			Permissions: []string{"legacy:read", "legacy:edit"},
+		})
+	})
+
+	Convey("When a role message is missing its name and permissions, an error is returned", t, func() {
+		role, err := CreateRole(strings.NewReader(`{"permissions": []}`))
+		So(err, ShouldBeNil)
+		So(role.ValidateRole(), ShouldResemble, fmt.Errorf("missing mandatory fields: name, permissions"))
+	})
+
+	Convey("When a role message has no body, an error is returned", t, func() {
+		role, err := CreateRole(reader{})
+		So(err, ShouldResemble, ErrorReadingBody)
+		So(role, ShouldBeNil)
+	})
+
+	Convey("When a role message is not valid json, an error is returned", t, func() {
+		role, err := CreateRole(strings.NewReader(`{`))
+		So(err, ShouldResemble, ErrorParsingBody)
+		So(role, ShouldBeNil)
+	})
+}
+
+func TestCreatePermission(t *testing.T) {
+	Convey("When a permission has a valid json body, a valid permission is returned", t, func() {
+		permission, err := CreatePermission(strings.NewReader(`{"description": "edit legacy content", "service": "zebedee", "deprecated": true}`))
+		So(err, ShouldBeNil)
+		So(permission.ValidatePermission(), ShouldBeNil)
+		So(permission.GetPermission("legacy:edit"), ShouldResemble, &Permission{
+			ID:          "legacy:edit",
+			Description: "edit legacy content",
+			Service:     "zebedee",
+			Deprecated:  true,
+		})
+	})
+
+	Convey("When a permission message is missing its description and service, an error is returned", t, func() {
+		permission, err := CreatePermission(strings.NewReader(`{}`))
+		So(err, ShouldBeNil)
+		So(permission.ValidatePermission(), ShouldResemble, fmt.Errorf("missing mandatory fields: description, service"))
+	})
+
+	Convey("When a permission message is not valid json, an error is returned", t, func() {
+		permission, err := CreatePermission(strings.NewReader(`{`))
+		So(err, ShouldResemble, ErrorParsingBody)
+		So(permission, ShouldBeNil)
+	})
+}
+
+func TestRoleHierarchyEffectivePermissions(t *testing.T) {
+	Convey("Given a role hierarchy where a role inherits from two parents with a common ancestor", t, func() {
+		hierarchy := NewRoleHierarchy([]*Role{
+			{ID: "base", Permissions: []string{"legacy:read"}},
+			{ID: "datasets", Permissions: []string{"datasets:read"}, Parents: []string{"base"}},
+			{ID: "files", Permissions: []string{"static-files:read", "legacy:read"}, Parents: []string{"base"}},
+			{ID: "admin", Permissions: []string{"users:read"}, Parents: []string{"datasets", "files"}},
+		})
+
+		Convey("Then the effective permissions contain every inherited permission once, direct permissions first", func() {
+			permissions, err := hierarchy.EffectivePermissions("admin")
+			So(err, ShouldBeNil)
+			So(permissions, ShouldResemble, []string{"users:read", "datasets:read", "legacy:read", "static-files:read"})
+		})
+
+		Convey("Then a role without parents only has its direct permissions", func() {
+			permissions, err := hierarchy.EffectivePermissions("base")
+			So(err, ShouldBeNil)
+			So(permissions, ShouldResemble, []string{"legacy:read"})
+		})
+
+		Convey("Then the children of a role are the roles that inherit directly from it, in order", func() {
+			So(hierarchy.Children("base"), ShouldResemble, []string{"datasets", "files"})
+			So(hierarchy.Children("datasets"), ShouldResemble, []string{"admin"})
+			So(hierarchy.Children("admin"), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a role hierarchy with a cycle", t, func() {
+		hierarchy := NewRoleHierarchy([]*Role{
+			{ID: "a", Parents: []string{"b"}},
+			{ID: "b", Parents: []string{"c"}},
+			{ID: "c", Parents: []string{"a"}},
+		})
+
+		Convey("Then resolving the effective permissions returns a cycle error with the inheritance path", func() {
+			permissions, err := hierarchy.EffectivePermissions("b")
+			So(errors.Is(err, ErrRoleInheritanceCycle), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "role inherits from itself: b -> c -> a -> b")
+			So(permissions, ShouldBeNil)
+		})
+	})
+}
+
+func TestPermissionPatterns(t *testing.T) {
+	Convey("Given permission patterns with wildcards", t, func() {
+		Convey("Then wildcards replacing whole segments are valid", func() {
+			So(ValidatePermissionPattern("datasets:*"), ShouldBeNil)
+			So(ValidatePermissionPattern("*:read"), ShouldBeNil)
+			So(ValidatePermissionPattern("*:*"), ShouldBeNil)
+		})
+
+		Convey("Then wildcards within a segment are invalid", func() {
+			So(errors.Is(ValidatePermissionPattern("data*:read"), ErrInvalidPermissionPattern), ShouldBeTrue)
+			So(errors.Is(ValidatePermissionPattern("datasets:re*"), ErrInvalidPermissionPattern), ShouldBeTrue)
+		})
+
+		Convey("Then patterns only match permissions with the same number of segments", func() {
+			So(MatchesPermission("datasets:*", "datasets:read"), ShouldBeTrue)
+			So(MatchesPermission("*:read", "datasets:read"), ShouldBeTrue)
+			So(MatchesPermission("*:read", "datasets:update"), ShouldBeFalse)
+			So(MatchesPermission("datasets:*", "dataset-editions-versions:read"), ShouldBeFalse)
+			So(MatchesPermission("*", "datasets:read"), ShouldBeFalse)
+			So(MatchesPermission("datasets:read", "datasets:read"), ShouldBeTrue)
+		})
+
+		Convey("Then the patterns matching a permission are the permission with every combination of wildcard segments", func() {
+			So(MatchingPermissionPatterns("datasets:read"), ShouldResemble, []string{"datasets:read", "datasets:*", "*:read", "*:*"})
+			So(MatchingPermissionPatterns("datasets:*"), ShouldResemble, []string{"datasets:*", "*:*"})
+			So(MatchingPermissionPatterns("read"), ShouldResemble, []string{"read", "*"})
+			for _, pattern := range MatchingPermissionPatterns("datasets:editions:read") {
+				So(MatchesPermission(pattern, "datasets:editions:read"), ShouldBeTrue)
+			}
+			So(MatchingPermissionPatterns("datasets:editions:read"), ShouldHaveLength, 8)
+		})
+
+		Convey("Then expanding permissions replaces patterns with the matching known permissions without duplicates", func() {
+			known := []string{"datasets:read", "datasets:update", "users:read", "datasets:*"}
+			So(ExpandPermissions([]string{"datasets:read", "datasets:*", "*:read", "unknown:read"}, known), ShouldResemble,
+				[]string{"datasets:read", "datasets:update", "users:read", "unknown:read"})
+		})
+	})
+}
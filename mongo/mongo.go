@@ -3,17 +3,25 @@ package mongo
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-permissions-api/apierrors"
 	"github.com/ONSdigital/dp-permissions-api/config"
+	"github.com/ONSdigital/dp-permissions-api/metrics"
 	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/tracing"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	mongohealth "github.com/ONSdigital/dp-mongodb/v3/health"
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Mongo struct {
@@ -25,7 +33,7 @@ type Mongo struct {
 
 // NewMongoStore creates a new Mongo object encapsulating a connection to the mongo server/cluster with the given configuration,
 // and a health client to check the health of the mongo server/cluster
-func NewMongoStore(_ context.Context, cfg config.MongoDB) (m *Mongo, err error) {
+func NewMongoStore(ctx context.Context, cfg config.MongoDB) (m *Mongo, err error) {
 	m = &Mongo{MongoDriverConfig: cfg}
 
 	m.Connection, err = mongodriver.Open(&m.MongoDriverConfig)
@@ -37,13 +45,104 @@ func NewMongoStore(_ context.Context, cfg config.MongoDB) (m *Mongo, err error)
 		mongohealth.Database(m.Database): {
 			mongohealth.Collection(m.ActualCollectionName(config.RolesCollection)),
 			mongohealth.Collection(m.ActualCollectionName(config.PoliciesCollection)),
+			mongohealth.Collection(m.ActualCollectionName(config.PermissionsCollection)),
 		},
 	}
 	m.healthClient = mongohealth.NewClientWithCollections(m.Connection, databaseCollectionBuilder)
 
+	if err = m.createIndexes(ctx); err != nil {
+		return nil, errors.Join(err, m.Connection.Close(ctx))
+	}
+
+	if err = m.setLowercaseRoleNames(ctx); err != nil {
+		return nil, errors.Join(err, m.Connection.Close(ctx))
+	}
+
 	return m, nil
 }
 
+// RoleDocument is a role as it is stored, with its lowercased name, so that roles are found by name case-insensitively
+// with an equality match on an index
+type RoleDocument struct {
+	models.Role `bson:",inline"`
+	NameLower   string `bson:"name_lower"`
+}
+
+// NewRoleDocument creates the stored document of a role
+func NewRoleDocument(role *models.Role) RoleDocument {
+	return RoleDocument{Role: *role, NameLower: strings.ToLower(role.Name)}
+}
+
+// roleIndexes are the indexes of the roles collection, which support sorting roles by name, finding roles by name and
+// finding the roles that have a permission or inherit from a role
+var roleIndexes = bson.A{
+	bson.D{{Key: "key", Value: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}}, {Key: "name", Value: "name_1__id_1"}},
+	bson.D{{Key: "key", Value: bson.D{{Key: "name_lower", Value: 1}}}, {Key: "name", Value: "name_lower_1"}},
+	bson.D{{Key: "key", Value: bson.D{{Key: "permissions", Value: 1}}}, {Key: "name", Value: "permissions_1"}},
+	bson.D{{Key: "key", Value: bson.D{{Key: "parents", Value: 1}}}, {Key: "name", Value: "parents_1"}},
+}
+
+// policyIndexes are the indexes of the policies collection, which support counting the policies of a role, and finding
+// the deleted policies
+var policyIndexes = bson.A{
+	bson.D{{Key: "key", Value: bson.D{{Key: "role", Value: 1}}}, {Key: "name", Value: "role_1"}},
+	bson.D{{Key: "key", Value: bson.D{{Key: "deleted_at", Value: 1}}}, {Key: "name", Value: "deleted_at_1"}, {Key: "sparse", Value: true}},
+}
+
+// permissionIndexes are the indexes of the permissions catalogue, which support finding the deprecated permissions.
+// Creating them also creates the catalogue collection, so that the health check of a deployment that has not
+// registered any permissions yet does not fail.
+var permissionIndexes = bson.A{
+	bson.D{{Key: "key", Value: bson.D{{Key: "deprecated", Value: 1}}}, {Key: "name", Value: "deprecated_1"}},
+}
+
+// notDeleted matches the policies that have not been deleted
+var notDeleted = bson.M{"$exists": false}
+
+// createIndexes creates any of the indexes of the collections that do not exist
+func (m *Mongo) createIndexes(ctx context.Context) error {
+	collectionIndexes := []struct {
+		collection string
+		indexes    bson.A
+	}{
+		{collection: config.RolesCollection, indexes: roleIndexes},
+		{collection: config.PoliciesCollection, indexes: policyIndexes},
+		{collection: config.PermissionsCollection, indexes: permissionIndexes},
+	}
+
+	for _, c := range collectionIndexes {
+		collection := m.ActualCollectionName(c.collection)
+		err := m.Connection.RunCommand(ctx, bson.D{
+			{Key: "createIndexes", Value: collection},
+			{Key: "indexes", Value: c.indexes},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create the %s collection indexes: %w", collection, err)
+		}
+	}
+
+	return nil
+}
+
+// setLowercaseRoleNames sets the lowercased name of the roles that were stored without one
+func (m *Mongo) setLowercaseRoleNames(ctx context.Context) error {
+	collection := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection))
+
+	var roles []models.Role
+	if _, err := collection.Find(ctx, bson.M{"name_lower": bson.M{"$exists": false}}, &roles); err != nil {
+		return fmt.Errorf("failed to find the roles without a lowercased name: %w", err)
+	}
+
+	for i := range roles {
+		update := bson.M{"$set": bson.M{"name_lower": strings.ToLower(roles[i].Name)}}
+		if _, err := collection.UpdateOne(ctx, bson.M{"_id": roles[i].ID}, update); err != nil {
+			return fmt.Errorf("failed to set the lowercased name of role %s: %w", roles[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
 // Close the mongo session and returns any error
 // It is an error to call m.Close if m.Init() returned an error, and there is no open connection
 func (m *Mongo) Close(ctx context.Context) error {
@@ -55,12 +154,31 @@ func (m *Mongo) Checker(ctx context.Context, state *healthcheck.CheckState) erro
 	return m.healthClient.Checker(ctx, state)
 }
 
+// startOperation starts a span for a Mongo operation, and returns a function that ends the span and records the
+// duration of the operation. Not found errors are expected outcomes of lookups, so they are not recorded as failures.
+func (m *Mongo) startOperation(ctx context.Context, operation string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "mongo."+operation, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, func(err *error) {
+		operationErr := *err
+		if errors.Is(operationErr, apierrors.ErrRoleNotFound) || errors.Is(operationErr, apierrors.ErrPolicyNotFound) ||
+			errors.Is(operationErr, apierrors.ErrPermissionNotFound) {
+			operationErr = nil
+		}
+		metrics.ObserveMongoOperation(operation, time.Since(start), operationErr)
+		tracing.End(span, operationErr)
+	}
+}
+
 // GetRole retrieves a role document by its ID
-func (m *Mongo) GetRole(ctx context.Context, id string) (*models.Role, error) {
+func (m *Mongo) GetRole(ctx context.Context, id string) (_ *models.Role, err error) {
+	ctx, end := m.startOperation(ctx, "GetRole")
+	defer end(&err)
 	log.Info(ctx, "getting role by ID", log.Data{"id": id})
 
 	var role models.Role
-	err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).FindOne(ctx, bson.M{"_id": id}, &role)
+	err = m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).FindOne(ctx, bson.M{"_id": id}, &role)
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return nil, apierrors.ErrRoleNotFound
@@ -73,7 +191,9 @@ func (m *Mongo) GetRole(ctx context.Context, id string) (*models.Role, error) {
 
 // GetRoles retrieves all role documents from Mongo, according to the provided limit and offset.
 // Offset and limit need to  be positive or zero.
-func (m *Mongo) GetRoles(ctx context.Context, offset, limit int) (*models.Roles, error) {
+func (m *Mongo) GetRoles(ctx context.Context, offset, limit int) (_ *models.Roles, err error) {
+	ctx, end := m.startOperation(ctx, "GetRoles")
+	defer end(&err)
 	if offset < 0 || limit < 0 {
 		return nil, apierrors.ErrLimitAndOffset
 	}
@@ -85,8 +205,146 @@ func (m *Mongo) GetRoles(ctx context.Context, offset, limit int) (*models.Roles,
 	if err != nil {
 		return nil, err
 	}
-	if totalCount == 0 {
-		return nil, apierrors.ErrRoleNotFound
+
+	return &models.Roles{
+		Items:      results,
+		Count:      len(results),
+		TotalCount: totalCount,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// FindRoles retrieves the role documents that match the filter, sorted by the filter's sort order, according to the
+// provided limit and offset. Offset and limit need to be positive or zero.
+func (m *Mongo) FindRoles(ctx context.Context, filter models.RoleFilter, offset, limit int) (_ *models.Roles, err error) {
+	ctx, end := m.startOperation(ctx, "FindRoles")
+	defer end(&err)
+	if offset < 0 || limit < 0 {
+		return nil, apierrors.ErrLimitAndOffset
+	}
+	log.Info(ctx, "querying document store for filtered list of roles", log.Data{"filter": filter})
+
+	results := []models.Role{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).Find(ctx, roleQuery(filter), &results,
+		mongodriver.Sort(roleSort(filter.Sort)), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Roles{
+		Items:      results,
+		Count:      len(results),
+		TotalCount: totalCount,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// roleQuery creates the query for the roles that match the filter
+func roleQuery(filter models.RoleFilter) bson.M {
+	query := bson.M{}
+	if filter.Name != "" {
+		query["name_lower"] = strings.ToLower(filter.Name)
+	}
+	if filter.Permission != "" {
+		query["permissions"] = filter.Permission
+	}
+	if filter.Search != "" {
+		search := bson.M{"$regex": regexp.QuoteMeta(filter.Search), "$options": "i"}
+		query["$or"] = bson.A{bson.M{"_id": search}, bson.M{"name": search}, bson.M{"permissions": search}}
+	}
+	return query
+}
+
+// roleSort creates the sort document for the sort order, sorting roles with the same name by ID
+func roleSort(order models.RoleSort) bson.D {
+	switch order {
+	case models.RoleSortIDDesc:
+		return bson.D{{Key: "_id", Value: -1}}
+	case models.RoleSortName:
+		return bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}
+	case models.RoleSortNameDesc:
+		return bson.D{{Key: "name", Value: -1}, {Key: "_id", Value: -1}}
+	default:
+		return bson.D{{Key: "_id", Value: 1}}
+	}
+}
+
+// AddRole inserts a new role to data store. ErrRoleAlreadyExists is returned if a role with the same ID was inserted
+// concurrently.
+func (m *Mongo) AddRole(ctx context.Context, role *models.Role) (_ *models.Role, err error) {
+	ctx, end := m.startOperation(ctx, "AddRole")
+	defer end(&err)
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).Insert(ctx, NewRoleDocument(role)); err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, apierrors.ErrRoleAlreadyExists
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// UpdateRole replaces the name and permissions of an existing role
+func (m *Mongo) UpdateRole(ctx context.Context, role *models.Role) (err error) {
+	ctx, end := m.startOperation(ctx, "UpdateRole")
+	defer end(&err)
+	log.Info(ctx, "update role by id", log.Data{"id": role.ID})
+
+	updateResult, err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).UpdateById(ctx, role.ID, bson.M{"$set": NewRoleDocument(role)})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return apierrors.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// DeleteRole deletes a role document by its ID
+func (m *Mongo) DeleteRole(ctx context.Context, id string) (err error) {
+	ctx, end := m.startOperation(ctx, "DeleteRole")
+	defer end(&err)
+	log.Info(ctx, "deleting role by id", log.Data{"id": id})
+
+	collectionDeleteResult, err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).DeleteById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if collectionDeleteResult.DeletedCount == 0 {
+		return apierrors.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// GetRolesWithDeprecatedPermissions retrieves the role documents that reference a permission marked as deprecated in
+// the catalogue, according to the provided limit and offset. Offset and limit need to be positive or zero.
+func (m *Mongo) GetRolesWithDeprecatedPermissions(ctx context.Context, offset, limit int) (_ *models.Roles, err error) {
+	ctx, end := m.startOperation(ctx, "GetRolesWithDeprecatedPermissions")
+	defer end(&err)
+	if offset < 0 || limit < 0 {
+		return nil, apierrors.ErrLimitAndOffset
+	}
+	log.Info(ctx, "querying document store for list of roles with deprecated permissions")
+
+	deprecated, err := m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).Distinct(ctx, "_id", bson.M{"deprecated": true})
+	if err != nil {
+		return nil, err
+	}
+
+	results := []models.Role{}
+	totalCount := 0
+	if len(deprecated) > 0 {
+		totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).Find(ctx, bson.M{"permissions": bson.M{"$in": deprecated}}, &results,
+			mongodriver.Sort(bson.M{"_id": 1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &models.Roles{
@@ -99,7 +357,9 @@ func (m *Mongo) GetRoles(ctx context.Context, offset, limit int) (*models.Roles,
 }
 
 // GetAllRoles returns all role documents, without pagination
-func (m *Mongo) GetAllRoles(ctx context.Context) ([]*models.Role, error) {
+func (m *Mongo) GetAllRoles(ctx context.Context) (_ []*models.Role, err error) {
+	ctx, end := m.startOperation(ctx, "GetAllRoles")
+	defer end(&err)
 	var roles []*models.Role
 	if _, err := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection)).Find(ctx, bson.D{}, &roles); err != nil {
 		return nil, err
@@ -108,31 +368,170 @@ func (m *Mongo) GetAllRoles(ctx context.Context) ([]*models.Role, error) {
 	return roles, nil
 }
 
-// GetAllBundlePolicies returns all policy documents for a permissions bundle, without pagination
-func (m *Mongo) GetAllBundlePolicies(ctx context.Context) ([]*models.BundlePolicy, error) {
+// GetRolesGrantingPermission returns the role documents that grant the permission, sorted by ID. These are the roles
+// that have the permission, or a permission pattern that matches it, of their own, and the roles that inherit it from
+// one of their ancestors.
+func (m *Mongo) GetRolesGrantingPermission(ctx context.Context, permission string) (_ []*models.Role, err error) {
+	ctx, end := m.startOperation(ctx, "GetRolesGrantingPermission")
+	defer end(&err)
+	log.Info(ctx, "querying document store for roles granting permission", log.Data{"permission": permission})
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.RolesCollection))
+
+	var roles []*models.Role
+	if _, err := collection.Find(ctx, bson.M{"permissions": bson.M{"$in": models.MatchingPermissionPatterns(permission)}}, &roles); err != nil {
+		return nil, err
+	}
+
+	// find the descendants of the roles one generation at a time, skipping roles that have already been found
+	found := make([]string, 0, len(roles))
+	for _, role := range roles {
+		found = append(found, role.ID)
+	}
+	parents := found
+	for len(parents) > 0 {
+		var children []*models.Role
+		query := bson.M{"parents": bson.M{"$in": parents}, "_id": bson.M{"$nin": found}}
+		if _, err := collection.Find(ctx, query, &children); err != nil {
+			return nil, err
+		}
+
+		parents = make([]string, 0, len(children))
+		for _, child := range children {
+			parents = append(parents, child.ID)
+		}
+		found = append(found, parents...)
+		roles = append(roles, children...)
+	}
+
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles, nil
+}
+
+// CountPoliciesByRole returns the number of policies that bind each of the roles, by role ID. Roles without any
+// policies are omitted, and deleted policies are not counted.
+func (m *Mongo) CountPoliciesByRole(ctx context.Context, roleIDs []string) (_ map[string]int, err error) {
+	ctx, end := m.startOperation(ctx, "CountPoliciesByRole")
+	defer end(&err)
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"role": bson.M{"$in": roleIDs}, "deleted_at": notDeleted}},
+		bson.M{"$group": bson.M{"_id": "$role", "count": bson.M{"$sum": 1}}},
+	}
+
+	var results []struct {
+		Role  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Aggregate(ctx, pipeline, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(results))
+	for _, result := range results {
+		counts[result.Role] = result.Count
+	}
+	return counts, nil
+}
+
+// GetPoliciesByRole retrieves the policy documents that bind a role, ordered by ID, according to the provided limit and
+// offset. Offset and limit need to be positive or zero. Deleted policies are not included.
+func (m *Mongo) GetPoliciesByRole(ctx context.Context, roleID string, offset, limit int) (_ *models.Policies, err error) {
+	ctx, end := m.startOperation(ctx, "GetPoliciesByRole")
+	defer end(&err)
+	if offset < 0 || limit < 0 {
+		return nil, apierrors.ErrLimitAndOffset
+	}
+	log.Info(ctx, "querying document store for policies of role", log.Data{"role_id": roleID})
+
+	results := []models.Policy{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Find(ctx, bson.M{"role": roleID, "deleted_at": notDeleted}, &results,
+		mongodriver.Sort(bson.M{"_id": 1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Policies{
+		Items:      results,
+		Count:      len(results),
+		TotalCount: totalCount,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// DeletePoliciesByRole permanently deletes the policy documents that bind a role, including deleted policies, as they
+// could not be restored without the role, and returns the IDs of the deleted policies
+func (m *Mongo) DeletePoliciesByRole(ctx context.Context, roleID string) (_ []string, err error) {
+	ctx, end := m.startOperation(ctx, "DeletePoliciesByRole")
+	defer end(&err)
+	log.Info(ctx, "deleting policies of role", log.Data{"role_id": roleID})
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+	values, err := collection.Distinct(ctx, "_id", bson.M{"role": roleID})
+	if err != nil {
+		return nil, err
+	}
+
+	policyIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			policyIDs = append(policyIDs, id)
+		}
+	}
+	if len(policyIDs) == 0 {
+		return policyIDs, nil
+	}
+
+	// only the listed policies are deleted, so that every deleted policy is known
+	if _, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": policyIDs}}); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(policyIDs)
+	return policyIDs, nil
+}
+
+// GetAllBundlePolicies returns all policy documents for a permissions bundle, without pagination. Deleted policies are
+// not included.
+func (m *Mongo) GetAllBundlePolicies(ctx context.Context) (_ []*models.BundlePolicy, err error) {
+	ctx, end := m.startOperation(ctx, "GetAllBundlePolicies")
+	defer end(&err)
 	var policies []*models.BundlePolicy
-	if _, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Find(ctx, bson.D{}, &policies); err != nil {
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Find(ctx, bson.M{"deleted_at": notDeleted}, &policies); err != nil {
 		return nil, err
 	}
 
 	return policies, nil
 }
 
-// AddPolicy inserts new policy to data store
-func (m *Mongo) AddPolicy(ctx context.Context, policy *models.Policy) (*models.Policy, error) {
+// AddPolicy inserts new policy to data store. ErrPolicyDeleted is returned if a deleted policy has the ID of the policy.
+func (m *Mongo) AddPolicy(ctx context.Context, policy *models.Policy) (_ *models.Policy, err error) {
+	ctx, end := m.startOperation(ctx, "AddPolicy")
+	defer end(&err)
+	now := writeTime()
+	policy.CreatedAt = now
+	policy.LastUpdated = now
+	policy.Version = 1
+
 	if _, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Insert(ctx, policy); err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, m.duplicatePolicyError(ctx, policy.ID)
+		}
 		return nil, err
 	}
 
 	return policy, nil
 }
 
-// GetPolicy returns a policy given its id
-func (m *Mongo) GetPolicy(ctx context.Context, id string) (*models.Policy, error) {
+// GetPolicy returns a policy given its id. A deleted policy is not found.
+func (m *Mongo) GetPolicy(ctx context.Context, id string) (_ *models.Policy, err error) {
+	ctx, end := m.startOperation(ctx, "GetPolicy")
+	defer end(&err)
 	log.Info(ctx, "getting policy by id", log.Data{"id": id})
 
 	var policy models.Policy
-	err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).FindOne(ctx, bson.M{"_id": id}, &policy)
+	err = m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).FindOne(ctx, bson.M{"_id": id, "deleted_at": notDeleted}, &policy)
 	if err != nil {
 		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
 			return nil, apierrors.ErrPolicyNotFound
@@ -143,38 +542,435 @@ func (m *Mongo) GetPolicy(ctx context.Context, id string) (*models.Policy, error
 	return &policy, nil
 }
 
-// UpdatePolicy updates the given policy, or inserts/creates the given policy if it does not exist
-func (m *Mongo) UpdatePolicy(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+// UpdatePolicy updates the given policy, or inserts/creates the given policy if it does not exist. A deleted policy is
+// not updated, and ErrPolicyDeleted is returned instead.
+func (m *Mongo) UpdatePolicy(ctx context.Context, policy *models.Policy) (_ *models.UpdateResult, err error) {
+	ctx, end := m.startOperation(ctx, "UpdatePolicy")
+	defer end(&err)
 	log.Info(ctx, "update policy by id", log.Data{"id": policy.ID})
+
+	now := writeTime()
 	updatePolicy := bson.M{
-		"$set": policy,
+		"$set": policyUpdate(policy, now),
+		"$inc": incrementVersion,
 		"$setOnInsert": bson.M{
-			"last_updated": time.Now(),
+			"created_at": now,
 		},
 	}
+	policy.LastUpdated = now
 
-	upsertResult, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).UpsertById(ctx, policy.ID, updatePolicy)
+	// a deleted policy is not matched, so inserting the policy fails with a duplicate key error
+	selector := bson.M{"_id": policy.ID, "deleted_at": notDeleted}
+	upsertResult, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).UpsertOne(ctx, selector, updatePolicy)
 	if err != nil {
+		if driver.IsDuplicateKeyError(err) {
+			return nil, m.duplicatePolicyError(ctx, policy.ID)
+		}
 		return nil, err
 	}
 
 	return &models.UpdateResult{ModifiedCount: upsertResult.ModifiedCount, UpsertedCount: upsertResult.UpsertedCount}, nil
 }
 
-// DeletePolicy deletes a policy given its id
-func (m *Mongo) DeletePolicy(ctx context.Context, id string) error {
-	log.Info(ctx, "deleting policy by id", log.Data{"id": id})
+// ReplacePolicy replaces the entities, role and condition of an existing policy, without creating the policy if it
+// does not exist
+func (m *Mongo) ReplacePolicy(ctx context.Context, policy *models.Policy) (err error) {
+	ctx, end := m.startOperation(ctx, "ReplacePolicy")
+	defer end(&err)
+	log.Info(ctx, "replace policy by id", log.Data{"id": policy.ID})
+
+	now := writeTime()
+	selector := bson.M{"_id": policy.ID, "deleted_at": notDeleted}
+	updateResult, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).UpdateOne(ctx, selector,
+		bson.M{"$set": policyUpdate(policy, now), "$inc": incrementVersion})
+	if err != nil {
+		return err
+	}
+	policy.LastUpdated = now
 
-	var collectionDeleteResult *mongodriver.CollectionDeleteResult
+	if updateResult.MatchedCount == 0 {
+		return apierrors.ErrPolicyNotFound
+	}
+
+	return nil
+}
+
+// ReplacePolicyIfUnmodified replaces the entities, role and condition of an existing policy, only if it still has the
+// given version, so that a change made since the policy was read is not lost. ErrPolicyModified is returned if the
+// policy was changed, and ErrPolicyNotFound if it no longer exists or is deleted.
+func (m *Mongo) ReplacePolicyIfUnmodified(ctx context.Context, policy *models.Policy, version int) (err error) {
+	ctx, end := m.startOperation(ctx, "ReplacePolicyIfUnmodified")
+	defer end(&err)
+	log.Info(ctx, "replace policy by id if unmodified", log.Data{"id": policy.ID, "version": version})
+
+	selector := bson.M{"_id": policy.ID, "deleted_at": notDeleted, "version": version}
+	if version == 0 {
+		// the policy has not been written since versions were recorded
+		selector["version"] = bson.M{"$exists": false}
+	}
 
-	collectionDeleteResult, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).DeleteById(ctx, id)
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+	now := writeTime()
+	updateResult, err := collection.UpdateOne(ctx, selector, bson.M{"$set": policyUpdate(policy, now), "$inc": incrementVersion})
 	if err != nil {
 		return err
 	}
 
-	if collectionDeleteResult.DeletedCount == 0 {
+	if updateResult.MatchedCount == 0 {
+		count, err := collection.Count(ctx, bson.M{"_id": policy.ID, "deleted_at": notDeleted})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return apierrors.ErrPolicyNotFound
+		}
+		return apierrors.ErrPolicyModified
+	}
+	policy.LastUpdated = now
+	policy.Version = version + 1
+
+	return nil
+}
+
+// AddPolicyEntity atomically adds an entity to a policy, and reports whether it was added, i.e. it was not already an
+// entity of the policy. The metadata of the policy is only updated if the entity is added.
+func (m *Mongo) AddPolicyEntity(ctx context.Context, policyID, entity, updatedBy string) (_ bool, err error) {
+	ctx, end := m.startOperation(ctx, "AddPolicyEntity")
+	defer end(&err)
+	log.Info(ctx, "adding entity to policy", log.Data{"id": policyID, "entity": entity})
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+
+	selector := bson.M{"_id": policyID, "entities": bson.M{"$ne": entity}, "deleted_at": notDeleted}
+	updateResult, err := collection.UpdateOne(ctx, selector, bson.M{
+		"$addToSet": bson.M{"entities": entity},
+		"$set":      bson.M{"last_updated": writeTime(), "updated_by": updatedBy},
+		"$inc":      incrementVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		// the policy does not exist, or the entity is already an entity of the policy
+		if _, err := m.getPolicyEntities(ctx, policyID); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RemovePolicyEntity atomically removes an entity from a policy, and reports whether it was removed, i.e. it was an
+// entity of the policy. The last entity of a policy is not removed, as a policy must have at least one entity.
+// The metadata of the policy is only updated if the entity is removed.
+func (m *Mongo) RemovePolicyEntity(ctx context.Context, policyID, entity, updatedBy string) (_ bool, err error) {
+	ctx, end := m.startOperation(ctx, "RemovePolicyEntity")
+	defer end(&err)
+	log.Info(ctx, "removing entity from policy", log.Data{"id": policyID, "entity": entity})
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+
+	// a policy whose only entity is the removed entity is not matched
+	selector := bson.M{"_id": policyID, "entities": bson.M{"$eq": entity, "$ne": bson.A{entity}}, "deleted_at": notDeleted}
+	updateResult, err := collection.UpdateOne(ctx, selector, bson.M{
+		"$pull": bson.M{"entities": entity},
+		"$set":  bson.M{"last_updated": writeTime(), "updated_by": updatedBy},
+		"$inc":  incrementVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		// the policy does not exist, the entity is not an entity of the policy, or it is its only entity
+		entities, err := m.getPolicyEntities(ctx, policyID)
+		if err != nil {
+			return false, err
+		}
+		if len(entities) == 1 && entities[0] == entity {
+			return false, apierrors.ErrLastPolicyEntity
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// getPolicyEntities gets the entities of a policy that has not been deleted
+func (m *Mongo) getPolicyEntities(ctx context.Context, policyID string) ([]string, error) {
+	var policy models.Policy
+	if err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).FindOne(ctx, bson.M{"_id": policyID, "deleted_at": notDeleted}, &policy,
+		mongodriver.Projection(bson.M{"entities": 1})); err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, apierrors.ErrPolicyNotFound
+		}
+		return nil, err
+	}
+	return policy.Entities, nil
+}
+
+// incrementVersion increments the version of a policy, which is done by every write of the policy
+var incrementVersion = bson.M{"version": 1}
+
+// policyUpdate returns the fields of a policy that are set when it is written, including its metadata
+func policyUpdate(policy *models.Policy, now time.Time) bson.M {
+	return bson.M{
+		"entities":     policy.Entities,
+		"role":         policy.Role,
+		"condition":    policy.Condition,
+		"last_updated": now,
+		"updated_by":   policy.UpdatedBy,
+	}
+}
+
+// writeTime returns the time of a write, with the millisecond precision of the dates stored in MongoDB
+func writeTime() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// duplicatePolicyError returns the error for a policy that could not be written because a policy with its ID already
+// exists, which is ErrPolicyDeleted if the existing policy is deleted
+func (m *Mongo) duplicatePolicyError(ctx context.Context, policyID string) error {
+	var policy models.Policy
+	if err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).FindOne(ctx, bson.M{"_id": policyID}, &policy,
+		mongodriver.Projection(bson.M{"deleted_at": 1})); err != nil {
+		return err
+	}
+	if !policy.DeletedAt.IsZero() {
+		return apierrors.ErrPolicyDeleted
+	}
+	return apierrors.ErrPolicyAlreadyExists
+}
+
+// DeletePolicy marks a policy as deleted, given its id, so that it is no longer applied but can be restored until it
+// is purged. A policy that is already deleted is not found.
+func (m *Mongo) DeletePolicy(ctx context.Context, id, deletedBy string) (err error) {
+	ctx, end := m.startOperation(ctx, "DeletePolicy")
+	defer end(&err)
+	log.Info(ctx, "deleting policy by id", log.Data{"id": id})
+
+	selector := bson.M{"_id": id, "deleted_at": notDeleted}
+	updateResult, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).UpdateOne(ctx, selector,
+		bson.M{"$set": bson.M{"deleted_at": writeTime(), "deleted_by": deletedBy}, "$inc": incrementVersion})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
 		return apierrors.ErrPolicyNotFound
 	}
 
 	return nil
 }
+
+// GetDeletedPolicies retrieves the deleted policy documents that have not been purged, ordered by ID, according to the
+// provided limit and offset. Offset and limit need to be positive or zero.
+func (m *Mongo) GetDeletedPolicies(ctx context.Context, offset, limit int) (_ *models.Policies, err error) {
+	ctx, end := m.startOperation(ctx, "GetDeletedPolicies")
+	defer end(&err)
+	if offset < 0 || limit < 0 {
+		return nil, apierrors.ErrLimitAndOffset
+	}
+	log.Info(ctx, "querying document store for deleted policies")
+
+	results := []models.Policy{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).Find(ctx, bson.M{"deleted_at": bson.M{"$exists": true}}, &results,
+		mongodriver.Sort(bson.M{"_id": 1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Policies{
+		Items:      results,
+		Count:      len(results),
+		TotalCount: totalCount,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// GetDeletedPolicy retrieves a deleted policy that has not been purged, given its id. ErrPolicyNotDeleted is returned if
+// the policy is not deleted.
+func (m *Mongo) GetDeletedPolicy(ctx context.Context, id string) (_ *models.Policy, err error) {
+	ctx, end := m.startOperation(ctx, "GetDeletedPolicy")
+	defer end(&err)
+	log.Info(ctx, "getting deleted policy by id", log.Data{"id": id})
+
+	var policy models.Policy
+	err = m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection)).FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}}, &policy)
+	if err != nil {
+		if !errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, err
+		}
+		// the policy does not exist, or it is not deleted
+		if _, err := m.getPolicyEntities(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, apierrors.ErrPolicyNotDeleted
+	}
+
+	return &policy, nil
+}
+
+// RestorePolicy restores a deleted policy, given its id. ErrPolicyNotDeleted is returned if the policy is not deleted.
+func (m *Mongo) RestorePolicy(ctx context.Context, id, updatedBy string) (err error) {
+	ctx, end := m.startOperation(ctx, "RestorePolicy")
+	defer end(&err)
+	log.Info(ctx, "restoring policy by id", log.Data{"id": id})
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+
+	selector := bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}}
+	updateResult, err := collection.UpdateOne(ctx, selector, bson.M{
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		"$set":   bson.M{"last_updated": writeTime(), "updated_by": updatedBy},
+		"$inc":   incrementVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		// the policy does not exist, or it is not deleted
+		if _, err := m.getPolicyEntities(ctx, id); err != nil {
+			return err
+		}
+		return apierrors.ErrPolicyNotDeleted
+	}
+
+	return nil
+}
+
+// PurgeDeletedPolicies permanently deletes the policies that were deleted before the given time, and returns the IDs of
+// the purged policies
+func (m *Mongo) PurgeDeletedPolicies(ctx context.Context, deletedBefore time.Time) (_ []string, err error) {
+	ctx, end := m.startOperation(ctx, "PurgeDeletedPolicies")
+	defer end(&err)
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.PoliciesCollection))
+	selector := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	values, err := collection.Distinct(ctx, "_id", selector)
+	if err != nil {
+		return nil, err
+	}
+
+	policyIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			policyIDs = append(policyIDs, id)
+		}
+	}
+	if len(policyIDs) == 0 {
+		return policyIDs, nil
+	}
+
+	// the deletion time is matched again, so that a policy restored since it was listed is not purged
+	if _, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": policyIDs}, "deleted_at": bson.M{"$lt": deletedBefore}}); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(policyIDs)
+	return policyIDs, nil
+}
+
+// GetPermission retrieves a permission from the catalogue by its ID
+func (m *Mongo) GetPermission(ctx context.Context, id string) (_ *models.Permission, err error) {
+	ctx, end := m.startOperation(ctx, "GetPermission")
+	defer end(&err)
+	log.Info(ctx, "getting permission by id", log.Data{"id": id})
+
+	var permission models.Permission
+	err = m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).FindOne(ctx, bson.M{"_id": id}, &permission)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, apierrors.ErrPermissionNotFound
+		}
+		return nil, err
+	}
+
+	return &permission, nil
+}
+
+// GetPermissions retrieves the permissions in the catalogue, according to the provided limit and offset.
+// Offset and limit need to be positive or zero.
+func (m *Mongo) GetPermissions(ctx context.Context, offset, limit int) (_ *models.Permissions, err error) {
+	ctx, end := m.startOperation(ctx, "GetPermissions")
+	defer end(&err)
+	if offset < 0 || limit < 0 {
+		return nil, apierrors.ErrLimitAndOffset
+	}
+	log.Info(ctx, "querying document store for list of permissions")
+
+	results := []models.Permission{}
+	totalCount, err := m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).Find(ctx, bson.D{}, &results,
+		mongodriver.Sort(bson.M{"_id": 1}), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Permissions{
+		Items:      results,
+		Count:      len(results),
+		TotalCount: totalCount,
+		Offset:     offset,
+		Limit:      limit,
+	}, nil
+}
+
+// UpsertPermission registers the given permission in the catalogue, or updates it if it is already registered
+func (m *Mongo) UpsertPermission(ctx context.Context, permission *models.Permission) (_ *models.UpdateResult, err error) {
+	ctx, end := m.startOperation(ctx, "UpsertPermission")
+	defer end(&err)
+	log.Info(ctx, "upsert permission by id", log.Data{"id": permission.ID})
+
+	upsertResult, err := m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).UpsertById(ctx, permission.ID, bson.M{"$set": permission})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UpdateResult{ModifiedCount: upsertResult.ModifiedCount, UpsertedCount: upsertResult.UpsertedCount}, nil
+}
+
+// GetAllPermissions returns all permissions in the catalogue, without pagination
+func (m *Mongo) GetAllPermissions(ctx context.Context) (_ []*models.Permission, err error) {
+	ctx, end := m.startOperation(ctx, "GetAllPermissions")
+	defer end(&err)
+	var permissions []*models.Permission
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).Find(ctx, bson.D{}, &permissions); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// GetUnknownPermissions returns the given permissions that are not registered in the catalogue
+func (m *Mongo) GetUnknownPermissions(ctx context.Context, permissions []string) (_ []string, err error) {
+	ctx, end := m.startOperation(ctx, "GetUnknownPermissions")
+	defer end(&err)
+	if len(permissions) == 0 {
+		return nil, nil
+	}
+
+	known, err := m.Connection.Collection(m.ActualCollectionName(config.PermissionsCollection)).Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": permissions}})
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]struct{}, len(known))
+	for _, id := range known {
+		if permission, ok := id.(string); ok {
+			registered[permission] = struct{}{}
+		}
+	}
+
+	var unknown []string
+	for _, permission := range permissions {
+		if _, ok := registered[permission]; !ok {
+			unknown = append(unknown, permission)
+		}
+	}
+
+	return unknown, nil
+}
@@ -2,8 +2,12 @@ package permissions
 
 import (
 	"context"
+	"time"
 
+	"github.com/ONSdigital/dp-permissions-api/metrics"
 	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/tracing"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 //go:generate moq -out mock/store.go -pkg mock . Store
@@ -12,6 +16,7 @@ import (
 type Store interface {
 	GetAllRoles(ctx context.Context) ([]*models.Role, error)
 	GetAllBundlePolicies(ctx context.Context) ([]*models.BundlePolicy, error)
+	GetAllPermissions(ctx context.Context) ([]*models.Permission, error)
 }
 
 // Bundler creates permission bundle data - a format optimised for evaluating user permissions.
@@ -26,31 +31,82 @@ func NewBundler(store Store) *Bundler {
 	}
 }
 
-// Get the latest bundle data.
-func (b Bundler) Get(ctx context.Context) (models.Bundle, error) {
-	policies, err := b.store.GetAllBundlePolicies(ctx)
+// Get the latest bundle data. The duration and outcome of building the bundle, and its size, are recorded as metrics.
+func (b Bundler) Get(ctx context.Context) (bundle models.Bundle, err error) {
+	ctx, span := tracing.Start(ctx, "permissions.Bundler.Get")
+	defer func(start time.Time) {
+		metrics.ObserveBundle(time.Since(start), bundle, err)
+		tracing.End(span, err)
+	}(time.Now())
+
+	policies, roles, catalogue, err := b.getBundleData(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	roles, err := b.store.GetAllRoles(ctx)
+	createCtx, createSpan := tracing.Start(ctx, "permissions.createBundle")
+	bundle = createBundle(createCtx, policies, roles, catalogue)
+	tracing.End(createSpan, nil)
+
+	return bundle, nil
+}
+
+// Preview the bundle data that would result from a change to a policy or a role, before the change is stored. Unlike
+// Get, the previewed bundle is not recorded as metrics, as it may never be the bundle that is served.
+func (b Bundler) Preview(ctx context.Context, change models.BundleChange) (bundle models.Bundle, err error) {
+	ctx, span := tracing.Start(ctx, "permissions.Bundler.Preview")
+	defer func() { tracing.End(span, err) }()
+
+	policies, roles, catalogue, err := b.getBundleData(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	bundle := createBundle(policies, roles)
+	policies, roles = change.Apply(policies, roles)
+	return createBundle(ctx, policies, roles, catalogue), nil
+}
 
-	return bundle, nil
+func (b Bundler) getBundleData(ctx context.Context) ([]*models.BundlePolicy, []*models.Role, []*models.Permission, error) {
+	policies, err := b.store.GetAllBundlePolicies(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	roles, err := b.store.GetAllRoles(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	catalogue, err := b.store.GetAllPermissions(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return policies, roles, catalogue, nil
 }
 
-func createBundle(policies []*models.BundlePolicy, roles []*models.Role) models.Bundle {
+// createBundle maps each permission to the policies of the roles that grant it, either directly or by inheriting it
+// from a parent role. Wildcard permissions are expanded to the matching known permissions, which are the permissions
+// in the catalogue and the concrete permissions of any role. A role whose inheritance is invalid, because it is part of
+// a cycle or inherits from a role that does not exist, is logged and grants nothing, so that one bad role does not deny
+// every permission to everyone.
+func createBundle(ctx context.Context, policies []*models.BundlePolicy, roles []*models.Role, catalogue []*models.Permission) models.Bundle {
 	roleIDToPolicies := createRoleToPoliciesMap(policies)
+	hierarchy := models.NewRoleHierarchy(roles)
+	knownPermissions := getKnownPermissions(roles, catalogue)
 	bundle := models.Bundle{}
 
 	for _, role := range roles {
 		policiesForRole := roleIDToPolicies[role.ID]
 
-		for _, permission := range role.Permissions {
+		permissions, err := hierarchy.EffectivePermissions(role.ID)
+		if err != nil {
+			log.Error(ctx, "failed to resolve permissions of role, so its policies are left out of the bundle", err, log.Data{"role_id": role.ID})
+			continue
+		}
+		permissions = models.ExpandPermissions(permissions, knownPermissions)
+
+		for _, permission := range permissions {
 			entityLookup, ok := bundle[permission]
 			if !ok {
 				entityLookup = map[string][]*models.BundlePolicy{}
@@ -78,3 +134,14 @@ func createRoleToPoliciesMap(policies []*models.BundlePolicy) map[string][]*mode
 	}
 	return roleIDToPolicies
 }
+
+func getKnownPermissions(roles []*models.Role, catalogue []*models.Permission) []string {
+	knownPermissions := make([]string, 0, len(catalogue))
+	for _, permission := range catalogue {
+		knownPermissions = append(knownPermissions, permission.ID)
+	}
+	for _, role := range roles {
+		knownPermissions = append(knownPermissions, role.Permissions...)
+	}
+	return knownPermissions
+}
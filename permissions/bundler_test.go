@@ -9,6 +9,9 @@ import (
 	"github.com/ONSdigital/dp-permissions-api/permissions"
 	"github.com/ONSdigital/dp-permissions-api/permissions/mock"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestBundler_Get(t *testing.T) {
@@ -81,6 +84,9 @@ func TestBundler_Get(t *testing.T) {
 			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
 				return roles, nil
 			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
 		}
 		bundler := permissions.NewBundler(store)
 
@@ -124,3 +130,285 @@ func TestBundler_Get_StoreError(t *testing.T) {
 		})
 	})
 }
+
+func TestBundler_Preview(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store with an admin policy and a publisher policy", t, func() {
+		adminPolicy := &models.BundlePolicy{ID: "admin", Entities: []string{"groups/admin"}, Role: "admin"}
+		publisherPolicy := &models.BundlePolicy{ID: "publisher", Entities: []string{"groups/publisher"}, Role: "publisher"}
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{adminPolicy, publisherPolicy}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{
+					{ID: "admin", Permissions: []string{models.PoliciesUpdate}},
+					{ID: "publisher", Permissions: []string{"legacy.read"}},
+				}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the bundle is previewed with the admin policy deleted", func() {
+			bundle, err := bundler.Preview(ctx, models.BundleChange{DeletedPolicyID: "admin"})
+
+			Convey("Then the bundle does not include the admin policy", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, models.Bundle{
+					models.PoliciesUpdate: {},
+					"legacy.read": {
+						"groups/publisher": {publisherPolicy},
+					},
+				})
+			})
+		})
+
+		Convey("When the bundle is previewed with the publisher role granting policies:update", func() {
+			bundle, err := bundler.Preview(ctx, models.BundleChange{Role: &models.Role{ID: "publisher", Permissions: []string{models.PoliciesUpdate}}})
+
+			Convey("Then the publisher policy grants policies:update", func() {
+				So(err, ShouldBeNil)
+				So(bundle[models.PoliciesUpdate], ShouldResemble, models.EntityIDToPolicies{
+					"groups/admin":     {adminPolicy},
+					"groups/publisher": {publisherPolicy},
+				})
+				So(bundle, ShouldNotContainKey, "legacy.read")
+			})
+		})
+	})
+
+	Convey("Given a store that returns an error", t, func() {
+		expectedErr := errors.New("store is broken")
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return nil, expectedErr
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the bundle is previewed, then the error is returned", func() {
+			bundle, err := bundler.Preview(ctx, models.BundleChange{DeletedPolicyID: "admin"})
+			So(err, ShouldEqual, expectedErr)
+			So(bundle, ShouldBeNil)
+		})
+	})
+}
+
+func TestBundler_Get_InheritedPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store with roles that inherit permissions from parent roles", t, func() {
+		roles := []*models.Role{
+			{ID: "previewer", Permissions: []string{"legacy.read"}},
+			{ID: "author", Permissions: []string{"legacy.update"}, Parents: []string{"previewer"}},
+			{ID: "admin", Permissions: []string{"users.add", "legacy.read"}, Parents: []string{"author"}},
+		}
+		authorPolicy := &models.BundlePolicy{ID: "author", Entities: []string{"groups/author"}, Role: "author"}
+		adminPolicy := &models.BundlePolicy{ID: "admin", Entities: []string{"groups/admin"}, Role: "admin"}
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{authorPolicy, adminPolicy}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return roles, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called", func() {
+			bundle, err := bundler.Get(ctx)
+
+			Convey("Then the bundle grants the inherited permissions to the policies of the child roles, without duplicates", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, models.Bundle{
+					"legacy.read": {
+						"groups/author": {authorPolicy},
+						"groups/admin":  {adminPolicy},
+					},
+					"legacy.update": {
+						"groups/author": {authorPolicy},
+						"groups/admin":  {adminPolicy},
+					},
+					"users.add": {
+						"groups/admin": {adminPolicy},
+					},
+				})
+			})
+		})
+	})
+
+	Convey("Given a store with roles whose inheritance forms a cycle", t, func() {
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{
+					{ID: "policy-a", Entities: []string{"groups/a"}, Role: "a"},
+					{ID: "policy-c", Entities: []string{"groups/c"}, Role: "c"},
+				}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{
+					{ID: "a", Permissions: []string{"legacy.read"}, Parents: []string{"b"}},
+					{ID: "b", Permissions: []string{"legacy.update"}, Parents: []string{"a"}},
+					{ID: "c", Permissions: []string{"legacy.delete"}},
+				}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called", func() {
+			bundle, err := bundler.Get(ctx)
+
+			Convey("Then the roles of the cycle grant nothing, and the rest of the bundle is created", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, models.Bundle{
+					"legacy.delete": models.EntityIDToPolicies{
+						"groups/c": {{ID: "policy-c", Entities: []string{"groups/c"}, Role: "c"}},
+					},
+				})
+			})
+		})
+	})
+
+	Convey("Given a store with a role whose parent role does not exist", t, func() {
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{{ID: "a", Permissions: []string{"legacy.read"}, Parents: []string{"missing"}}}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called, the role grants nothing, and an empty bundle is returned", func() {
+			bundle, err := bundler.Get(ctx)
+
+			So(err, ShouldBeNil)
+			So(bundle, ShouldResemble, models.Bundle{})
+		})
+	})
+}
+
+func TestBundler_Get_WildcardPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store with roles that have wildcard permissions", t, func() {
+		datasetsAdminPolicy := &models.BundlePolicy{ID: "datasets-admin", Entities: []string{"groups/datasets-admin"}, Role: "datasets-admin"}
+		readerPolicy := &models.BundlePolicy{ID: "reader", Entities: []string{"groups/reader"}, Role: "reader"}
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{datasetsAdminPolicy, readerPolicy}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{
+					{ID: "datasets-admin", Permissions: []string{"datasets:*"}},
+					{ID: "reader", Permissions: []string{"*:read", "legacy:read"}},
+					{ID: "publisher", Permissions: []string{"legacy:read", "legacy:publish"}},
+				}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return []*models.Permission{{ID: "datasets:read"}, {ID: "datasets:update"}, {ID: "users:read"}}, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called", func() {
+			bundle, err := bundler.Get(ctx)
+
+			Convey("Then the wildcards are expanded to the matching permissions of the catalogue and the other roles", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, models.Bundle{
+					"datasets:read": {
+						"groups/datasets-admin": {datasetsAdminPolicy},
+						"groups/reader":         {readerPolicy},
+					},
+					"datasets:update": {
+						"groups/datasets-admin": {datasetsAdminPolicy},
+					},
+					"users:read": {
+						"groups/reader": {readerPolicy},
+					},
+					"legacy:read": {
+						"groups/reader": {readerPolicy},
+					},
+					"legacy:publish": {},
+				})
+			})
+
+			Convey("Then the wildcard patterns themselves are not included in the bundle", func() {
+				So(bundle, ShouldNotContainKey, "datasets:*")
+				So(bundle, ShouldNotContainKey, "*:read")
+			})
+		})
+	})
+
+	Convey("Given a store that fails to return the permissions catalogue", t, func() {
+		expectedErr := errors.New("catalogue is broken")
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, expectedErr
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called, the error is returned", func() {
+			bundle, err := bundler.Get(ctx)
+
+			So(err, ShouldEqual, expectedErr)
+			So(bundle, ShouldBeNil)
+		})
+	})
+}
+
+func TestBundler_Get_Tracing(t *testing.T) {
+	ctx := context.Background()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	Convey("Given a store that returns permissions data", t, func() {
+		store := &mock.StoreMock{
+			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+				return []*models.BundlePolicy{{ID: "policy1", Entities: []string{"groups/admin"}, Role: "admin"}}, nil
+			},
+			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+				return []*models.Role{{ID: "admin", Permissions: []string{"legacy.read"}}}, nil
+			},
+			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+				return nil, nil
+			},
+		}
+		bundler := permissions.NewBundler(store)
+
+		Convey("When the Get function is called", func() {
+			_, err := bundler.Get(ctx)
+			So(err, ShouldBeNil)
+
+			Convey("Then the bundle creation is traced as a child of the Get span", func() {
+				spans := recorder.Ended()
+				So(spans, ShouldHaveLength, 2)
+				So(spans[0].Name(), ShouldEqual, "permissions.createBundle")
+				So(spans[1].Name(), ShouldEqual, "permissions.Bundler.Get")
+				So(spans[0].Parent().SpanID(), ShouldEqual, spans[1].SpanContext().SpanID())
+			})
+		})
+	})
+}
@@ -16,26 +16,32 @@ var _ permissions.Store = &StoreMock{}
 
 // StoreMock is a mock implementation of permissions.Store.
 //
-// 	func TestSomethingThatUsesStore(t *testing.T) {
+//	func TestSomethingThatUsesStore(t *testing.T) {
 //
-// 		// make and configure a mocked permissions.Store
-// 		mockedStore := &StoreMock{
-// 			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
-// 				panic("mock out the GetAllBundlePolicies method")
-// 			},
-// 			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
-// 				panic("mock out the GetAllRoles method")
-// 			},
-// 		}
+//		// make and configure a mocked permissions.Store
+//		mockedStore := &StoreMock{
+//			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+//				panic("mock out the GetAllBundlePolicies method")
+//			},
+//			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+//				panic("mock out the GetAllPermissions method")
+//			},
+//			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+//				panic("mock out the GetAllRoles method")
+//			},
+//		}
 //
-// 		// use mockedStore in code that requires permissions.Store
-// 		// and then make assertions.
+//		// use mockedStore in code that requires permissions.Store
+//		// and then make assertions.
 //
-// 	}
+//	}
 type StoreMock struct {
 	// GetAllBundlePoliciesFunc mocks the GetAllBundlePolicies method.
 	GetAllBundlePoliciesFunc func(ctx context.Context) ([]*models.BundlePolicy, error)
 
+	// GetAllPermissionsFunc mocks the GetAllPermissions method.
+	GetAllPermissionsFunc func(ctx context.Context) ([]*models.Permission, error)
+
 	// GetAllRolesFunc mocks the GetAllRoles method.
 	GetAllRolesFunc func(ctx context.Context) ([]*models.Role, error)
 
@@ -46,6 +52,11 @@ type StoreMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetAllPermissions holds details about calls to the GetAllPermissions method.
+		GetAllPermissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetAllRoles holds details about calls to the GetAllRoles method.
 		GetAllRoles []struct {
 			// Ctx is the ctx argument value.
@@ -53,6 +64,7 @@ type StoreMock struct {
 		}
 	}
 	lockGetAllBundlePolicies sync.RWMutex
+	lockGetAllPermissions    sync.RWMutex
 	lockGetAllRoles          sync.RWMutex
 }
 
@@ -74,7 +86,8 @@ func (mock *StoreMock) GetAllBundlePolicies(ctx context.Context) ([]*models.Bund
 
 // GetAllBundlePoliciesCalls gets all the calls that were made to GetAllBundlePolicies.
 // Check the length with:
-//     len(mockedStore.GetAllBundlePoliciesCalls())
+//
+//	len(mockedStore.GetAllBundlePoliciesCalls())
 func (mock *StoreMock) GetAllBundlePoliciesCalls() []struct {
 	Ctx context.Context
 } {
@@ -87,6 +100,38 @@ func (mock *StoreMock) GetAllBundlePoliciesCalls() []struct {
 	return calls
 }
 
+// GetAllPermissions calls GetAllPermissionsFunc.
+func (mock *StoreMock) GetAllPermissions(ctx context.Context) ([]*models.Permission, error) {
+	if mock.GetAllPermissionsFunc == nil {
+		panic("StoreMock.GetAllPermissionsFunc: method is nil but Store.GetAllPermissions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllPermissions.Lock()
+	mock.calls.GetAllPermissions = append(mock.calls.GetAllPermissions, callInfo)
+	mock.lockGetAllPermissions.Unlock()
+	return mock.GetAllPermissionsFunc(ctx)
+}
+
+// GetAllPermissionsCalls gets all the calls that were made to GetAllPermissions.
+// Check the length with:
+//
+//	len(mockedStore.GetAllPermissionsCalls())
+func (mock *StoreMock) GetAllPermissionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllPermissions.RLock()
+	calls = mock.calls.GetAllPermissions
+	mock.lockGetAllPermissions.RUnlock()
+	return calls
+}
+
 // GetAllRoles calls GetAllRolesFunc.
 func (mock *StoreMock) GetAllRoles(ctx context.Context) ([]*models.Role, error) {
 	if mock.GetAllRolesFunc == nil {
@@ -105,7 +150,8 @@ func (mock *StoreMock) GetAllRoles(ctx context.Context) ([]*models.Role, error)
 
 // GetAllRolesCalls gets all the calls that were made to GetAllRoles.
 // Check the length with:
-//     len(mockedStore.GetAllRolesCalls())
+//
+//	len(mockedStore.GetAllRolesCalls())
 func (mock *StoreMock) GetAllRolesCalls() []struct {
 	Ctx context.Context
 } {
@@ -0,0 +1,83 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	"github.com/ONSdigital/dp-permissions-api/purge"
+	"sync"
+	"time"
+)
+
+// Ensure, that StoreMock does implement purge.Store.
+// If this is not the case, regenerate this file with moq.
+var _ purge.Store = &StoreMock{}
+
+// StoreMock is a mock implementation of purge.Store.
+//
+//	func TestSomethingThatUsesStore(t *testing.T) {
+//
+//		// make and configure a mocked purge.Store
+//		mockedStore := &StoreMock{
+//			PurgeDeletedPoliciesFunc: func(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+//				panic("mock out the PurgeDeletedPolicies method")
+//			},
+//		}
+//
+//		// use mockedStore in code that requires purge.Store
+//		// and then make assertions.
+//
+//	}
+type StoreMock struct {
+	// PurgeDeletedPoliciesFunc mocks the PurgeDeletedPolicies method.
+	PurgeDeletedPoliciesFunc func(ctx context.Context, deletedBefore time.Time) ([]string, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// PurgeDeletedPolicies holds details about calls to the PurgeDeletedPolicies method.
+		PurgeDeletedPolicies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DeletedBefore is the deletedBefore argument value.
+			DeletedBefore time.Time
+		}
+	}
+	lockPurgeDeletedPolicies sync.RWMutex
+}
+
+// PurgeDeletedPolicies calls PurgeDeletedPoliciesFunc.
+func (mock *StoreMock) PurgeDeletedPolicies(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	if mock.PurgeDeletedPoliciesFunc == nil {
+		panic("StoreMock.PurgeDeletedPoliciesFunc: method is nil but Store.PurgeDeletedPolicies was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}{
+		Ctx:           ctx,
+		DeletedBefore: deletedBefore,
+	}
+	mock.lockPurgeDeletedPolicies.Lock()
+	mock.calls.PurgeDeletedPolicies = append(mock.calls.PurgeDeletedPolicies, callInfo)
+	mock.lockPurgeDeletedPolicies.Unlock()
+	return mock.PurgeDeletedPoliciesFunc(ctx, deletedBefore)
+}
+
+// PurgeDeletedPoliciesCalls gets all the calls that were made to PurgeDeletedPolicies.
+// Check the length with:
+//
+//	len(mockedStore.PurgeDeletedPoliciesCalls())
+func (mock *StoreMock) PurgeDeletedPoliciesCalls() []struct {
+	Ctx           context.Context
+	DeletedBefore time.Time
+} {
+	var calls []struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}
+	mock.lockPurgeDeletedPolicies.RLock()
+	calls = mock.calls.PurgeDeletedPolicies
+	mock.lockPurgeDeletedPolicies.RUnlock()
+	return calls
+}
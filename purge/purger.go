@@ -0,0 +1,99 @@
+package purge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+//go:generate moq -out mock/store.go -pkg mock . Store
+
+// Store defines the behaviour of a PermissionsStore as used by the Purger type.
+type Store interface {
+	PurgeDeletedPolicies(ctx context.Context, deletedBefore time.Time) ([]string, error)
+}
+
+// DefaultInterval is the purge interval of a Purger that is created without a positive purge interval
+const DefaultInterval = time.Hour
+
+// Purger permanently deletes the policies that were deleted longer ago than the retention period, so that deleted
+// policies can be restored for the retention period.
+type Purger struct {
+	store     Store
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+	stop      chan struct{}
+	done      sync.WaitGroup
+}
+
+// NewPurger creates a new Purger instance, which purges the deleted policies at the given interval once started. An
+// interval that is not positive is replaced with DefaultInterval, as a ticker cannot be started with it.
+func NewPurger(store Store, retention, interval time.Duration) *Purger {
+	if interval <= 0 {
+		log.Warn(context.Background(), "invalid policy purge interval, using the default", log.Data{"interval": interval, "default": DefaultInterval})
+		interval = DefaultInterval
+	}
+
+	return &Purger{
+		store:     store,
+		retention: retention,
+		interval:  interval,
+		now:       time.Now,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Interval returns the interval at which the purger purges the deleted policies once started.
+func (p *Purger) Interval() time.Duration {
+	return p.interval
+}
+
+// Purge permanently deletes the policies that were deleted longer ago than the retention period, and returns the IDs of
+// the purged policies.
+func (p *Purger) Purge(ctx context.Context) ([]string, error) {
+	deletedBefore := p.now().Add(-p.retention)
+
+	policyIDs, err := p.store.PurgeDeletedPolicies(ctx, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policyID := range policyIDs {
+		log.Info(ctx, "purged deleted policy", log.Data{"policy_id": policyID, "deleted_before": deletedBefore})
+	}
+	return policyIDs, nil
+}
+
+// Start purges the deleted policies at the purge interval, until the purger is stopped. Errors are logged, and the
+// policies are purged again at the next interval.
+func (p *Purger) Start(ctx context.Context) {
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := p.Purge(ctx); err != nil {
+					log.Error(ctx, "failed to purge deleted policies", err)
+				}
+			case <-p.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops purging the deleted policies, and waits for a purge in progress to finish.
+func (p *Purger) Stop() {
+	close(p.stop)
+	p.done.Wait()
+}
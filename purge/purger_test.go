@@ -0,0 +1,112 @@
+package purge_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-permissions-api/purge"
+	"github.com/ONSdigital/dp-permissions-api/purge/mock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const retention = 30 * 24 * time.Hour
+
+func TestPurger_Purge(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a store with deleted policies that are older than the retention period", t, func() {
+		store := &mock.StoreMock{
+			PurgeDeletedPoliciesFunc: func(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+				return []string{"policy1", "policy2"}, nil
+			},
+		}
+		purger := purge.NewPurger(store, retention, time.Hour)
+
+		Convey("When the deleted policies are purged", func() {
+			start := time.Now()
+			policyIDs, err := purger.Purge(ctx)
+
+			Convey("Then the policies deleted before the retention period are purged", func() {
+				So(err, ShouldBeNil)
+				So(policyIDs, ShouldResemble, []string{"policy1", "policy2"})
+				So(store.PurgeDeletedPoliciesCalls(), ShouldHaveLength, 1)
+				So(store.PurgeDeletedPoliciesCalls()[0].DeletedBefore, ShouldHappenOnOrBetween, start.Add(-retention), time.Now().Add(-retention))
+			})
+		})
+	})
+
+	Convey("Given a store that returns an error", t, func() {
+		store := &mock.StoreMock{
+			PurgeDeletedPoliciesFunc: func(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+				return nil, errors.New("store error")
+			},
+		}
+		purger := purge.NewPurger(store, retention, time.Hour)
+
+		Convey("When the deleted policies are purged, then the error is returned", func() {
+			policyIDs, err := purger.Purge(ctx)
+			So(err, ShouldResemble, errors.New("store error"))
+			So(policyIDs, ShouldBeNil)
+		})
+	})
+}
+
+func TestNewPurger(t *testing.T) {
+	Convey("Given a purge interval that is not positive", t, func() {
+		for _, interval := range []time.Duration{0, -time.Minute} {
+			Convey(fmt.Sprintf("When a purger is created with an interval of %s", interval), func() {
+				purger := purge.NewPurger(&mock.StoreMock{}, retention, interval)
+
+				Convey("Then the default interval is used, and the purger can be started and stopped", func() {
+					So(purger.Interval(), ShouldEqual, purge.DefaultInterval)
+					So(func() {
+						purger.Start(context.Background())
+						purger.Stop()
+					}, ShouldNotPanic)
+				})
+			})
+		}
+	})
+
+	Convey("Given a positive purge interval", t, func() {
+		Convey("When a purger is created, then the interval is used", func() {
+			purger := purge.NewPurger(&mock.StoreMock{}, retention, time.Minute)
+			So(purger.Interval(), ShouldEqual, time.Minute)
+		})
+	})
+}
+
+func TestPurger_Start(t *testing.T) {
+	Convey("Given a started purger with a short purge interval", t, func() {
+		var purges atomic.Int32
+		store := &mock.StoreMock{
+			PurgeDeletedPoliciesFunc: func(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+				if purges.Add(1) == 1 {
+					return nil, errors.New("store error")
+				}
+				return []string{}, nil
+			},
+		}
+		purger := purge.NewPurger(store, retention, time.Millisecond)
+		purger.Start(context.Background())
+
+		Convey("Then the deleted policies are purged repeatedly, even after an error, until the purger is stopped", func() {
+			So(func() bool {
+				deadline := time.Now().Add(time.Second)
+				for purges.Load() < 2 && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				return purges.Load() >= 2
+			}(), ShouldBeTrue)
+
+			purger.Stop()
+			stopped := purges.Load()
+			time.Sleep(10 * time.Millisecond)
+			So(purges.Load(), ShouldEqual, stopped)
+		})
+	})
+}
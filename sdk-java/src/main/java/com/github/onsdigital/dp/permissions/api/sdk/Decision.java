@@ -0,0 +1,61 @@
+package com.github.onsdigital.dp.permissions.api.sdk;
+
+import com.github.onsdigital.dp.permissions.api.sdk.model.BundlePolicy;
+
+import lombok.Getter;
+
+/**
+ * The outcome of evaluating whether a user has a permission.
+ */
+@Getter
+public final class Decision {
+
+    /**
+     * The decision of a permission that no policy grants.
+     */
+    private static final Decision DENIED = new Decision(false, null, null);
+
+    /**
+     * True if a policy grants the permission.
+     */
+    private final boolean allowed;
+
+    /**
+     * The user or group entity that the matching policy applies to, e.g.
+     * groups/admin, or null if the permission is denied.
+     */
+    private final String entity;
+
+    /**
+     * The policy that grants the permission, or null if the permission is
+     * denied.
+     */
+    private final BundlePolicy policy;
+
+    private Decision(final boolean isAllowed, final String policyEntity,
+            final BundlePolicy matchingPolicy) {
+        this.allowed = isAllowed;
+        this.entity = policyEntity;
+        this.policy = matchingPolicy;
+    }
+
+    /**
+     * Create the decision of a permission granted by a policy.
+     *
+     * @param entity the entity that the policy applies to
+     * @param policy the policy that grants the permission
+     * @return the decision
+     */
+    static Decision allowed(final String entity, final BundlePolicy policy) {
+        return new Decision(true, entity, policy);
+    }
+
+    /**
+     * Get the decision of a permission that no policy grants.
+     *
+     * @return the decision
+     */
+    static Decision denied() {
+        return DENIED;
+    }
+}
@@ -0,0 +1,70 @@
+package com.github.onsdigital.dp.permissions.api.sdk;
+
+import java.util.List;
+import java.util.Map;
+
+import com.github.onsdigital.dp.permissions.api.sdk.model.BundlePolicy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.EntityData;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PermissionsBundle;
+
+/**
+ * Decides whether users have permissions using a permissions bundle, in the
+ * same way as the Evaluator of the Go SDK, so that services don't need to
+ * implement the evaluation themselves.
+ */
+public class Evaluator {
+
+    /**
+     * The permissions bundle that permissions are evaluated against.
+     */
+    private final PermissionsBundle bundle;
+
+    /**
+     * Create an Evaluator for the given permissions bundle.
+     *
+     * @param permissionsBundle the permissions bundle
+     */
+    public Evaluator(final PermissionsBundle permissionsBundle) {
+        if (permissionsBundle == null) {
+            throw new IllegalArgumentException(
+                    "'permissionsBundle' must not be null");
+        }
+        this.bundle = permissionsBundle;
+    }
+
+    /**
+     * Decides whether the user, or one of their groups, has the permission
+     * given the attributes of the request. The permission is allowed by the
+     * first policy of the user's entities, the user first followed by their
+     * groups, whose condition is met. A policy without a condition always
+     * applies. The permission is denied if no policy applies.
+     *
+     * @param entityData the user and groups of the request
+     * @param permission the permission, e.g. datasets:edit
+     * @param attributes the attributes of the request, e.g. collection_id,
+     *                   or null if it has none
+     * @return the decision, with the policy that allows the permission
+     */
+    public Decision evaluate(final EntityData entityData,
+            final String permission, final Map<String, String> attributes) {
+        Map<String, List<BundlePolicy>> entityLookup = bundle.get(permission);
+        if (entityLookup == null || entityData == null) {
+            return Decision.denied();
+        }
+
+        for (String entity : entityData.getEntities()) {
+            List<BundlePolicy> policies = entityLookup.get(entity);
+            if (policies == null) {
+                continue;
+            }
+            for (BundlePolicy policy : policies) {
+                if (policy.getCondition() == null
+                        || policy.getCondition().isMet(attributes)) {
+                    return Decision.allowed(entity, policy);
+                }
+            }
+        }
+
+        return Decision.denied();
+    }
+}
@@ -1,19 +1,61 @@
 package com.github.onsdigital.dp.permissions.api.sdk;
 
 import java.io.IOException;
+import java.io.UnsupportedEncodingException;
 import java.net.URI;
 import java.net.URISyntaxException;
+import java.net.URLEncoder;
+import java.nio.charset.StandardCharsets;
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.databind.DeserializationFeature;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.BadRequestException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.EntityNotPermittedException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidAuthTokenException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidLimitQueryParameterMaxExceededException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidPatchException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidPermissionException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidPolicyException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidQueryParameterException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidRoleException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.JSONUnmarshalException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.LastPolicyEntityException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.LockoutException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PatchTestFailedException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PermissionNotFoundException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.PermissionsAPIException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyAlreadyExistsException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyDeletedException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyModifiedException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyNotDeletedException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyNotFoundException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.ProtectedPolicyException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.RoleAlreadyExistsException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.RoleInUseException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.RoleNotFoundException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.UnknownEntitiesException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.UnknownPermissionsException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.UnsupportedMediaTypeException;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PermissionsBundle;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Policy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PolicyInfo;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Role;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Roles;
 import org.apache.hc.client5.http.impl.classic.CloseableHttpClient;
 import org.apache.hc.client5.http.impl.classic.HttpClients;
+import org.apache.hc.core5.http.ContentType;
+import org.apache.hc.core5.http.HttpHeaders;
 import org.apache.hc.core5.http.HttpStatus;
 import org.apache.hc.client5.http.classic.methods.HttpDelete;
+import org.apache.hc.client5.http.classic.methods.HttpGet;
+import org.apache.hc.client5.http.classic.methods.HttpPost;
+import org.apache.hc.client5.http.classic.methods.HttpPut;
 import org.apache.hc.client5.http.classic.methods.HttpUriRequestBase;
 import org.apache.hc.client5.http.classic.methods.HttpUriRequest;
 import org.apache.hc.core5.http.io.HttpClientResponseHandler;
 import org.apache.hc.core5.http.io.entity.EntityUtils;
+import org.apache.hc.core5.http.io.entity.StringEntity;
 
 public class PermissionsAPIClient implements PermissionsClient {
 
@@ -37,6 +79,169 @@ public class PermissionsAPIClient implements PermissionsClient {
      */
     private static final String SERVICE_TOKEN_HEADER_NAME = "Authorization";
 
+    /**
+     * Path of the roles endpoints.
+     */
+    private static final String ROLES_PATH = "/v1/roles";
+
+    /**
+     * Path of the policies endpoints.
+     */
+    private static final String POLICIES_PATH = "/v1/policies";
+
+    /**
+     * Path of the permissions bundle endpoint.
+     */
+    private static final String PERMISSIONS_BUNDLE_PATH =
+            "/v1/permissions-bundle";
+
+    /**
+     * Error code of a policy that does not exist.
+     */
+    private static final String POLICY_NOT_FOUND_ERROR = "PolicyNotFoundError";
+
+    /**
+     * Error code of a role that does not exist.
+     */
+    private static final String ROLE_NOT_FOUND_ERROR = "RoleNotFoundError";
+
+    /**
+     * Error code of a policy that already exists with the given ID.
+     */
+    private static final String POLICY_ALREADY_EXISTS_ERROR =
+            "PolicyAlreadyExistsError";
+
+    /**
+     * Error code of an invalid policy.
+     */
+    private static final String INVALID_POLICY_ERROR = "InvalidPolicyError";
+
+    /**
+     * Error code of a policy with users or groups that do not exist.
+     */
+    private static final String UNKNOWN_ENTITIES_ERROR =
+            "UnknownEntitiesError";
+
+    /**
+     * Error code of a request body that is not valid JSON.
+     */
+    private static final String JSON_UNMARSHAL_ERROR = "JSONUnmarshalError";
+
+    /**
+     * Error code of an invalid query parameter.
+     */
+    private static final String INVALID_QUERY_PARAMETER_ERROR =
+            "InvalidQueryParameter";
+
+    /**
+     * Error code of a limit query parameter above the maximum limit.
+     */
+    private static final String INVALID_LIMIT_QUERY_PARAMETER_MAX_EXCEEDED_ERROR =
+            "InvalidLimitQueryParameterMaxExceeded";
+
+    /**
+     * Error code of an invalid role.
+     */
+    private static final String INVALID_ROLE_ERROR = "InvalidRoleError";
+
+    /**
+     * Error code of an invalid permission.
+     */
+    private static final String INVALID_PERMISSION_ERROR =
+            "InvalidPermissionError";
+
+    /**
+     * Error code of a role with permissions that are not registered.
+     */
+    private static final String UNKNOWN_PERMISSIONS_ERROR =
+            "UnknownPermissionsError";
+
+    /**
+     * Error code of an invalid JSON patch.
+     */
+    private static final String INVALID_PATCH_ERROR = "InvalidPatchError";
+
+    /**
+     * Error code of a missing or invalid auth token.
+     */
+    private static final String INVALID_AUTH_TOKEN_ERROR =
+            "InvalidAuthTokenError";
+
+    /**
+     * Error code of an entity whose bundle the caller may not get.
+     */
+    private static final String ENTITY_NOT_PERMITTED_ERROR =
+            "EntityNotPermittedError";
+
+    /**
+     * Error code of a permission that does not exist.
+     */
+    private static final String PERMISSION_NOT_FOUND_ERROR =
+            "PermissionNotFoundError";
+
+    /**
+     * Error code of a role that already exists with the given ID or name.
+     */
+    private static final String ROLE_ALREADY_EXISTS_ERROR =
+            "RoleAlreadyExistsError";
+
+    /**
+     * Error code of a role that is bound by policies or is a parent role.
+     */
+    private static final String ROLE_IN_USE_ERROR = "RoleInUseError";
+
+    /**
+     * Error code of removing the last entity of a policy.
+     */
+    private static final String LAST_POLICY_ENTITY_ERROR =
+            "LastPolicyEntityError";
+
+    /**
+     * Error code of a JSON patch test operation that failed.
+     */
+    private static final String PATCH_TEST_FAILED_ERROR =
+            "PatchTestFailedError";
+
+    /**
+     * Error code of a deleted policy that is changed.
+     */
+    private static final String POLICY_DELETED_ERROR = "PolicyDeletedError";
+
+    /**
+     * Error code of restoring a policy that is not deleted.
+     */
+    private static final String POLICY_NOT_DELETED_ERROR =
+            "PolicyNotDeletedError";
+
+    /**
+     * Error code of a change that would lock everyone out of policy
+     * administration.
+     */
+    private static final String LOCKOUT_ERROR = "LockoutError";
+
+    /**
+     * Error code of changing a protected policy without override.
+     */
+    private static final String PROTECTED_POLICY_ERROR = "ProtectedPolicyError";
+
+    /**
+     * Error code of a policy that was changed while it was patched.
+     */
+    private static final String POLICY_MODIFIED_ERROR = "PolicyModifiedError";
+
+    /**
+     * Error code of an unsupported request content type.
+     */
+    private static final String UNSUPPORTED_MEDIA_TYPE_ERROR =
+            "UnsupportedMediaTypeError";
+
+    /**
+     * JSON mapper for request and response bodies.
+     */
+    private static final ObjectMapper JSON = new ObjectMapper()
+            .configure(DeserializationFeature.FAIL_ON_UNKNOWN_PROPERTIES,
+                    false);
+
     /**
      * Create a new instance of PermissionsAPIClient.
      *
@@ -70,6 +275,162 @@ public class PermissionsAPIClient implements PermissionsClient {
         return HttpClients.createDefault();
     }
 
+    /**
+     * Gets the first page of roles by sending a GET request to /roles.
+     *
+     * @return the page of roles
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Roles getRoles()
+            throws IOException, BadRequestException, PermissionsAPIException {
+
+        HttpGet req = new HttpGet(permissionsAPIUri.resolve(ROLES_PATH));
+        ResponseResult response = sendNonPolicyRequest(req,
+                ROLE_NOT_FOUND_ERROR, HttpStatus.SC_OK);
+        return JSON.readValue(response.getBody(), Roles.class);
+    }
+
+    /**
+     * Gets a page of roles by sending a GET request to /roles with the
+     * given offset and limit.
+     *
+     * @param offset the number of roles to skip
+     * @param limit  the maximum number of roles to return
+     * @return the page of roles
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Roles getRoles(final int offset, final int limit)
+            throws IOException, BadRequestException, PermissionsAPIException {
+
+        if (offset < 0) {
+            throw new IllegalArgumentException(
+                    "'offset' must not be negative");
+        }
+        if (limit <= 0) {
+            throw new IllegalArgumentException("'limit' must be positive");
+        }
+
+        HttpGet req = new HttpGet(permissionsAPIUri.resolve(ROLES_PATH
+                + "?offset=" + offset + "&limit=" + limit));
+        ResponseResult response = sendNonPolicyRequest(req,
+                ROLE_NOT_FOUND_ERROR, HttpStatus.SC_OK);
+        return JSON.readValue(response.getBody(), Roles.class);
+    }
+
+    /**
+     * Gets a role by sending a GET request to /roles/{id}.
+     *
+     * @param roleID the role ID
+     * @return the role
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Role getRole(final String roleID)
+            throws IOException, BadRequestException, PermissionsAPIException {
+
+        requireID(roleID, "roleID");
+
+        HttpGet req = new HttpGet(resolvePathSegment(ROLES_PATH, roleID));
+        ResponseResult response = sendNonPolicyRequest(req,
+                ROLE_NOT_FOUND_ERROR, HttpStatus.SC_OK);
+        return JSON.readValue(response.getBody(), Role.class);
+    }
+
+    /**
+     * Creates a policy by sending a POST request to /policies.
+     *
+     * @param policy the policy to create
+     * @return the created policy, with its generated ID
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Policy postPolicy(final PolicyInfo policy)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException {
+
+        requirePolicy(policy);
+
+        HttpPost req = new HttpPost(permissionsAPIUri.resolve(POLICIES_PATH));
+        req.setEntity(jsonEntity(policy));
+        ResponseResult response = send(req, POLICY_NOT_FOUND_ERROR,
+                HttpStatus.SC_CREATED);
+        return JSON.readValue(response.getBody(), Policy.class);
+    }
+
+    /**
+     * Creates a policy with the given ID by sending a POST request to
+     * /policies/{id}.
+     *
+     * @param policyID the policy ID
+     * @param policy   the policy to create
+     * @return the created policy
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Policy postPolicyWithID(final String policyID,
+            final PolicyInfo policy)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException {
+
+        requireID(policyID, "policyID");
+        requirePolicy(policy);
+
+        HttpPost req = new HttpPost(
+                resolvePathSegment(POLICIES_PATH, policyID));
+        req.setEntity(jsonEntity(policy));
+        ResponseResult response = send(req, POLICY_NOT_FOUND_ERROR,
+                HttpStatus.SC_CREATED);
+        return JSON.readValue(response.getBody(), Policy.class);
+    }
+
+    /**
+     * Gets a policy by sending a GET request to /policies/{id}.
+     *
+     * @param policyID the policy ID
+     * @return the policy
+     * @throws IOException if the request fails
+     */
+    @Override
+    public Policy getPolicy(final String policyID)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException {
+
+        requireID(policyID, "policyID");
+
+        HttpGet req = new HttpGet(
+                resolvePathSegment(POLICIES_PATH, policyID));
+        ResponseResult response = send(req, POLICY_NOT_FOUND_ERROR,
+                HttpStatus.SC_OK);
+        return JSON.readValue(response.getBody(), Policy.class);
+    }
+
+    /**
+     * Creates or updates a policy by sending a PUT request to
+     * /policies/{id}.
+     *
+     * @param policyID the policy ID
+     * @param policy   the policy
+     * @return true if the policy was created, false if it was updated
+     * @throws IOException if the request fails
+     */
+    @Override
+    public boolean putPolicy(final String policyID, final Policy policy)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException {
+
+        requireID(policyID, "policyID");
+        requirePolicy(policy);
+
+        HttpPut req = new HttpPut(
+                resolvePathSegment(POLICIES_PATH, policyID));
+        req.setEntity(jsonEntity(policy));
+        ResponseResult response = send(req, POLICY_NOT_FOUND_ERROR,
+                HttpStatus.SC_CREATED, HttpStatus.SC_OK);
+        return response.getStatusCode() == HttpStatus.SC_CREATED;
+    }
+
     /**
      * Deletes a policy by sending a DELETE request to /policies/{id}.
      * The {@code policyID} is the policy ID
@@ -82,18 +443,107 @@ public class PermissionsAPIClient implements PermissionsClient {
             throws IOException, BadRequestException, PolicyNotFoundException,
             PermissionsAPIException {
 
-        if (policyID == null || policyID.isEmpty()) {
+        requireID(policyID, "policyID");
+
+        HttpDelete req = new HttpDelete(
+                resolvePathSegment(POLICIES_PATH, policyID));
+        send(req, POLICY_NOT_FOUND_ERROR, HttpStatus.SC_NO_CONTENT,
+                HttpStatus.SC_OK);
+    }
+
+    /**
+     * Gets the permissions bundle by sending a GET request to
+     * /permissions-bundle. The plain JSON encoding of the bundle is requested.
+     *
+     * @return the permissions bundle
+     * @throws IOException if the request fails
+     */
+    @Override
+    public PermissionsBundle getPermissionsBundle()
+            throws IOException, BadRequestException, PermissionsAPIException {
+
+        HttpGet req = new HttpGet(
+                permissionsAPIUri.resolve(PERMISSIONS_BUNDLE_PATH));
+        req.addHeader(HttpHeaders.ACCEPT,
+                ContentType.APPLICATION_JSON.getMimeType());
+        ResponseResult response = sendNonPolicyRequest(req, null,
+                HttpStatus.SC_OK);
+        return JSON.readValue(response.getBody(), PermissionsBundle.class);
+    }
+
+    private static void requireID(final String id, final String name) {
+        if (id == null || id.isEmpty()) {
             throw new IllegalArgumentException(
-                    "'policyID' must not be null or empty");
+                    "'" + name + "' must not be null or empty");
         }
+    }
+
+    private static void requirePolicy(final Object policy) {
+        if (policy == null) {
+            throw new IllegalArgumentException("'policy' must not be null");
+        }
+    }
 
-        URI requestUri = permissionsAPIUri.resolve("/v1/policies/" + policyID);
-        HttpDelete req = new HttpDelete(requestUri);
+    /**
+     * Resolves the path of a resource from the path of its collection and
+     * its ID, which is encoded as a single path segment, e.g. so that the
+     * slash of an ID does not change the endpoint.
+     */
+    private URI resolvePathSegment(final String collectionPath,
+            final String id) throws UnsupportedEncodingException {
+        String segment = URLEncoder.encode(id, StandardCharsets.UTF_8.name())
+                .replace("+", "%20");
+        return permissionsAPIUri.resolve(collectionPath + "/" + segment);
+    }
+
+    private static StringEntity jsonEntity(final Object body)
+            throws JsonProcessingException {
+        return new StringEntity(JSON.writeValueAsString(body),
+                ContentType.APPLICATION_JSON);
+    }
+
+    /**
+     * Sends the request with the service auth token, and throws the
+     * exception for the error code of the response if its status code is
+     * not one of those expected.
+     *
+     * @param req                 the request
+     * @param notFoundCode        the error code assumed for a 404 response
+     *                            without one, or null
+     * @param expectedStatusCodes the status codes of a successful response
+     * @return the successful response
+     */
+    private ResponseResult send(final HttpUriRequestBase req,
+            final String notFoundCode, final int... expectedStatusCodes)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException {
 
         req.addHeader(SERVICE_TOKEN_HEADER_NAME, "Bearer " + authToken);
 
         ResponseResult response = executeRequest(req);
-        validateResponseCode(req, response.getStatusCode(), response.getBody());
+        for (int expectedStatusCode : expectedStatusCodes) {
+            if (response.getStatusCode() == expectedStatusCode) {
+                return response;
+            }
+        }
+
+        throwErrorResponse(req, response, expectedStatusCodes[0],
+                notFoundCode);
+        return response;
+    }
+
+    /**
+     * Sends a request to an endpoint that does not return policy errors, so
+     * that its callers do not need to handle a PolicyNotFoundException.
+     */
+    private ResponseResult sendNonPolicyRequest(final HttpUriRequestBase req,
+            final String notFoundCode, final int... expectedStatusCodes)
+            throws IOException, BadRequestException, PermissionsAPIException {
+        try {
+            return send(req, notFoundCode, expectedStatusCodes);
+        } catch (PolicyNotFoundException ex) {
+            throw new PermissionsAPIException(ex.getMessage(), ex.getCode());
+        }
     }
 
     private String formatErrResponse(final HttpUriRequestBase httpRequest,
@@ -113,28 +563,110 @@ public class PermissionsAPIClient implements PermissionsClient {
         return message;
     }
 
-    private void validateResponseCode(final HttpUriRequestBase httpRequest,
-            final int statusCode,
-            final String responseBody)
+    /**
+     * Throws the exception for the error code of an error response. If the
+     * response has no error code, the exception is chosen by its status code.
+     */
+    private void throwErrorResponse(final HttpUriRequestBase httpRequest,
+            final ResponseResult response,
+            final int expectedStatusCode,
+            final String notFoundCode)
             throws BadRequestException, PolicyNotFoundException,
             PermissionsAPIException {
-        switch (statusCode) {
-            case HttpStatus.SC_OK:
-                return;
-            case HttpStatus.SC_NO_CONTENT:
-                return;
-            case HttpStatus.SC_BAD_REQUEST:
-                throw new BadRequestException(formatErrResponse(httpRequest,
-                        statusCode, HttpStatus.SC_BAD_REQUEST, responseBody),
-                        statusCode);
-            case HttpStatus.SC_NOT_FOUND:
-                throw new PolicyNotFoundException(formatErrResponse(httpRequest,
-                        statusCode, HttpStatus.SC_NOT_FOUND, responseBody),
-                        statusCode);
-            default:
-                throw new PermissionsAPIException(formatErrResponse(httpRequest,
-                        statusCode, HttpStatus.SC_INTERNAL_SERVER_ERROR,
-                        responseBody), statusCode);
+        int statusCode = response.getStatusCode();
+        String message = formatErrResponse(httpRequest, statusCode,
+                expectedStatusCode, response.getBody());
+
+        String errorCode = parseErrorCode(response.getBody());
+        if (errorCode == null && statusCode == HttpStatus.SC_NOT_FOUND) {
+            errorCode = notFoundCode;
+        }
+
+        if (errorCode != null) {
+            switch (errorCode) {
+                case POLICY_NOT_FOUND_ERROR:
+                    throw new PolicyNotFoundException(message, statusCode);
+                case ROLE_NOT_FOUND_ERROR:
+                    throw new RoleNotFoundException(message, statusCode);
+                case POLICY_ALREADY_EXISTS_ERROR:
+                    throw new PolicyAlreadyExistsException(message,
+                            statusCode);
+                case INVALID_POLICY_ERROR:
+                    throw new InvalidPolicyException(message, statusCode);
+                case UNKNOWN_ENTITIES_ERROR:
+                    throw new UnknownEntitiesException(message, statusCode);
+                case JSON_UNMARSHAL_ERROR:
+                    throw new JSONUnmarshalException(message, statusCode);
+                case INVALID_QUERY_PARAMETER_ERROR:
+                    throw new InvalidQueryParameterException(message,
+                            statusCode);
+                case INVALID_LIMIT_QUERY_PARAMETER_MAX_EXCEEDED_ERROR:
+                    throw new InvalidLimitQueryParameterMaxExceededException(
+                            message, statusCode);
+                case INVALID_ROLE_ERROR:
+                    throw new InvalidRoleException(message, statusCode);
+                case INVALID_PERMISSION_ERROR:
+                    throw new InvalidPermissionException(message, statusCode);
+                case UNKNOWN_PERMISSIONS_ERROR:
+                    throw new UnknownPermissionsException(message, statusCode);
+                case INVALID_PATCH_ERROR:
+                    throw new InvalidPatchException(message, statusCode);
+                case INVALID_AUTH_TOKEN_ERROR:
+                    throw new InvalidAuthTokenException(message, statusCode);
+                case ENTITY_NOT_PERMITTED_ERROR:
+                    throw new EntityNotPermittedException(message, statusCode);
+                case PERMISSION_NOT_FOUND_ERROR:
+                    throw new PermissionNotFoundException(message, statusCode);
+                case ROLE_ALREADY_EXISTS_ERROR:
+                    throw new RoleAlreadyExistsException(message, statusCode);
+                case ROLE_IN_USE_ERROR:
+                    throw new RoleInUseException(message, statusCode);
+                case LAST_POLICY_ENTITY_ERROR:
+                    throw new LastPolicyEntityException(message, statusCode);
+                case PATCH_TEST_FAILED_ERROR:
+                    throw new PatchTestFailedException(message, statusCode);
+                case POLICY_DELETED_ERROR:
+                    throw new PolicyDeletedException(message, statusCode);
+                case POLICY_NOT_DELETED_ERROR:
+                    throw new PolicyNotDeletedException(message, statusCode);
+                case LOCKOUT_ERROR:
+                    throw new LockoutException(message, statusCode);
+                case PROTECTED_POLICY_ERROR:
+                    throw new ProtectedPolicyException(message, statusCode);
+                case POLICY_MODIFIED_ERROR:
+                    throw new PolicyModifiedException(message, statusCode);
+                case UNSUPPORTED_MEDIA_TYPE_ERROR:
+                    throw new UnsupportedMediaTypeException(message,
+                            statusCode);
+                default:
+                    break;
+            }
+        }
+
+        if (statusCode == HttpStatus.SC_BAD_REQUEST) {
+            throw new BadRequestException(message, statusCode);
+        }
+        throw new PermissionsAPIException(message, statusCode);
+    }
+
+    /**
+     * Get the code of the first error in an error response body, e.g.
+     * {@code {"errors":[{"code":"PolicyNotFoundError"}]}}.
+     *
+     * @param responseBody the response body
+     * @return the error code, or null if the body has none
+     */
+    private static String parseErrorCode(final String responseBody) {
+        if (responseBody == null || responseBody.isEmpty()) {
+            return null;
+        }
+        try {
+            JsonNode code = JSON.readTree(responseBody)
+                    .path("errors").path(0).path("code");
+            return code.isTextual() ? code.asText() : null;
+        } catch (JsonProcessingException ex) {
+            // e.g. the plain text body of an internal server error
+            return null;
         }
     }
 
@@ -6,8 +6,120 @@ import java.io.IOException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.BadRequestException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.PermissionsAPIException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyNotFoundException;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PermissionsBundle;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Policy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PolicyInfo;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Role;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Roles;
 
 public interface PermissionsClient extends Closeable {
+    /**
+     * Gets the first page of roles by sending a GET request to the /roles
+     * endpoint, using the default offset and limit of the API.
+     *
+     * @return the page of roles
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    Roles getRoles() throws IOException, BadRequestException,
+            PermissionsAPIException;
+
+    /**
+     * Gets a page of roles by sending a GET request to the /roles endpoint.
+     *
+     * @param offset the number of roles to skip
+     * @param limit  the maximum number of roles to return
+     * @return the page of roles
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws BadRequestException     if the offset or limit is invalid
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    Roles getRoles(int offset, int limit) throws IOException,
+            BadRequestException, PermissionsAPIException;
+
+    /**
+     * Gets a role by sending a GET request to the /roles/{id} endpoint.
+     *
+     * A {@code 404 Not Found} status throws a {@code RoleNotFoundException}.
+     *
+     * @param roleID the role ID
+     * @return the role
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    Role getRole(String roleID) throws IOException, BadRequestException,
+            PermissionsAPIException;
+
+    /**
+     * Creates a policy with a generated ID by sending a POST request to the
+     * /policies endpoint.
+     *
+     * @param policy the policy to create
+     * @return the created policy
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws BadRequestException     if the policy is invalid
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    Policy postPolicy(PolicyInfo policy) throws IOException,
+            BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException;
+
+    /**
+     * Creates a policy with the given ID by sending a POST request to the
+     * /policies/{id} endpoint.
+     *
+     * A {@code 409 Conflict} status throws a
+     * {@code PolicyAlreadyExistsException}.
+     *
+     * @param policyID the policy ID
+     * @param policy   the policy to create
+     * @return the created policy
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws BadRequestException     if the policy is invalid
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    Policy postPolicyWithID(String policyID, PolicyInfo policy)
+            throws IOException, BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException;
+
+    /**
+     * Gets a policy by sending a GET request to the /policies/{id} endpoint.
+     *
+     * @param policyID the policy ID
+     * @return the policy
+     * @throws IOException              if an I/O error occurs during the
+     *                                  request
+     * @throws PolicyNotFoundException  if the policy does not exist
+     * @throws PermissionsAPIException  if the permissions API returns an error
+     *                                  response
+     */
+    Policy getPolicy(String policyID) throws IOException, BadRequestException,
+            PolicyNotFoundException, PermissionsAPIException;
+
+    /**
+     * Creates or updates a policy by sending a PUT request to the
+     * /policies/{id} endpoint.
+     *
+     * A {@code 201 Created} status indicates the policy was created, and a
+     * {@code 200 OK} status that an existing policy was updated.
+     *
+     * @param policyID the policy ID
+     * @param policy   the policy
+     * @return true if the policy was created, false if it was updated
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws BadRequestException     if the policy is invalid
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    boolean putPolicy(String policyID, Policy policy) throws IOException,
+            BadRequestException, PolicyNotFoundException,
+            PermissionsAPIException;
+
     /**
      * Deletes a policy by sending a DELETE request to the /policies/{id}
      * endpoint.
@@ -23,6 +135,15 @@ public interface PermissionsClient extends Closeable {
     void deletePolicy(String policyID) throws IOException, BadRequestException,
             PolicyNotFoundException, PermissionsAPIException;
 
-    //TODO: add methods for the other API endpoints (e.g. createPolicy,
-    //putPolicy etc.)
+    /**
+     * Gets the permissions bundle by sending a GET request to the
+     * /permissions-bundle endpoint.
+     *
+     * @return the permissions bundle
+     * @throws IOException             if an I/O error occurs during the request
+     * @throws PermissionsAPIException if the permissions API returns an error
+     *                                 response
+     */
+    PermissionsBundle getPermissionsBundle() throws IOException,
+            BadRequestException, PermissionsAPIException;
 }
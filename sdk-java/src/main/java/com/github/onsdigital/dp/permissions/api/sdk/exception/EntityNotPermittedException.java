@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the caller is not permitted to get the permissions bundle of an
+ * entity (EntityNotPermittedError).
+ */
+public class EntityNotPermittedException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of an EntityNotPermittedException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public EntityNotPermittedException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public EntityNotPermittedException() {
+        super("", HttpStatus.SC_FORBIDDEN);
+    }
+}
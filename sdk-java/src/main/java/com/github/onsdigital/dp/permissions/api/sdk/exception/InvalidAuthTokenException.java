@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the auth token of the request is missing or invalid
+ * (InvalidAuthTokenError).
+ */
+public class InvalidAuthTokenException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of an InvalidAuthTokenException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidAuthTokenException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidAuthTokenException() {
+        super("", HttpStatus.SC_UNAUTHORIZED);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the limit query parameter exceeds the maximum limit of the API
+ * (InvalidLimitQueryParameterMaxExceeded).
+ */
+public class InvalidLimitQueryParameterMaxExceededException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidLimitQueryParameterMaxExceededException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidLimitQueryParameterMaxExceededException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidLimitQueryParameterMaxExceededException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
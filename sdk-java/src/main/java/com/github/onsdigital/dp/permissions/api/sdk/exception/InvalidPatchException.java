@@ -0,0 +1,26 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the JSON patch of the policy is invalid (InvalidPatchError).
+ */
+public class InvalidPatchException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidPatchException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidPatchException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidPatchException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the permission is invalid (InvalidPermissionError).
+ */
+public class InvalidPermissionException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidPermissionException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidPermissionException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidPermissionException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the policy is invalid, e.g. it has no entities or its
+ * condition has an unknown operator (InvalidPolicyError).
+ */
+public class InvalidPolicyException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidPolicyException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidPolicyException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidPolicyException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
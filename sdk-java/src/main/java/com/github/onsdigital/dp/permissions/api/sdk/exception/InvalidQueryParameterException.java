@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a query parameter of the request is invalid
+ * (InvalidQueryParameter).
+ */
+public class InvalidQueryParameterException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidQueryParameterException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidQueryParameterException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidQueryParameterException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
@@ -0,0 +1,26 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the role is invalid (InvalidRoleError).
+ */
+public class InvalidRoleException extends BadRequestException {
+
+    /**
+     * Create a new instance of an InvalidRoleException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public InvalidRoleException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public InvalidRoleException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
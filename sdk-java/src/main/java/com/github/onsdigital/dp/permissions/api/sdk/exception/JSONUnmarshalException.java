@@ -0,0 +1,26 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the request body is not valid JSON (JSONUnmarshalError).
+ */
+public class JSONUnmarshalException extends BadRequestException {
+
+    /**
+     * Create a new instance of a JSONUnmarshalException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public JSONUnmarshalException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public JSONUnmarshalException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
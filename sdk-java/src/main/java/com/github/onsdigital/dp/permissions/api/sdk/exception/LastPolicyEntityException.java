@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the last entity of a policy is removed (LastPolicyEntityError).
+ */
+public class LastPolicyEntityException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a LastPolicyEntityException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public LastPolicyEntityException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public LastPolicyEntityException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the change would leave no user or group able to administer the
+ * policies (LockoutError).
+ */
+public class LockoutException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a LockoutException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public LockoutException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public LockoutException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a test operation of the JSON patch of the policy failed
+ * (PatchTestFailedError).
+ */
+public class PatchTestFailedException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PatchTestFailedException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PatchTestFailedException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PatchTestFailedException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the permission does not exist (PermissionNotFoundError).
+ */
+public class PermissionNotFoundException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PermissionNotFoundException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PermissionNotFoundException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PermissionNotFoundException() {
+        super("", HttpStatus.SC_NOT_FOUND);
+    }
+}
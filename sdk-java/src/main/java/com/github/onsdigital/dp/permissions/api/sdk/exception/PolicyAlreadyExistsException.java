@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a policy already exists with the given ID
+ * (PolicyAlreadyExistsError).
+ */
+public class PolicyAlreadyExistsException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PolicyAlreadyExistsException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PolicyAlreadyExistsException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PolicyAlreadyExistsException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the policy is deleted, and must be restored before it is changed
+ * (PolicyDeletedError).
+ */
+public class PolicyDeletedException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PolicyDeletedException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PolicyDeletedException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PolicyDeletedException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
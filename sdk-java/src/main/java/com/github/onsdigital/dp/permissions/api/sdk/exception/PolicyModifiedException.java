@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the policy was changed by another request while it was patched
+ * (PolicyModifiedError).
+ */
+public class PolicyModifiedException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PolicyModifiedException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PolicyModifiedException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PolicyModifiedException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
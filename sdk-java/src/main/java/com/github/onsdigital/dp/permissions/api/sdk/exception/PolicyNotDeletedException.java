@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a policy that is not deleted is restored
+ * (PolicyNotDeletedError).
+ */
+public class PolicyNotDeletedException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a PolicyNotDeletedException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public PolicyNotDeletedException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public PolicyNotDeletedException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
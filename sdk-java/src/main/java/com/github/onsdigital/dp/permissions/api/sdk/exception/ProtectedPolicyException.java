@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a protected policy is changed without override
+ * (ProtectedPolicyError).
+ */
+public class ProtectedPolicyException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a ProtectedPolicyException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public ProtectedPolicyException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public ProtectedPolicyException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when a role already exists with the given ID or name
+ * (RoleAlreadyExistsError).
+ */
+public class RoleAlreadyExistsException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a RoleAlreadyExistsException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public RoleAlreadyExistsException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public RoleAlreadyExistsException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the role is bound by policies, or is the parent of other roles
+ * (RoleInUseError).
+ */
+public class RoleInUseException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a RoleInUseException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public RoleInUseException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public RoleInUseException() {
+        super("", HttpStatus.SC_CONFLICT);
+    }
+}
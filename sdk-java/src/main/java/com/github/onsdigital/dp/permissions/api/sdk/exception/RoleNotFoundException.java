@@ -0,0 +1,26 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the role does not exist (RoleNotFoundError).
+ */
+public class RoleNotFoundException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of a RoleNotFoundException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public RoleNotFoundException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public RoleNotFoundException() {
+        super("", HttpStatus.SC_NOT_FOUND);
+    }
+}
@@ -0,0 +1,27 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the users or groups of the policy do not exist
+ * (UnknownEntitiesError).
+ */
+public class UnknownEntitiesException extends BadRequestException {
+
+    /**
+     * Create a new instance of an UnknownEntitiesException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public UnknownEntitiesException(final String message, final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public UnknownEntitiesException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the permissions of the role are not registered in the
+ * permissions catalogue (UnknownPermissionsError).
+ */
+public class UnknownPermissionsException extends BadRequestException {
+
+    /**
+     * Create a new instance of an UnknownPermissionsException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public UnknownPermissionsException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public UnknownPermissionsException() {
+        super("", HttpStatus.SC_BAD_REQUEST);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.exception;
+
+import org.apache.hc.core5.http.HttpStatus;
+
+/**
+ * Thrown when the content type of the request body is not supported
+ * (UnsupportedMediaTypeError).
+ */
+public class UnsupportedMediaTypeException extends PermissionsAPIException {
+
+    /**
+     * Create a new instance of an UnsupportedMediaTypeException.
+     *
+     * @param message    A string detailing the reason for the exception
+     * @param statusCode The http status code that caused the API exception
+     */
+    public UnsupportedMediaTypeException(final String message,
+            final int statusCode) {
+        super(message, statusCode);
+    }
+
+    /**
+     * New default constructor.
+     */
+    public UnsupportedMediaTypeException() {
+        super("", HttpStatus.SC_UNSUPPORTED_MEDIA_TYPE);
+    }
+}
@@ -0,0 +1,28 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * A policy in the permissions bundle, which omits the entities and role of
+ * the policy.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class BundlePolicy {
+
+    /**
+     * The ID of the policy.
+     */
+    private String id;
+
+    /**
+     * The optional condition of the policy.
+     */
+    private Condition condition;
+}
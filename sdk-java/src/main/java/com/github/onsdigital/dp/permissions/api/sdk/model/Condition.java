@@ -0,0 +1,81 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.List;
+import java.util.Map;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonInclude;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * A condition that a request attribute must meet for a policy to apply.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonInclude(JsonInclude.Include.NON_EMPTY)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class Condition {
+
+    /**
+     * Operator matching an attribute equal to one of the values.
+     */
+    public static final String OPERATOR_STRING_EQUALS = "StringEquals";
+
+    /**
+     * Operator matching an attribute starting with one of the values.
+     */
+    public static final String OPERATOR_STARTS_WITH = "StartsWith";
+
+    /**
+     * The name of the request attribute, e.g. collection_id.
+     */
+    private String attribute;
+
+    /**
+     * The operator used to compare the attribute with the values.
+     */
+    private String operator;
+
+    /**
+     * The values that the attribute is compared with.
+     */
+    private List<String> values;
+
+    /**
+     * Whether the condition is met by the attributes of a request. A
+     * condition without an attribute is always met, and a condition is not
+     * met if its attribute is not in the attributes. StringEquals is met if
+     * the attribute value is equal to one of the values, and StartsWith if it
+     * starts with one of them. Any other operator is never met. Values are
+     * compared case-sensitively.
+     *
+     * @param attributes the attributes of the request, e.g. collection_id
+     * @return true if the condition is met
+     */
+    public boolean isMet(final Map<String, String> attributes) {
+        if (attribute == null || attribute.isEmpty()) {
+            return true;
+        }
+
+        String value = attributes == null ? null : attributes.get(attribute);
+        if (value == null || values == null) {
+            return false;
+        }
+
+        for (String conditionValue : values) {
+            if (OPERATOR_STRING_EQUALS.equals(operator)
+                    && value.equals(conditionValue)) {
+                return true;
+            }
+            if (OPERATOR_STARTS_WITH.equals(operator)
+                    && value.startsWith(conditionValue)) {
+                return true;
+            }
+        }
+        return false;
+    }
+}
@@ -0,0 +1,59 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.ArrayList;
+import java.util.List;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * The user and groups of a request, whose permissions are evaluated.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+public class EntityData {
+
+    /**
+     * Prefix of the entity of a user.
+     */
+    public static final String ENTITY_PREFIX_USERS = "users/";
+
+    /**
+     * Prefix of the entity of a group.
+     */
+    public static final String ENTITY_PREFIX_GROUPS = "groups/";
+
+    /**
+     * The ID of the user, e.g. their email address.
+     */
+    private String userId;
+
+    /**
+     * The IDs of the groups of the user.
+     */
+    private List<String> groups;
+
+    /**
+     * Get the entities of the user and their groups, the user first, e.g.
+     * users/janedoe@example.com and groups/publishers. An empty user or group
+     * ID has no entity.
+     *
+     * @return the entities
+     */
+    public List<String> getEntities() {
+        List<String> entities = new ArrayList<>();
+        if (userId != null && !userId.isEmpty()) {
+            entities.add(ENTITY_PREFIX_USERS + userId);
+        }
+        if (groups != null) {
+            for (String group : groups) {
+                if (group != null && !group.isEmpty()) {
+                    entities.add(ENTITY_PREFIX_GROUPS + group);
+                }
+            }
+        }
+        return entities;
+    }
+}
@@ -0,0 +1,36 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * The permissions bundle, which maps each permission to the entities it is
+ * granted to, and each entity to the policies that grant it.
+ */
+public class PermissionsBundle
+        extends HashMap<String, Map<String, List<BundlePolicy>>> {
+
+    /**
+     * Serialisation version of the bundle.
+     */
+    private static final long serialVersionUID = 1L;
+
+    /**
+     * Get the policies that grant a permission to an entity.
+     *
+     * @param permission the permission, e.g. datasets:edit
+     * @param entity     the entity, e.g. groups/publishers
+     * @return the policies, or an empty list if the permission is not
+     *         granted to the entity
+     */
+    public List<BundlePolicy> getPolicies(final String permission,
+            final String entity) {
+        Map<String, List<BundlePolicy>> entityPolicies = get(permission);
+        if (entityPolicies == null || !entityPolicies.containsKey(entity)) {
+            return Collections.emptyList();
+        }
+        return entityPolicies.get(entity);
+    }
+}
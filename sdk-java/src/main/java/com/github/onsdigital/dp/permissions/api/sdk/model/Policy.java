@@ -0,0 +1,82 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.Date;
+import java.util.List;
+
+import com.fasterxml.jackson.annotation.JsonFormat;
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonInclude;
+import com.fasterxml.jackson.annotation.JsonProperty;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * A policy, which grants the permissions of a role to users and groups.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonInclude(JsonInclude.Include.NON_NULL)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class Policy {
+
+    /**
+     * The ID of the policy.
+     */
+    private String id;
+
+    /**
+     * The users and groups the policy applies to, e.g. groups/admin.
+     */
+    private List<String> entities;
+
+    /**
+     * The ID of the role the policy grants.
+     */
+    private String role;
+
+    /**
+     * The optional condition of the policy.
+     */
+    private Condition condition;
+
+    /**
+     * When the policy was created, if known.
+     */
+    @JsonProperty("created_at")
+    @JsonFormat(shape = JsonFormat.Shape.STRING)
+    private Date createdAt;
+
+    /**
+     * When the policy was last written.
+     */
+    @JsonProperty("last_updated")
+    @JsonFormat(shape = JsonFormat.Shape.STRING)
+    private Date lastUpdated;
+
+    /**
+     * The ID of the user or service that last wrote the policy.
+     */
+    @JsonProperty("updated_by")
+    private String updatedBy;
+
+    /**
+     * Incremented whenever the policy is written, or null if the policy was
+     * not written since this was recorded.
+     */
+    private Integer version;
+
+    /**
+     * Create a new policy without metadata.
+     *
+     * @param id        The ID of the policy
+     * @param entities  The users and groups the policy applies to
+     * @param role      The ID of the role the policy grants
+     * @param condition The optional condition of the policy
+     */
+    public Policy(String id, List<String> entities, String role, Condition condition) {
+        this(id, entities, role, condition, null, null, null, null);
+    }
+}
@@ -0,0 +1,36 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.List;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonInclude;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * The properties required to create a policy.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonInclude(JsonInclude.Include.NON_NULL)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class PolicyInfo {
+
+    /**
+     * The users and groups the policy applies to, e.g. groups/admin.
+     */
+    private List<String> entities;
+
+    /**
+     * The ID of the role the policy grants.
+     */
+    private String role;
+
+    /**
+     * The optional condition of the policy.
+     */
+    private Condition condition;
+}
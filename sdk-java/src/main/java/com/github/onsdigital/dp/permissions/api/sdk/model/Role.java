@@ -0,0 +1,47 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.List;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonProperty;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * A role, which is a named set of permissions.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class Role {
+
+    /**
+     * The ID of the role.
+     */
+    private String id;
+
+    /**
+     * The name of the role.
+     */
+    private String name;
+
+    /**
+     * The permissions of the role.
+     */
+    private List<String> permissions;
+
+    /**
+     * The IDs of the roles that the role inherits permissions from.
+     */
+    private List<String> parents;
+
+    /**
+     * The permissions of the role, including those inherited from its
+     * parents.
+     */
+    @JsonProperty("effective_permissions")
+    private List<String> effectivePermissions;
+}
@@ -0,0 +1,46 @@
+package com.github.onsdigital.dp.permissions.api.sdk.model;
+
+import java.util.List;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonProperty;
+
+import lombok.AllArgsConstructor;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+
+/**
+ * A page of roles.
+ */
+@Data
+@NoArgsConstructor
+@AllArgsConstructor
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class Roles {
+
+    /**
+     * The number of roles in the page.
+     */
+    private int count;
+
+    /**
+     * The offset of the page.
+     */
+    private int offset;
+
+    /**
+     * The maximum number of roles in the page.
+     */
+    private int limit;
+
+    /**
+     * The roles in the page.
+     */
+    private List<Role> items;
+
+    /**
+     * The total number of roles.
+     */
+    @JsonProperty("total_count")
+    private int totalCount;
+}
@@ -0,0 +1,132 @@
+package com.github.onsdigital.dp.permissions.api.sdk;
+
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+import com.github.onsdigital.dp.permissions.api.sdk.model.BundlePolicy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Condition;
+import com.github.onsdigital.dp.permissions.api.sdk.model.EntityData;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PermissionsBundle;
+import org.junit.jupiter.api.Test;
+import static org.junit.jupiter.api.Assertions.*;
+
+class EvaluatorTest {
+
+	private static final String PERMISSION = "datasets:edit";
+
+	private static final BundlePolicy USER_POLICY = new BundlePolicy("user-policy",
+			new Condition("collection_id", Condition.OPERATOR_STRING_EQUALS, Collections.singletonList("collection1")));
+
+	private static final BundlePolicy GROUP_POLICY = new BundlePolicy("group-policy", null);
+
+	private static final BundlePolicy PREFIX_POLICY = new BundlePolicy("prefix-policy",
+			new Condition("dataset_edition", Condition.OPERATOR_STARTS_WITH, Collections.singletonList("cpih/")));
+
+	private static final BundlePolicy UNKNOWN_OPERATOR_POLICY = new BundlePolicy("unknown-operator-policy",
+			new Condition("collection_id", "StringLike", Collections.singletonList("collection1")));
+
+	private static final BundlePolicy NO_ATTRIBUTE_POLICY = new BundlePolicy("no-attribute-policy",
+			new Condition("", Condition.OPERATOR_STRING_EQUALS, Collections.singletonList("collection1")));
+
+	@Test
+	void testEvaluateUserPolicyBeforeGroupPolicy() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "users/janedoe@example.com", USER_POLICY,
+				"groups/publishers", GROUP_POLICY));
+
+		Decision decision = evaluator.evaluate(entityData(), PERMISSION,
+				Collections.singletonMap("collection_id", "collection1"));
+
+		assertTrue(decision.isAllowed());
+		assertEquals("users/janedoe@example.com", decision.getEntity());
+		assertEquals(USER_POLICY, decision.getPolicy());
+	}
+
+	@Test
+	void testEvaluateFallsBackToGroupPolicyWhenConditionNotMet() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "users/janedoe@example.com", USER_POLICY,
+				"groups/publishers", GROUP_POLICY));
+
+		Decision decision = evaluator.evaluate(entityData(), PERMISSION,
+				Collections.singletonMap("collection_id", "collection2"));
+
+		assertTrue(decision.isAllowed());
+		assertEquals("groups/publishers", decision.getEntity());
+		assertEquals(GROUP_POLICY, decision.getPolicy());
+	}
+
+	@Test
+	void testEvaluateStartsWith() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "groups/publishers", PREFIX_POLICY));
+
+		assertTrue(evaluator.evaluate(entityData(), PERMISSION,
+				Collections.singletonMap("dataset_edition", "cpih/2024")).isAllowed());
+		assertFalse(evaluator.evaluate(entityData(), PERMISSION,
+				Collections.singletonMap("dataset_edition", "cpi/2024")).isAllowed());
+	}
+
+	@Test
+	void testEvaluateMissingAttributeIsDenied() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "users/janedoe@example.com", USER_POLICY));
+
+		assertFalse(evaluator.evaluate(entityData(), PERMISSION, Collections.emptyMap()).isAllowed());
+		assertFalse(evaluator.evaluate(entityData(), PERMISSION, null).isAllowed());
+	}
+
+	@Test
+	void testEvaluateUnknownOperatorIsDenied() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "groups/publishers", UNKNOWN_OPERATOR_POLICY));
+
+		Decision decision = evaluator.evaluate(entityData(), PERMISSION,
+				Collections.singletonMap("collection_id", "collection1"));
+
+		assertFalse(decision.isAllowed());
+		assertNull(decision.getEntity());
+		assertNull(decision.getPolicy());
+	}
+
+	@Test
+	void testEvaluateConditionWithoutAttributeIsUnconditional() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "groups/publishers", NO_ATTRIBUTE_POLICY));
+
+		assertTrue(evaluator.evaluate(entityData(), PERMISSION, null).isAllowed());
+	}
+
+	@Test
+	void testEvaluatePermissionNotInBundleIsDenied() {
+		Evaluator evaluator = new Evaluator(bundle(PERMISSION, "groups/publishers", GROUP_POLICY));
+
+		assertFalse(evaluator.evaluate(entityData(), "datasets:delete", null).isAllowed());
+		assertFalse(evaluator.evaluate(new EntityData("johndoe@example.com", null), PERMISSION, null).isAllowed());
+	}
+
+	@Test
+	void testEvaluatorThrowsOnNullBundle() {
+		assertThrows(IllegalArgumentException.class, () -> new Evaluator(null));
+	}
+
+	@Test
+	void testEntityDataGetEntities() {
+		assertEquals(Arrays.asList("users/janedoe@example.com", "groups/publishers", "groups/viewers"),
+				new EntityData("janedoe@example.com", Arrays.asList("publishers", "", "viewers")).getEntities());
+		assertEquals(Collections.singletonList("groups/publishers"),
+				new EntityData("", Collections.singletonList("publishers")).getEntities());
+	}
+
+	private static EntityData entityData() {
+		return new EntityData("janedoe@example.com", Collections.singletonList("publishers"));
+	}
+
+	private static PermissionsBundle bundle(final String permission, final Object... entityPolicies) {
+		Map<String, List<BundlePolicy>> entityLookup = new HashMap<>();
+		for (int i = 0; i < entityPolicies.length; i += 2) {
+			entityLookup.put((String) entityPolicies[i],
+					Collections.singletonList((BundlePolicy) entityPolicies[i + 1]));
+		}
+		PermissionsBundle bundle = new PermissionsBundle();
+		bundle.put(permission, entityLookup);
+		return bundle;
+	}
+}
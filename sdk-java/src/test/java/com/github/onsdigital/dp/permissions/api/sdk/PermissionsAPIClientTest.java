@@ -1,11 +1,33 @@
 package com.github.onsdigital.dp.permissions.api.sdk;
 
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.atomic.AtomicReference;
+
 import org.apache.hc.client5.http.impl.classic.CloseableHttpResponse;
 import org.apache.hc.client5.http.impl.classic.CloseableHttpClient;
-import org.apache.hc.client5.http.classic.methods.HttpDelete;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.BadRequestException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidPolicyException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.InvalidQueryParameterException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.LockoutException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PermissionsAPIException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyAlreadyExistsException;
 import com.github.onsdigital.dp.permissions.api.sdk.exception.PolicyNotFoundException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.RoleNotFoundException;
+import com.github.onsdigital.dp.permissions.api.sdk.exception.UnknownEntitiesException;
+import com.github.onsdigital.dp.permissions.api.sdk.model.BundlePolicy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Condition;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PermissionsBundle;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Policy;
+import com.github.onsdigital.dp.permissions.api.sdk.model.PolicyInfo;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Role;
+import com.github.onsdigital.dp.permissions.api.sdk.model.Roles;
+import org.apache.hc.core5.http.ClassicHttpRequest;
 import org.apache.hc.core5.http.io.HttpClientResponseHandler;
+import org.apache.hc.core5.http.io.entity.EntityUtils;
 import org.junit.jupiter.api.Test;
 import static org.junit.jupiter.api.Assertions.*;
 import static org.mockito.ArgumentMatchers.any;
@@ -71,15 +93,290 @@ class PermissionsAPIClientTest {
 		client.close();
 	}
 
-	@SuppressWarnings({"rawtypes", "unchecked"})
-	private void stubExecuteWithHandler(final CloseableHttpClient mockClient,
-			final CloseableHttpResponse mockResponse) throws Exception {
-		when(mockClient.execute(any(HttpDelete.class), any(HttpClientResponseHandler.class)))
-				.thenAnswer(invocation -> {
-					HttpClientResponseHandler handler = invocation.getArgument(1);
-					return handler.handleResponse(mockResponse);
-				});
+	@Test
+	void testGetRolesSuccess() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(200);
+		Role role = new Role("admin", "Admin", Arrays.asList("users:read"), null, null);
+		MockHttp.responseBody(mockResponse, new Roles(1, 10, 20, Collections.singletonList(role), 11));
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+
+		Roles roles = client.getRoles(10, 20);
+
+		assertEquals(1, roles.getCount());
+		assertEquals(11, roles.getTotalCount());
+		assertEquals(role, roles.getItems().get(0));
+		assertEquals("GET", request.get().getMethod());
+		assertEquals(PERMISSIONS_API_URL + "/v1/roles?offset=10&limit=20", request.get().getUri().toString());
+		assertEquals("Bearer " + SERVICE_AUTH_TOKEN, request.get().getFirstHeader("Authorization").getValue());
+		client.close();
+	}
+
+	@Test
+	void testGetRolesThrowsOnInvalidPage() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		assertThrows(IllegalArgumentException.class, () -> client.getRoles(-1, 20));
+		assertThrows(IllegalArgumentException.class, () -> client.getRoles(0, 0));
+		client.close();
+	}
+
+	@Test
+	void testGetRoleSuccess() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(200);
+		Map<String, Object> body = new HashMap<>();
+		body.put("id", "publisher");
+		body.put("name", "Publisher");
+		body.put("permissions", Collections.singletonList("datasets:edit"));
+		body.put("parents", Collections.singletonList("viewer"));
+		body.put("effective_permissions", Arrays.asList("datasets:edit", "datasets:read"));
+		MockHttp.responseBody(mockResponse, body);
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+
+		Role role = client.getRole("publisher");
+
+		assertEquals("publisher", role.getId());
+		assertEquals(Collections.singletonList("viewer"), role.getParents());
+		assertEquals(Arrays.asList("datasets:edit", "datasets:read"), role.getEffectivePermissions());
+		assertEquals(PERMISSIONS_API_URL + "/v1/roles/publisher", request.get().getUri().toString());
+		client.close();
+	}
+
+	@Test
+	void testGetRoleNotFound() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(404);
+		MockHttp.responseBody(mockResponse, errorBody("RoleNotFoundError", "role not found"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		RoleNotFoundException ex = assertThrows(RoleNotFoundException.class, () -> client.getRole("missing"));
+		assertEquals(404, ex.getCode());
+		client.close();
+	}
+
+	@Test
+	void testGetRoleEncodesID() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(404);
+		MockHttp.responseBody(mockResponse, errorBody("RoleNotFoundError", "role not found"));
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+		assertThrows(RoleNotFoundException.class, () -> client.getRole("a/b c"));
+		assertEquals(PERMISSIONS_API_URL + "/v1/roles/a%2Fb%20c", request.get().getUri().toString());
+		client.close();
+	}
+
+	@Test
+	void testGetRolePolicyNotFoundCodeIsNotPolicyNotFoundException() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(404);
+		MockHttp.responseBody(mockResponse, errorBody("PolicyNotFoundError", "policy not found"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		PermissionsAPIException ex = assertThrows(PermissionsAPIException.class, () -> client.getRole("publisher"));
+		assertFalse(ex instanceof PolicyNotFoundException);
+		assertEquals(404, ex.getCode());
+		client.close();
+	}
+
+	@Test
+	void testGetRolesInvalidQueryParameter() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(400);
+		MockHttp.responseBody(mockResponse, errorBody("InvalidQueryParameter", "invalid query parameter"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		InvalidQueryParameterException ex = assertThrows(InvalidQueryParameterException.class,
+				() -> client.getRoles(0, 10));
+		assertEquals(400, ex.getCode());
+		client.close();
+	}
+
+	@Test
+	void testPostPolicySuccess() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(201);
+		Condition condition = new Condition("collection_id", Condition.OPERATOR_STRING_EQUALS,
+				Collections.singletonList("col-1"));
+		PolicyInfo policyInfo = new PolicyInfo(Collections.singletonList("groups/publishers"), "publisher", condition);
+		Policy created = new Policy("policy123", policyInfo.getEntities(), "publisher", condition);
+		MockHttp.responseBody(mockResponse, created);
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+
+		Policy policy = client.postPolicy(policyInfo);
+
+		assertEquals(created, policy);
+		assertEquals("POST", request.get().getMethod());
+		assertEquals(PERMISSIONS_API_URL + "/v1/policies", request.get().getUri().toString());
+		String requestBody = EntityUtils.toString(request.get().getEntity());
+		assertTrue(requestBody.contains("\"entities\":[\"groups/publishers\"]"));
+		assertTrue(requestBody.contains("\"operator\":\"StringEquals\""));
+		client.close();
+	}
+
+	@Test
+	void testPostPolicyInvalid() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(400);
+		MockHttp.responseBody(mockResponse, errorBody("InvalidPolicyError", "missing mandatory fields: role"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		PolicyInfo policyInfo = new PolicyInfo(Collections.singletonList("groups/publishers"), null, null);
+		InvalidPolicyException ex = assertThrows(InvalidPolicyException.class, () -> client.postPolicy(policyInfo));
+		assertTrue(ex.getMessage().contains("missing mandatory fields: role"));
+		client.close();
+	}
+
+	@Test
+	void testPostPolicyUnknownEntities() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(400);
+		MockHttp.responseBody(mockResponse, errorBody("UnknownEntitiesError", "unknown entities: groups/missing"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		PolicyInfo policyInfo = new PolicyInfo(Collections.singletonList("groups/missing"), "publisher", null);
+		assertThrows(UnknownEntitiesException.class, () -> client.postPolicy(policyInfo));
+		client.close();
+	}
+
+	@Test
+	void testPostPolicyWithIDAlreadyExists() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(409);
+		MockHttp.responseBody(mockResponse, errorBody("PolicyAlreadyExistsError", "policy already exists with given ID"));
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+		PolicyInfo policyInfo = new PolicyInfo(Collections.singletonList("groups/publishers"), "publisher", null);
+		PolicyAlreadyExistsException ex = assertThrows(PolicyAlreadyExistsException.class,
+				() -> client.postPolicyWithID("policy123", policyInfo));
+		assertEquals(409, ex.getCode());
+		assertEquals(PERMISSIONS_API_URL + "/v1/policies/policy123", request.get().getUri().toString());
+		client.close();
+	}
+
+	@Test
+	void testGetPolicySuccess() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(200);
+		Policy expected = new Policy("policy123", Collections.singletonList("groups/publishers"), "publisher", null);
+		MockHttp.responseBody(mockResponse, expected);
+		stubExecuteWithHandler(mockClient, mockResponse);
+		assertEquals(expected, client.getPolicy("policy123"));
+		client.close();
+	}
+
+	@Test
+	void testGetPolicyNotFound() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(404);
+		MockHttp.responseBody(mockResponse, errorBody("PolicyNotFoundError", "policy not found"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		assertThrows(PolicyNotFoundException.class, () -> client.getPolicy("policy123"));
+		client.close();
+	}
+
+	@Test
+	void testGetPolicyEncodesID() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(200);
+		MockHttp.responseBody(mockResponse, new Policy("a/b c", Collections.singletonList("groups/publishers"), "publisher", null));
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+		assertEquals("a/b c", client.getPolicy("a/b c").getId());
+		assertEquals(PERMISSIONS_API_URL + "/v1/policies/a%2Fb%20c", request.get().getUri().toString());
+		client.close();
+	}
+
+	@Test
+	void testDeletePolicyLockout() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(409);
+		MockHttp.responseBody(mockResponse, errorBody("LockoutError", "the change would remove the last administrator"));
+		stubExecuteWithHandler(mockClient, mockResponse);
+		LockoutException ex = assertThrows(LockoutException.class, () -> client.deletePolicy("policy123"));
+		assertEquals(409, ex.getCode());
+		client.close();
+	}
+
+	@Test
+	void testPutPolicyCreatedAndUpdated() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		Policy policy = new Policy("policy123", Collections.singletonList("groups/publishers"), "publisher", null);
+
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, MockHttp.response(201));
+		assertTrue(client.putPolicy("policy123", policy));
+		assertEquals("PUT", request.get().getMethod());
+		assertEquals(PERMISSIONS_API_URL + "/v1/policies/policy123", request.get().getUri().toString());
+
+		stubExecuteWithHandler(mockClient, MockHttp.response(200));
+		assertFalse(client.putPolicy("policy123", policy));
+		client.close();
+	}
+
+	@Test
+	void testPutPolicyThrowsOnNullOrEmpty() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		assertThrows(IllegalArgumentException.class, () -> client.putPolicy("", new Policy()));
+		assertThrows(IllegalArgumentException.class, () -> client.putPolicy("policy123", null));
+		client.close();
 	}
 
+	@Test
+	void testGetPermissionsBundleSuccess() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		CloseableHttpResponse mockResponse = MockHttp.response(200);
+		Map<String, Map<String, List<BundlePolicy>>> body = new HashMap<>();
+		body.put("datasets:edit", Collections.singletonMap("groups/publishers",
+				Collections.singletonList(new BundlePolicy("policy123", null))));
+		MockHttp.responseBody(mockResponse, body);
+		AtomicReference<ClassicHttpRequest> request = stubExecuteWithHandler(mockClient, mockResponse);
+
+		PermissionsBundle bundle = client.getPermissionsBundle();
+
+		assertEquals("policy123", bundle.getPolicies("datasets:edit", "groups/publishers").get(0).getId());
+		assertTrue(bundle.getPolicies("datasets:edit", "groups/viewers").isEmpty());
+		assertTrue(bundle.getPolicies("datasets:delete", "groups/publishers").isEmpty());
+		assertEquals(PERMISSIONS_API_URL + "/v1/permissions-bundle", request.get().getUri().toString());
+		assertEquals("application/json", request.get().getFirstHeader("Accept").getValue());
+		client.close();
+	}
+
+	@Test
+	void testGetPermissionsBundleServerError() throws Exception {
+		CloseableHttpClient mockClient = mock(CloseableHttpClient.class);
+		PermissionsAPIClient client = new PermissionsAPIClient(PERMISSIONS_API_URL, SERVICE_AUTH_TOKEN, mockClient);
+		stubExecuteWithHandler(mockClient, MockHttp.response(500));
+		PermissionsAPIException ex = assertThrows(PermissionsAPIException.class, client::getPermissionsBundle);
+		assertEquals(500, ex.getCode());
+		client.close();
+	}
 
+	private static Map<String, Object> errorBody(final String code, final String description) {
+		Map<String, String> error = new HashMap<>();
+		error.put("code", code);
+		error.put("description", description);
+		return Collections.singletonMap("errors", Collections.singletonList(error));
+	}
+
+	@SuppressWarnings({"rawtypes", "unchecked"})
+	private AtomicReference<ClassicHttpRequest> stubExecuteWithHandler(final CloseableHttpClient mockClient,
+			final CloseableHttpResponse mockResponse) throws Exception {
+		AtomicReference<ClassicHttpRequest> request = new AtomicReference<>();
+		doAnswer(invocation -> {
+			request.set(invocation.getArgument(0));
+			HttpClientResponseHandler handler = invocation.getArgument(1);
+			return handler.handleResponse(mockResponse);
+		}).when(mockClient).execute(any(ClassicHttpRequest.class), any(HttpClientResponseHandler.class));
+		return request;
+	}
 }
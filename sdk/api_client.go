@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
 	"github.com/ONSdigital/dp-permissions-api/models"
@@ -14,13 +16,16 @@ import (
 
 // package level constants
 const (
-	bundlerEndpoint          = "%s/v1/permissions-bundle"
-	addPolicyEndpoint        = "%s/v1/policies"    // Add policy
-	policyEndpoint           = "%s/v1/policies/%s" // Get / Add / Update / Delete policy
-	rolesEndpoint            = "%s/v1/roles"       // Add roles
-	getRoleEndpoint          = "%s/v1/roles/%s"    // Get roles
-	Authorization     string = "Authorization"
-	BearerPrefix      string = "Bearer "
+	bundlerEndpoint              = "%s/v1/permissions-bundle"
+	bundleKeysEndpoint           = "%s/v1/permissions-bundle/keys"
+	addPolicyEndpoint            = "%s/v1/policies"                // Add policy
+	policyEndpoint               = "%s/v1/policies/%s"             // Get / Add / Update / Delete policy
+	policyEntityEndpoint         = "%s/v1/policies/%s/entities/%s" // Add / Remove policy entity
+	restorePolicyEndpoint        = "%s/v1/policies/%s/restore"     // Restore deleted policy
+	rolesEndpoint                = "%s/v1/roles"                   // Add roles
+	getRoleEndpoint              = "%s/v1/roles/%s"                // Get roles
+	Authorization         string = "Authorization"
+	BearerPrefix          string = "Bearer "
 )
 
 // HTTPClient is the interface that defines a client for making HTTP requests
@@ -30,26 +35,68 @@ type HTTPClient interface {
 
 // APIClient implementation of permissions.Store that gets permission data from the permissions API
 type APIClient struct {
-	host    string
-	httpCli HTTPClient
+	host           string
+	httpCli        HTTPClient
+	verifier       *bundleVerifier
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+	tokenSource    TokenSource
 }
 
 // NewClient constructs a new APIClient instance with a default http client and Options.
-func NewClient(host string) *APIClient {
-	return NewClientWithClienter(host, dphttp.NewClient())
+// Requests to idempotent endpoints are retried with the default retry policy, unless the options override it.
+func NewClient(host string, opts ...Option) *APIClient {
+	return NewClientWithClienter(host, newDefaultHTTPClient(), append([]Option{WithMaxRetries(defaultMaxRetries)}, opts...)...)
 }
 
-// NewClientWithClienter constructs a new APIClient instance.
-func NewClientWithClienter(host string, httpClient HTTPClient) *APIClient {
-	return &APIClient{
-		host:    host,
-		httpCli: httpClient,
+// NewClientWithClienter constructs a new APIClient instance. Requests are not retried unless WithMaxRetries is given,
+// as the http client may have a retry policy of its own.
+func NewClientWithClienter(host string, httpClient HTTPClient, opts ...Option) *APIClient {
+	c := &APIClient{
+		host:           host,
+		httpCli:        httpClient,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewVerifyingClient constructs a new APIClient instance with a default http client, which rejects any permissions
+// bundle that has not been signed by one of the given keys.
+func NewVerifyingClient(host string, keys models.JSONWebKeySet, opts ...Option) (*APIClient, error) {
+	return NewVerifyingClientWithClienter(host, newDefaultHTTPClient(), keys, append([]Option{WithMaxRetries(defaultMaxRetries)}, opts...)...)
+}
+
+// NewVerifyingClientWithClienter constructs a new APIClient instance, which rejects any permissions bundle that has
+// not been signed by one of the given keys.
+func NewVerifyingClientWithClienter(host string, httpClient HTTPClient, keys models.JSONWebKeySet, opts ...Option) (*APIClient, error) {
+	verifier, err := newBundleVerifier(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClientWithClienter(host, httpClient, opts...)
+	c.verifier = verifier
+	return c, nil
+}
+
+// newDefaultHTTPClient creates a dp-net http client without retries, as they are made by the APIClient retry policy
+func newDefaultHTTPClient() dphttp.Clienter {
+	httpClient := dphttp.NewClient()
+	httpClient.SetMaxRetries(0)
+	return httpClient
 }
 
 // == Roles Endpoint ==
 
-func (c *APIClient) GetRoles(ctx context.Context, headers Headers) (*models.Roles, error) {
+// GetRoles gets a page of roles, ordered by ID. The server default offset and limit are used, unless they are set
+// with the WithOffset and WithLimit options.
+func (c *APIClient) GetRoles(ctx context.Context, headers Headers, opts ...ListOption) (*models.Roles, error) {
 	uri := fmt.Sprintf(rolesEndpoint, c.host)
 
 	req, err := http.NewRequest(http.MethodGet, uri, http.NoBody)
@@ -57,9 +104,15 @@ func (c *APIClient) GetRoles(ctx context.Context, headers Headers) (*models.Role
 		return nil, err
 	}
 
+	query := req.URL.Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	req.URL.RawQuery = query.Encode()
+
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +124,7 @@ func (c *APIClient) GetRoles(ctx context.Context, headers Headers) (*models.Role
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api permissions-getallpolicies endpoint: %s", resp.Status)
+		return nil, newAPIError(resp, "permissions-getallpolicies")
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -88,7 +141,8 @@ func (c *APIClient) GetRoles(ctx context.Context, headers Headers) (*models.Role
 	return &result, nil
 }
 
-func (c *APIClient) GetRole(ctx context.Context, id string, headers Headers) (*models.Roles, error) {
+// GetRole gets a role, including its effective permissions, by its ID
+func (c *APIClient) GetRole(ctx context.Context, id string, headers Headers) (*models.Role, error) {
 	uri := fmt.Sprintf(getRoleEndpoint, c.host, id)
 
 	req, err := http.NewRequest(http.MethodGet, uri, http.NoBody)
@@ -98,7 +152,7 @@ func (c *APIClient) GetRole(ctx context.Context, id string, headers Headers) (*m
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +164,7 @@ func (c *APIClient) GetRole(ctx context.Context, id string, headers Headers) (*m
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api permissions-getrole endpoint: %s", resp.Status)
+		return nil, newAPIError(resp, "permissions-getrole")
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -118,7 +172,7 @@ func (c *APIClient) GetRole(ctx context.Context, id string, headers Headers) (*m
 		return nil, fmt.Errorf("unexpected error when attempting to read response: %v", err)
 	}
 
-	var result models.Roles
+	var result models.Role
 	err = json.Unmarshal(b, &result)
 	if err != nil {
 		return nil, fmt.Errorf("unable to unmarshal permission response to model: %v", err)
@@ -145,7 +199,7 @@ func (c *APIClient) PostPolicy(ctx context.Context, policy models.PolicyInfo, he
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +211,7 @@ func (c *APIClient) PostPolicy(ctx context.Context, policy models.PolicyInfo, he
 	}()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api permissions-addpolicy endpoint: %s", resp.Status)
+		return nil, newAPIError(resp, "permissions-addpolicy")
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -190,7 +244,7 @@ func (c *APIClient) PostPolicyWithID(ctx context.Context, id string, policy mode
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +256,7 @@ func (c *APIClient) PostPolicyWithID(ctx context.Context, id string, policy mode
 	}()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api: %s", resp.Status)
+		return nil, newAPIError(resp, "")
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -229,7 +283,7 @@ func (c *APIClient) DeletePolicy(ctx context.Context, id string, headers Headers
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -240,8 +294,38 @@ func (c *APIClient) DeletePolicy(ctx context.Context, id string, headers Headers
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status returned from the permissions api permissions-deletepolicy endpoint: %s", resp.Status)
+	if resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "permissions-deletepolicy")
+	}
+
+	return nil
+}
+
+// RestorePolicy restores a deleted policy that has not been purged. ErrPolicyNotDeleted is returned if the policy is
+// not deleted.
+func (c *APIClient) RestorePolicy(ctx context.Context, id string, headers Headers) error {
+	uri := fmt.Sprintf(restorePolicyEndpoint, c.host, id)
+
+	req, err := http.NewRequest(http.MethodPost, uri, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	headers.Add(req)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "permissions-restorepolicy")
 	}
 
 	return nil
@@ -257,7 +341,7 @@ func (c *APIClient) GetPolicy(ctx context.Context, id string, headers Headers) (
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -269,7 +353,7 @@ func (c *APIClient) GetPolicy(ctx context.Context, id string, headers Headers) (
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api permissions-getpolicy endpoint: %s", resp.Status)
+		return nil, newAPIError(resp, "permissions-getpolicy")
 	}
 
 	b, err := io.ReadAll(resp.Body)
@@ -286,9 +370,48 @@ func (c *APIClient) GetPolicy(ctx context.Context, id string, headers Headers) (
 	return &result, nil
 }
 
-func (c *APIClient) PutPolicy(ctx context.Context, id string, policy models.Policy, headers Headers) error {
+// PutPolicy creates or updates the policy with the given ID, and reports whether the policy was created
+func (c *APIClient) PutPolicy(ctx context.Context, id string, policy models.Policy, headers Headers) (created bool, err error) {
 	uri := fmt.Sprintf(policyEndpoint, c.host, id)
 
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPut, uri, bytes.NewReader(b))
+	if err != nil {
+		return false, err
+	}
+
+	headers.Add(req)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	default:
+		return false, newAPIError(resp, "permissions-putpolicy")
+	}
+}
+
+// UpdatePolicy replaces the policy with the given ID. Unlike PutPolicy, a policy that does not exist is not created, and
+// ErrPolicyNotFound is returned instead.
+func (c *APIClient) UpdatePolicy(ctx context.Context, id string, policy models.Policy, headers Headers) error {
+	uri := fmt.Sprintf(policyEndpoint, c.host, id) + "?strict=true"
+
 	b, err := json.Marshal(policy)
 	if err != nil {
 		return err
@@ -301,7 +424,7 @@ func (c *APIClient) PutPolicy(ctx context.Context, id string, policy models.Poli
 
 	headers.Add(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -313,7 +436,70 @@ func (c *APIClient) PutPolicy(ctx context.Context, id string, policy models.Poli
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status returned from the permissions api permissions-putpolicy endpoint: %s", resp.Status)
+		return newAPIError(resp, "permissions-updatepolicy")
+	}
+
+	return nil
+}
+
+// AddPolicyEntity adds an entity, e.g. groups/role-admin, to the policy with the given ID, without replacing the rest of
+// the policy, and reports whether the entity was added, i.e. it was not already an entity of the policy
+func (c *APIClient) AddPolicyEntity(ctx context.Context, policyID, entity string, headers Headers) (added bool, err error) {
+	uri := fmt.Sprintf(policyEntityEndpoint, c.host, policyID, url.PathEscape(entity))
+
+	req, err := http.NewRequest(http.MethodPost, uri, http.NoBody)
+	if err != nil {
+		return false, err
+	}
+
+	headers.Add(req)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	default:
+		return false, newAPIError(resp, "permissions-addpolicyentity")
+	}
+}
+
+// RemovePolicyEntity removes an entity from the policy with the given ID, without replacing the rest of the policy.
+// Removing an entity that is not an entity of the policy succeeds, but the last entity of a policy cannot be removed.
+func (c *APIClient) RemovePolicyEntity(ctx context.Context, policyID, entity string, headers Headers) error {
+	uri := fmt.Sprintf(policyEntityEndpoint, c.host, policyID, url.PathEscape(entity))
+
+	req, err := http.NewRequest(http.MethodDelete, uri, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	headers.Add(req)
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "permissions-removepolicyentity")
 	}
 
 	return nil
@@ -322,6 +508,7 @@ func (c *APIClient) PutPolicy(ctx context.Context, id string, policy models.Poli
 // == Permissions Endpoint ==
 
 // GetPermissionsBundle gets the permissions bundle data from the permissions API.
+// The compressed and normalised bundle encodings are requested, and decoded transparently.
 func (c *APIClient) GetPermissionsBundle(ctx context.Context, headers Headers) (Bundle, error) {
 	uri := fmt.Sprintf(bundlerEndpoint, c.host)
 
@@ -331,8 +518,9 @@ func (c *APIClient) GetPermissionsBundle(ctx context.Context, headers Headers) (
 	}
 
 	headers.Add(req)
+	addBundleNegotiationHeaders(req)
 
-	resp, err := c.httpCli.Do(ctx, req)
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -344,10 +532,27 @@ func (c *APIClient) GetPermissionsBundle(ctx context.Context, headers Headers) (
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status returned from the permissions api permissions-bundle endpoint: %s", resp.Status)
+		return nil, newAPIError(resp, "permissions-bundle")
+	}
+
+	b, err := getResponseBytes(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err = decodeContent(b, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.verifier != nil {
+		err = c.verifier.verify(b, resp.Header.Get(models.BundleSignatureKeyIDHeader), resp.Header.Get(models.BundleSignatureHeader))
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	permissions, err := getPermissionsBundleFromResponse(resp.Body)
+	permissions, err := getPermissionsBundleFromBytes(b, resp.Header.Get("Content-Type"))
 	if err != nil {
 		return nil, err
 	}
@@ -355,19 +560,44 @@ func (c *APIClient) GetPermissionsBundle(ctx context.Context, headers Headers) (
 	return permissions, nil
 }
 
-func getPermissionsBundleFromResponse(reader io.Reader) (Bundle, error) {
-	b, err := getResponseBytes(reader)
+// GetBundleVerificationKeys gets the set of public keys that can be used to verify signed permissions bundles.
+func (c *APIClient) GetBundleVerificationKeys(ctx context.Context, headers Headers) (*models.JSONWebKeySet, error) {
+	uri := fmt.Sprintf(bundleKeysEndpoint, c.host)
+
+	req, err := http.NewRequest(http.MethodGet, uri, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	headers.Add(req)
+
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	var bundle Bundle
+	defer func() {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+	}()
 
-	if err := json.Unmarshal(b, &bundle); err != nil {
-		return nil, ErrFailedToParsePermissionsResponse
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "permissions-bundle-keys")
 	}
 
-	return bundle, nil
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error when attempting to read response: %v", err)
+	}
+
+	var result models.JSONWebKeySet
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal permission response to model: %v", err)
+	}
+
+	return &result, nil
 }
 
 func getResponseBytes(reader io.Reader) ([]byte, error) {
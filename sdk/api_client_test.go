@@ -419,16 +419,11 @@ func TestAPIClient_GetRoles_Non200ResponseCodeReturned(t *testing.T) {
 
 func TestAPIClient_GetRole(t *testing.T) {
 	ctx := context.Background()
-	result := models.Roles{
-		Count:  2,
-		Offset: 0,
-		Limit:  2,
-		Items: []models.Role{{
-			ID:          "2",
-			Name:        testRoleName,
-			Permissions: []string{testPermissionAll},
-		}},
-		TotalCount: 0,
+	result := models.Role{
+		ID:                   "2",
+		Name:                 testRoleName,
+		Permissions:          []string{testPermissionAll},
+		EffectivePermissions: []string{testPermissionAll},
 	}
 
 	bresult, err := json.Marshal(result)
@@ -455,7 +450,7 @@ func TestAPIClient_GetRole(t *testing.T) {
 			})
 
 			Convey("Then the expected role is returned", func() {
-				So(role.Items[0], ShouldResemble, models.Role{ID: "2", Name: testRoleName, Permissions: []string{testPermissionAll}})
+				So(role, ShouldResemble, &models.Role{ID: "2", Name: testRoleName, Permissions: []string{testPermissionAll}, EffectivePermissions: []string{testPermissionAll}})
 			})
 		})
 	})
@@ -790,7 +785,7 @@ func TestAPIClient_DeletePolicy(t *testing.T) {
 		httpClient := &dphttp.ClienterMock{
 			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
 				return &http.Response{
-					StatusCode: http.StatusOK,
+					StatusCode: http.StatusNoContent,
 					Body:       io.NopCloser(bytes.NewReader([]byte{})),
 				}, nil
 			},
@@ -828,6 +823,28 @@ func TestAPIClient_DeletePolicy_BadRequest(t *testing.T) {
 	})
 }
 
+func TestAPIClient_DeletePolicy_Conflict(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the permissions API responds that the deletion would lock everyone out", t, func() {
+		apiClient := newErrorResponseClient(http.StatusConflict, `{"errors":[{"code":"LockoutError","description":"the change would leave no entity holding: policies:delete"}]}`)
+
+		Convey("When DeletePolicy is called, the error is a lockout error", func() {
+			err := apiClient.DeletePolicy(ctx, "policy1", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrLockout), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given the permissions API responds that the policy is protected", t, func() {
+		apiClient := newErrorResponseClient(http.StatusConflict, `{"errors":[{"code":"ProtectedPolicyError","description":"policy is protected"}]}`)
+
+		Convey("When DeletePolicy is called, the error is a protected policy error", func() {
+			err := apiClient.DeletePolicy(ctx, "policy1", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrProtectedPolicy), ShouldBeTrue)
+		})
+	})
+}
+
 func TestAPIClient_DeletePolicy_Non200ResponseCodeReturned(t *testing.T) {
 	ctx := context.Background()
 
@@ -892,6 +909,42 @@ func TestAPIClient_DeletePolicy_Non200ResponseCodeReturned(t *testing.T) {
 	})
 }
 
+func TestAPIClient_RestorePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mock http client that returns a successful restore policy response", t, func() {
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusNoContent,
+					Body:       io.NopCloser(bytes.NewReader([]byte{})),
+				}, nil
+			},
+		}
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When RestorePolicy is called", func() {
+			err := apiClient.RestorePolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the policy is restored, and no error is returned", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+				So(httpClient.DoCalls()[0].Req.Method, ShouldEqual, http.MethodPost)
+				So(httpClient.DoCalls()[0].Req.URL.String(), ShouldEqual, host+"/v1/policies/policy1/restore")
+			})
+		})
+	})
+
+	Convey("Given the permissions API responds that the policy is not deleted", t, func() {
+		apiClient := newErrorResponseClient(http.StatusConflict, `{"errors":[{"code":"PolicyNotDeletedError","description":"policy with given ID is not deleted"}]}`)
+
+		Convey("When RestorePolicy is called, the error is a policy not deleted error", func() {
+			err := apiClient.RestorePolicy(ctx, "policy1", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyNotDeleted), ShouldBeTrue)
+		})
+	})
+}
+
 func TestAPIClient_GetPolicy(t *testing.T) {
 	ctx := context.Background()
 
@@ -1036,18 +1089,37 @@ func TestAPIClient_PutPolicy(t *testing.T) {
 		apiClient := sdk.NewClientWithClienter(host, httpClient)
 
 		Convey("When PutPolicy is called", func() {
-			err := apiClient.PutPolicy(ctx, "1", models.Policy{
+			created, err := apiClient.PutPolicy(ctx, "1", models.Policy{
 				ID:        "",
 				Entities:  nil,
 				Role:      "",
 				Condition: models.Condition{},
 			}, sdk.Headers{})
 
-			Convey("Then no error is returned", func() {
+			Convey("Then no error is returned, and the policy is reported as updated", func() {
 				So(err, ShouldBeNil)
+				So(created, ShouldBeFalse)
 			})
 		})
 	})
+
+	Convey("Given a mock http client that returns a created put policy response", t, func() {
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusCreated,
+					Body:       io.NopCloser(bytes.NewReader([]byte{})),
+				}, nil
+			},
+		}
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When PutPolicy is called, the policy is reported as created", func() {
+			created, err := apiClient.PutPolicy(ctx, "1", models.Policy{Role: "r1"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+		})
+	})
 }
 
 func TestAPIClient_PutPolicy_BadRequest(t *testing.T) {
@@ -1062,7 +1134,7 @@ func TestAPIClient_PutPolicy_BadRequest(t *testing.T) {
 		apiClient := sdk.NewClientWithClienter(host, httpClient)
 
 		Convey("When PutPolicy is called", func() {
-			err := apiClient.PutPolicy(ctx, "1", models.Policy{}, sdk.Headers{})
+			_, err := apiClient.PutPolicy(ctx, "1", models.Policy{}, sdk.Headers{})
 
 			Convey("Then an error is returned", func() {
 				So(err, ShouldResemble, errors.New("bad request"))
@@ -1086,7 +1158,7 @@ func TestAPIClient_PutPolicy_Non200ResponseCodeReturned(t *testing.T) {
 		apiClient := sdk.NewClientWithClienter(host, httpClient)
 
 		Convey("When PutPolicy is called", func() {
-			err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
+			_, err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
 
 			Convey("Then the expected error is returned", func() {
 				So(err.Error(), ShouldEqual, `unexpected status returned from the permissions api permissions-putpolicy endpoint: Bad request. Invalid policy supplied`)
@@ -1106,7 +1178,7 @@ func TestAPIClient_PutPolicy_Non200ResponseCodeReturned(t *testing.T) {
 		apiClient := sdk.NewClientWithClienter(host, httpClient)
 
 		Convey("When PutPolicy is called", func() {
-			err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
+			_, err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
 
 			Convey("Then the expected error is returned", func() {
 				So(err.Error(), ShouldEqual, `unexpected status returned from the permissions api permissions-putpolicy endpoint: `+statusUnauthorisedRequest)
@@ -1126,7 +1198,7 @@ func TestAPIClient_PutPolicy_Non200ResponseCodeReturned(t *testing.T) {
 		apiClient := sdk.NewClientWithClienter(host, httpClient)
 
 		Convey("When PutPolicy is called", func() {
-			err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
+			_, err := apiClient.PutPolicy(ctx, "", models.Policy{}, sdk.Headers{})
 
 			Convey("Then the expected error is returned", func() {
 				So(err.Error(), ShouldEqual, `unexpected status returned from the permissions api permissions-putpolicy endpoint: `+statusInternalError)
@@ -0,0 +1,181 @@
+package sdk
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// refreshJitterFactor is the maximum fraction of the refresh interval that is randomly added to each refresh interval,
+// so that services started at the same time do not all refresh their bundle at the same time
+const refreshJitterFactor = 0.1
+
+// The refresh interval and maximum age of a BundleCache that is created without a positive refresh interval or
+// maximum age
+const (
+	DefaultBundleRefreshInterval = 5 * time.Minute
+	DefaultBundleMaxAge          = time.Hour
+)
+
+// Compiler check to ensure BundleCache implements the BundleGetter interface.
+var _ BundleGetter = (*BundleCache)(nil)
+
+// BundleGetter gets the permissions bundle, e.g. from the permissions API with an APIClient
+type BundleGetter interface {
+	GetPermissionsBundle(ctx context.Context, headers Headers) (Bundle, error)
+}
+
+// BundleCache keeps an in-memory copy of the permissions bundle, which it refreshes on an interval. If a refresh fails,
+// the last bundle that was got successfully keeps being served until it is older than the maximum age.
+type BundleCache struct {
+	getter          BundleGetter
+	refreshInterval time.Duration
+	maxAge          time.Duration
+
+	mutex       sync.RWMutex
+	bundle      Bundle
+	lastUpdated time.Time
+	lastErr     error
+
+	closing   chan struct{}
+	closed    chan struct{}
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// NewBundleCache creates a BundleCache that gets the bundle from the getter every refreshInterval, and serves it for
+// up to maxAge after it was last got successfully. A refresh interval or maximum age that is not positive is replaced
+// with DefaultBundleRefreshInterval or DefaultBundleMaxAge, so that a misconfigured cache does not call the getter in
+// a tight loop.
+func NewBundleCache(getter BundleGetter, refreshInterval, maxAge time.Duration) *BundleCache {
+	if refreshInterval <= 0 {
+		log.Warn(context.Background(), "invalid permissions bundle refresh interval, using the default", log.Data{"refresh_interval": refreshInterval, "default": DefaultBundleRefreshInterval})
+		refreshInterval = DefaultBundleRefreshInterval
+	}
+	if maxAge <= 0 {
+		log.Warn(context.Background(), "invalid permissions bundle maximum age, using the default", log.Data{"max_age": maxAge, "default": DefaultBundleMaxAge})
+		maxAge = DefaultBundleMaxAge
+	}
+
+	return &BundleCache{
+		getter:          getter,
+		refreshInterval: refreshInterval,
+		maxAge:          maxAge,
+		closing:         make(chan struct{}),
+		closed:          make(chan struct{}),
+	}
+}
+
+// Start gets the bundle, and starts a go routine that refreshes it until the cache is closed or the context is done.
+// The error of the initial load is returned, but the refreshes are started anyway, so that the cache recovers once
+// the bundle can be got.
+func (c *BundleCache) Start(ctx context.Context) error {
+	err := c.Refresh(ctx)
+
+	c.startOnce.Do(func() {
+		go c.refreshLoop(ctx)
+	})
+
+	return err
+}
+
+// Refresh gets the bundle and caches it. The cached bundle is kept if the bundle cannot be got.
+func (c *BundleCache) Refresh(ctx context.Context) error {
+	bundle, err := c.getter.GetPermissionsBundle(ctx, Headers{})
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.lastErr = err
+	if err != nil {
+		return err
+	}
+
+	c.bundle = bundle
+	c.lastUpdated = time.Now()
+	return nil
+}
+
+// GetPermissionsBundle returns the cached bundle. ErrNotCached is returned if the bundle has not been got yet, or if
+// it was last got successfully more than the maximum age ago.
+func (c *BundleCache) GetPermissionsBundle(_ context.Context, _ Headers) (Bundle, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.bundle == nil || c.isExpired() {
+		return nil, ErrNotCached
+	}
+	return c.bundle, nil
+}
+
+// Checker reports the health of the cache. It is critical if there is no bundle to serve, and warning if the last
+// refresh failed but the last bundle got is still being served. Implements healthcheck.Checker.
+func (c *BundleCache) Checker(_ context.Context, state *healthcheck.CheckState) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	switch {
+	case c.bundle == nil:
+		return state.Update(healthcheck.StatusCritical, "permissions bundle has not been cached", 0)
+	case c.isExpired():
+		return state.Update(healthcheck.StatusCritical, "cached permissions bundle has expired", 0)
+	case c.lastErr != nil:
+		return state.Update(healthcheck.StatusWarning, "the last permissions bundle refresh failed: "+c.lastErr.Error(), 0)
+	default:
+		return state.Update(healthcheck.StatusOK, "permissions bundle cache is ok", 0)
+	}
+}
+
+// Close stops refreshing the bundle, and waits for any refresh in progress to finish
+func (c *BundleCache) Close(_ context.Context) error {
+	c.closeOnce.Do(func() {
+		close(c.closing)
+	})
+
+	c.startOnce.Do(func() {
+		// the cache was never started, so there is no refresh loop to wait for, and it must not be started now
+		close(c.closed)
+	})
+	<-c.closed
+
+	return nil
+}
+
+// isExpired must be called with the mutex held
+func (c *BundleCache) isExpired() bool {
+	return c.maxAge > 0 && time.Since(c.lastUpdated) > c.maxAge
+}
+
+func (c *BundleCache) refreshLoop(ctx context.Context) {
+	defer close(c.closed)
+
+	for {
+		timer := time.NewTimer(c.nextRefresh())
+		select {
+		case <-timer.C:
+			if err := c.Refresh(ctx); err != nil {
+				log.Error(ctx, "failed to refresh the permissions bundle cache", err)
+			}
+		case <-c.closing:
+			timer.Stop()
+			return
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// nextRefresh returns the refresh interval with a random jitter of up to refreshJitterFactor of it added
+func (c *BundleCache) nextRefresh() time.Duration {
+	jitter := time.Duration(float64(c.refreshInterval) * refreshJitterFactor)
+	if jitter <= 0 {
+		return c.refreshInterval
+	}
+	//nolint:gosec // jitter spreads refreshes out, it does not need a secure random number
+	return c.refreshInterval + rand.N(jitter)
+}
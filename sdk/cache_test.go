@@ -0,0 +1,201 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errBundleUnavailable = errors.New("bundle unavailable")
+
+// stubBundleGetter returns its bundle, or its error if one is set, and counts the calls made to it
+type stubBundleGetter struct {
+	mutex  sync.Mutex
+	bundle sdk.Bundle
+	err    error
+	calls  int
+}
+
+func (g *stubBundleGetter) GetPermissionsBundle(_ context.Context, _ sdk.Headers) (sdk.Bundle, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.calls++
+	if g.err != nil {
+		return nil, g.err
+	}
+	return g.bundle, nil
+}
+
+func (g *stubBundleGetter) set(bundle sdk.Bundle, err error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.bundle, g.err = bundle, err
+}
+
+func (g *stubBundleGetter) callCount() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.calls
+}
+
+func checkCache(cache *sdk.BundleCache) *healthcheck.CheckState {
+	state := healthcheck.NewCheckState("permissions bundle cache")
+	So(cache.Checker(context.Background(), state), ShouldBeNil)
+	return state
+}
+
+func TestBundleCache(t *testing.T) {
+	ctx := context.Background()
+	bundle := sdk.Bundle{"datasets:read": {"groups/viewers": {{ID: "viewers-read"}}}}
+	updatedBundle := sdk.Bundle{"datasets:edit": {"groups/publishers": {{ID: "publishers-edit"}}}}
+
+	Convey("Given a bundle cache that has not been started", t, func() {
+		getter := &stubBundleGetter{bundle: bundle}
+		cache := sdk.NewBundleCache(getter, time.Hour, time.Hour)
+
+		Convey("Then ErrNotCached is returned and the cache is critical", func() {
+			cached, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+			So(err, ShouldEqual, sdk.ErrNotCached)
+			So(cached, ShouldBeNil)
+			So(checkCache(cache).Status(), ShouldEqual, healthcheck.StatusCritical)
+		})
+
+		Convey("When it is closed, then it returns without starting", func() {
+			So(cache.Close(ctx), ShouldBeNil)
+			So(getter.callCount(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a bundle cache that is started", t, func() {
+		getter := &stubBundleGetter{bundle: bundle}
+		cache := sdk.NewBundleCache(getter, time.Hour, time.Hour)
+		So(cache.Start(ctx), ShouldBeNil)
+		defer cache.Close(ctx)
+
+		Convey("Then the bundle is got on start and served from the cache", func() {
+			cached, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(cached, ShouldResemble, bundle)
+			So(getter.callCount(), ShouldEqual, 1)
+			So(checkCache(cache).Status(), ShouldEqual, healthcheck.StatusOK)
+		})
+
+		Convey("When a refresh fails", func() {
+			getter.set(nil, errBundleUnavailable)
+			So(cache.Refresh(ctx), ShouldEqual, errBundleUnavailable)
+
+			Convey("Then the last bundle is still served and the cache is warning", func() {
+				cached, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+				So(err, ShouldBeNil)
+				So(cached, ShouldResemble, bundle)
+
+				state := checkCache(cache)
+				So(state.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(state.Message(), ShouldContainSubstring, errBundleUnavailable.Error())
+			})
+
+			Convey("And a later refresh succeeds, then the new bundle is served and the cache is ok", func() {
+				getter.set(updatedBundle, nil)
+				So(cache.Refresh(ctx), ShouldBeNil)
+
+				cached, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+				So(err, ShouldBeNil)
+				So(cached, ShouldResemble, updatedBundle)
+				So(checkCache(cache).Status(), ShouldEqual, healthcheck.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given a bundle cache that fails to get the bundle on start", t, func() {
+		getter := &stubBundleGetter{err: errBundleUnavailable}
+		cache := sdk.NewBundleCache(getter, 10*time.Millisecond, time.Hour)
+		So(cache.Start(ctx), ShouldEqual, errBundleUnavailable)
+		defer cache.Close(ctx)
+
+		Convey("Then ErrNotCached is returned and the cache is critical", func() {
+			_, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+			So(err, ShouldEqual, sdk.ErrNotCached)
+			So(checkCache(cache).Status(), ShouldEqual, healthcheck.StatusCritical)
+		})
+
+		Convey("When the bundle becomes available, then it is cached by the next refresh", func() {
+			getter.set(bundle, nil)
+
+			So(func() bool {
+				_, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+				return err == nil
+			}, shouldEventuallyBeTrue)
+			So(getter.callCount(), ShouldBeGreaterThan, 1)
+		})
+	})
+
+	Convey("Given a bundle cache with a short maximum age", t, func() {
+		getter := &stubBundleGetter{bundle: bundle}
+		cache := sdk.NewBundleCache(getter, time.Hour, 10*time.Millisecond)
+		So(cache.Start(ctx), ShouldBeNil)
+		defer cache.Close(ctx)
+
+		Convey("When refreshes fail for longer than the maximum age", func() {
+			getter.set(nil, errBundleUnavailable)
+			So(cache.Refresh(ctx), ShouldEqual, errBundleUnavailable)
+			time.Sleep(20 * time.Millisecond)
+
+			Convey("Then the expired bundle is not served and the cache is critical", func() {
+				_, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+				So(err, ShouldEqual, sdk.ErrNotCached)
+				So(checkCache(cache).Status(), ShouldEqual, healthcheck.StatusCritical)
+			})
+		})
+	})
+
+	Convey("Given a bundle cache created without a positive refresh interval or maximum age", t, func() {
+		getter := &stubBundleGetter{bundle: bundle}
+		cache := sdk.NewBundleCache(getter, 0, -time.Second)
+		So(cache.Start(ctx), ShouldBeNil)
+		defer cache.Close(ctx)
+
+		Convey("Then the defaults are used, so the bundle is served and not refreshed in a tight loop", func() {
+			time.Sleep(10 * time.Millisecond)
+			So(getter.callCount(), ShouldEqual, 1)
+
+			cached, err := cache.GetPermissionsBundle(ctx, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(cached, ShouldResemble, bundle)
+		})
+	})
+
+	Convey("Given a bundle cache that refreshes frequently", t, func() {
+		getter := &stubBundleGetter{bundle: bundle}
+		cache := sdk.NewBundleCache(getter, time.Millisecond, time.Hour)
+		So(cache.Start(ctx), ShouldBeNil)
+
+		Convey("When it is closed, then the bundle is no longer refreshed", func() {
+			So(func() bool { return getter.callCount() > 2 }, shouldEventuallyBeTrue)
+			So(cache.Close(ctx), ShouldBeNil)
+			So(cache.Close(ctx), ShouldBeNil)
+
+			calls := getter.callCount()
+			time.Sleep(10 * time.Millisecond)
+			So(getter.callCount(), ShouldEqual, calls)
+		})
+	})
+}
+
+// shouldEventuallyBeTrue asserts that the condition becomes true within a second
+func shouldEventuallyBeTrue(actual any, _ ...any) string {
+	condition := actual.(func() bool)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return ""
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return "expected the condition to become true within a second"
+}
@@ -0,0 +1,402 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+
+	authmock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	"github.com/ONSdigital/dp-permissions-api/api"
+	apimock "github.com/ONSdigital/dp-permissions-api/api/mock"
+	"github.com/ONSdigital/dp-permissions-api/apierrors"
+	"github.com/ONSdigital/dp-permissions-api/config"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/permissions"
+	permissionsmock "github.com/ONSdigital/dp-permissions-api/permissions/mock"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// contractStore is an in-memory permissions store that behaves like the Mongo store, so that the SDK can be tested
+// against the real API router
+type contractStore struct {
+	mu       sync.Mutex
+	roles    map[string]*models.Role
+	policies map[string]*models.Policy
+	deleted  map[string]*models.Policy
+}
+
+func newContractStore(roles ...*models.Role) *contractStore {
+	s := &contractStore{roles: map[string]*models.Role{}, policies: map[string]*models.Policy{}, deleted: map[string]*models.Policy{}}
+	for _, role := range roles {
+		s.roles[role.ID] = role
+	}
+	return s
+}
+
+func (s *contractStore) sortedRoles() []*models.Role {
+	roles := make([]*models.Role, 0, len(s.roles))
+	for _, role := range s.roles {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles
+}
+
+func (s *contractStore) apiStore() *apimock.PermissionsStoreMock {
+	return &apimock.PermissionsStoreMock{
+		GetRoleFunc: func(ctx context.Context, id string) (*models.Role, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			role, ok := s.roles[id]
+			if !ok {
+				return nil, apierrors.ErrRoleNotFound
+			}
+			roleCopy := *role
+			return &roleCopy, nil
+		},
+		GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.sortedRoles(), nil
+		},
+		GetRolesFunc: func(ctx context.Context, offset, limit int) (*models.Roles, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			roles := s.sortedRoles()
+			items := []models.Role{}
+			for i := offset; i < len(roles) && i < offset+limit; i++ {
+				items = append(items, *roles[i])
+			}
+			return &models.Roles{Count: len(items), Offset: offset, Limit: limit, Items: items, TotalCount: len(roles)}, nil
+		},
+		AddPolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.Policy, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.policies[policy.ID] = policy
+			return policy, nil
+		},
+		GetPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			policy, ok := s.policies[id]
+			if !ok {
+				return nil, apierrors.ErrPolicyNotFound
+			}
+			return policy, nil
+		},
+		UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			_, exists := s.policies[policy.ID]
+			s.policies[policy.ID] = policy
+			if exists {
+				return &models.UpdateResult{ModifiedCount: 1}, nil
+			}
+			return &models.UpdateResult{UpsertedCount: 1}, nil
+		},
+		ReplacePolicyFunc: func(ctx context.Context, policy *models.Policy) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if _, ok := s.policies[policy.ID]; !ok {
+				return apierrors.ErrPolicyNotFound
+			}
+			s.policies[policy.ID] = policy
+			return nil
+		},
+		AddPolicyEntityFunc: func(ctx context.Context, policyID, entity, updatedBy string) (bool, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			policy, ok := s.policies[policyID]
+			if !ok {
+				return false, apierrors.ErrPolicyNotFound
+			}
+			if slices.Contains(policy.Entities, entity) {
+				return false, nil
+			}
+			policy.Entities = append(policy.Entities, entity)
+			return true, nil
+		},
+		RemovePolicyEntityFunc: func(ctx context.Context, policyID, entity, updatedBy string) (bool, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			policy, ok := s.policies[policyID]
+			if !ok {
+				return false, apierrors.ErrPolicyNotFound
+			}
+			if len(policy.Entities) == 1 && policy.Entities[0] == entity {
+				return false, apierrors.ErrLastPolicyEntity
+			}
+			entities := slices.DeleteFunc(slices.Clone(policy.Entities), func(e string) bool { return e == entity })
+			removed := len(entities) < len(policy.Entities)
+			policy.Entities = entities
+			return removed, nil
+		},
+		DeletePolicyFunc: func(ctx context.Context, id, deletedBy string) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			policy, ok := s.policies[id]
+			if !ok {
+				return apierrors.ErrPolicyNotFound
+			}
+			delete(s.policies, id)
+			s.deleted[id] = policy
+			return nil
+		},
+		GetDeletedPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if _, ok := s.policies[id]; ok {
+				return nil, apierrors.ErrPolicyNotDeleted
+			}
+			policy, ok := s.deleted[id]
+			if !ok {
+				return nil, apierrors.ErrPolicyNotFound
+			}
+			return policy, nil
+		},
+		RestorePolicyFunc: func(ctx context.Context, id, updatedBy string) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if _, ok := s.policies[id]; ok {
+				return apierrors.ErrPolicyNotDeleted
+			}
+			policy, ok := s.deleted[id]
+			if !ok {
+				return apierrors.ErrPolicyNotFound
+			}
+			delete(s.deleted, id)
+			s.policies[id] = policy
+			return nil
+		},
+	}
+}
+
+func (s *contractStore) bundlerStore() *permissionsmock.StoreMock {
+	return &permissionsmock.StoreMock{
+		GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.sortedRoles(), nil
+		},
+		GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			policies := []*models.BundlePolicy{}
+			for _, policy := range s.policies {
+				policies = append(policies, &models.BundlePolicy{ID: policy.ID, Entities: policy.Entities, Role: policy.Role, Condition: policy.Condition})
+			}
+			return policies, nil
+		},
+		GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+			return nil, nil
+		},
+	}
+}
+
+// newContractServer starts the real API router, with authorisation allowing every request
+func newContractServer(store *contractStore) *httptest.Server {
+	cfg := &config.Config{DefaultLimit: 20, DefaultOffset: 0, MaximumDefaultLimit: 1000}
+	auth := &authmock.MiddlewareMock{
+		RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+			return handlerFunc
+		},
+	}
+	permissionsAPI := api.Setup(cfg, mux.NewRouter(), store.apiStore(), permissions.NewBundler(store.bundlerStore()), nil, nil, nil, auth)
+	return httptest.NewServer(permissionsAPI.Router)
+}
+
+func TestContract_Roles(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the permissions API with a role that inherits from a parent role", t, func() {
+		store := newContractStore(
+			&models.Role{ID: "viewer", Name: "Viewer", Permissions: []string{"datasets:read"}},
+			&models.Role{ID: "publisher", Name: "Publisher", Permissions: []string{"datasets:edit"}, Parents: []string{"viewer"}},
+		)
+		server := newContractServer(store)
+		defer server.Close()
+		apiClient := sdk.NewClient(server.URL)
+
+		Convey("When GetRole is called, the role is returned with its effective permissions", func() {
+			role, err := apiClient.GetRole(ctx, "publisher", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(role.Name, ShouldEqual, "Publisher")
+			So(role.Parents, ShouldResemble, []string{"viewer"})
+			So(role.EffectivePermissions, ShouldResemble, []string{"datasets:edit", "datasets:read"})
+		})
+
+		Convey("When GetRole is called for a role that does not exist, a role not found error is returned", func() {
+			_, err := apiClient.GetRole(ctx, "inexistent", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrRoleNotFound), ShouldBeTrue)
+		})
+
+		Convey("When GetRoles is called with a limit, a page of roles is returned", func() {
+			roles, err := apiClient.GetRoles(ctx, sdk.Headers{}, sdk.WithLimit(1))
+			So(err, ShouldBeNil)
+			So(roles.Items, ShouldHaveLength, 1)
+			So(roles.Items[0].ID, ShouldEqual, "publisher")
+			So(roles.TotalCount, ShouldEqual, 2)
+		})
+
+		Convey("When ListAllRoles is called with a page size of 1, all the roles are returned", func() {
+			roles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 1)
+			So(err, ShouldBeNil)
+			So(getRoleIDs(roles), ShouldResemble, []string{"publisher", "viewer"})
+		})
+	})
+}
+
+func TestContract_NoRoles(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the permissions API without any roles", t, func() {
+		server := newContractServer(newContractStore())
+		defer server.Close()
+		apiClient := sdk.NewClient(server.URL)
+
+		Convey("When GetRoles is called, an empty page of roles is returned", func() {
+			roles, err := apiClient.GetRoles(ctx, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(roles.Items, ShouldBeEmpty)
+			So(roles.TotalCount, ShouldEqual, 0)
+		})
+
+		Convey("When ListAllRoles is called, an empty list of roles is returned", func() {
+			roles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 1)
+			So(err, ShouldBeNil)
+			So(roles, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestContract_Policies(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the permissions API with a role", t, func() {
+		store := newContractStore(&models.Role{ID: "viewer", Name: "Viewer", Permissions: []string{"datasets:read"}})
+		server := newContractServer(store)
+		defer server.Close()
+		apiClient := sdk.NewClient(server.URL)
+
+		Convey("When a policy is created with PostPolicy, it can be got with GetPolicy", func() {
+			policy, err := apiClient.PostPolicy(ctx, models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(policy.ID, ShouldNotBeEmpty)
+
+			got, err := apiClient.GetPolicy(ctx, policy.ID, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(got, ShouldResemble, policy)
+
+			Convey("And the policy is included in the permissions bundle", func() {
+				bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+				So(err, ShouldBeNil)
+				So(bundle["datasets:read"]["groups/viewers"], ShouldHaveLength, 1)
+				So(bundle["datasets:read"]["groups/viewers"][0].ID, ShouldEqual, policy.ID)
+			})
+		})
+
+		Convey("When a policy is created with an ID that already exists, a policy already exists error is returned", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy1", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+
+			_, err = apiClient.PostPolicyWithID(ctx, "policy1", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyAlreadyExists), ShouldBeTrue)
+		})
+
+		Convey("When an invalid policy is created, an invalid policy error is returned", func() {
+			_, err := apiClient.PostPolicy(ctx, models.PolicyInfo{Entities: []string{"groups/viewers"}}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrInvalidPolicy), ShouldBeTrue)
+		})
+
+		Convey("When PutPolicy is called for a new policy and then again, the policy is reported as created and then updated", func() {
+			created, err := apiClient.PutPolicy(ctx, "policy2", models.Policy{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			created, err = apiClient.PutPolicy(ctx, "policy2", models.Policy{Entities: []string{"groups/editors"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeFalse)
+		})
+
+		Convey("When UpdatePolicy is called for an existing policy, it is replaced, but an unknown policy is not created", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy5", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+
+			So(apiClient.UpdatePolicy(ctx, "policy5", models.Policy{Entities: []string{"groups/editors"}, Role: "viewer"}, sdk.Headers{}), ShouldBeNil)
+
+			policy, err := apiClient.GetPolicy(ctx, "policy5", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(policy.Entities, ShouldResemble, []string{"groups/editors"})
+
+			err = apiClient.UpdatePolicy(ctx, "policy6", models.Policy{Entities: []string{"groups/editors"}, Role: "viewer"}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeTrue)
+
+			_, err = apiClient.GetPolicy(ctx, "policy6", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeTrue)
+		})
+
+		Convey("When DeletePolicy is called for an existing policy and then again, it is deleted and then not found", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy3", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+
+			So(apiClient.DeletePolicy(ctx, "policy3", sdk.Headers{}), ShouldBeNil)
+			So(errors.Is(apiClient.DeletePolicy(ctx, "policy3", sdk.Headers{}), sdk.ErrPolicyNotFound), ShouldBeTrue)
+
+			_, err = apiClient.GetPolicy(ctx, "policy3", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeTrue)
+		})
+
+		Convey("When a deleted policy is restored with RestorePolicy, it can be got again", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy7", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(apiClient.DeletePolicy(ctx, "policy7", sdk.Headers{}), ShouldBeNil)
+
+			So(apiClient.RestorePolicy(ctx, "policy7", sdk.Headers{}), ShouldBeNil)
+
+			policy, err := apiClient.GetPolicy(ctx, "policy7", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(policy.Entities, ShouldResemble, []string{"groups/viewers"})
+
+			Convey("And a policy that is not deleted cannot be restored", func() {
+				err := apiClient.RestorePolicy(ctx, "policy7", sdk.Headers{})
+				So(errors.Is(err, sdk.ErrPolicyNotDeleted), ShouldBeTrue)
+			})
+		})
+
+		Convey("When entities are added to and removed from a policy, the policy is updated", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy4", models.PolicyInfo{Entities: []string{"groups/viewers"}, Role: "viewer"}, sdk.Headers{})
+			So(err, ShouldBeNil)
+
+			added, err := apiClient.AddPolicyEntity(ctx, "policy4", "users/viewer@ons.gov.uk", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(added, ShouldBeTrue)
+
+			added, err = apiClient.AddPolicyEntity(ctx, "policy4", "users/viewer@ons.gov.uk", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(added, ShouldBeFalse)
+
+			So(apiClient.RemovePolicyEntity(ctx, "policy4", "groups/viewers", sdk.Headers{}), ShouldBeNil)
+
+			policy, err := apiClient.GetPolicy(ctx, "policy4", sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(policy.Entities, ShouldResemble, []string{"users/viewer@ons.gov.uk"})
+
+			Convey("And the last entity of the policy cannot be removed", func() {
+				err := apiClient.RemovePolicyEntity(ctx, "policy4", "users/viewer@ons.gov.uk", sdk.Headers{})
+				So(errors.Is(err, sdk.ErrLastPolicyEntity), ShouldBeTrue)
+			})
+		})
+
+		Convey("When an entity is added to a policy that does not exist, a policy not found error is returned", func() {
+			_, err := apiClient.AddPolicyEntity(ctx, "unknown", "groups/viewers", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeTrue)
+		})
+	})
+}
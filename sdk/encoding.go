@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/andybalholm/brotli"
+)
+
+// Headers used to negotiate the permissions bundle encoding with the permissions API
+const (
+	bundleAcceptHeader         = models.BundleMediaTypeNormalised + ", " + models.BundleMediaTypeJSON + ";q=0.9"
+	bundleAcceptEncodingHeader = "br, gzip"
+)
+
+// MaxDecodedContentSize is the maximum size in bytes that a compressed permissions bundle may decompress to, which
+// protects clients from responses that expand into huge bodies
+const MaxDecodedContentSize = 128 << 20
+
+// normalisedBundle is the normalised encoding of the permissions bundle, which references policies by ID
+type normalisedBundle struct {
+	Policies    map[string]Policy              `json:"policies"`
+	Permissions map[string]map[string][]string `json:"permissions"`
+}
+
+// addBundleNegotiationHeaders requests the most compact permissions bundle encodings that the client can decode
+func addBundleNegotiationHeaders(req *http.Request) {
+	req.Header.Set("Accept", bundleAcceptHeader)
+	req.Header.Set("Accept-Encoding", bundleAcceptEncodingHeader)
+}
+
+// decodeContent reverses the content coding of a response body. Decoded bodies larger than MaxDecodedContentSize are rejected.
+func decodeContent(b []byte, contentEncoding string) ([]byte, error) {
+	var r io.Reader
+	switch contentEncoding {
+	case "", "identity":
+		return b, nil
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(b))
+	case "gzip":
+		gzipReader, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, ErrFailedToParsePermissionsResponse
+		}
+		defer gzipReader.Close()
+		r = gzipReader
+	default:
+		return nil, ErrUnsupportedContentEncoding
+	}
+
+	decoded, err := io.ReadAll(io.LimitReader(r, MaxDecodedContentSize+1))
+	if err != nil {
+		return nil, ErrFailedToParsePermissionsResponse
+	}
+	if len(decoded) > MaxDecodedContentSize {
+		return nil, ErrDecodedContentTooLarge
+	}
+
+	return decoded, nil
+}
+
+// getPermissionsBundleFromBytes parses either the JSON or normalised encoding of the bundle, depending on the content type
+func getPermissionsBundleFromBytes(b []byte, contentType string) (Bundle, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != models.BundleMediaTypeNormalised {
+		var bundle Bundle
+		if err := json.Unmarshal(b, &bundle); err != nil {
+			return nil, ErrFailedToParsePermissionsResponse
+		}
+		return bundle, nil
+	}
+
+	var normalised normalisedBundle
+	if err := json.Unmarshal(b, &normalised); err != nil {
+		return nil, ErrFailedToParsePermissionsResponse
+	}
+
+	bundle := make(Bundle, len(normalised.Permissions))
+	for permission, entityPolicyIDs := range normalised.Permissions {
+		entityLookup := make(EntityIDToPolicies, len(entityPolicyIDs))
+
+		for entity, policyIDs := range entityPolicyIDs {
+			policies := make([]Policy, 0, len(policyIDs))
+			for _, policyID := range policyIDs {
+				policy, ok := normalised.Policies[policyID]
+				if !ok {
+					return nil, ErrFailedToParsePermissionsResponse
+				}
+				policies = append(policies, policy)
+			}
+			entityLookup[entity] = policies
+		}
+
+		bundle[permission] = entityLookup
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,153 @@
+package sdk_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/andybalholm/brotli"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const exampleNormalisedBundleJSON = `{"policies":{"policy/123":{"id":"policy/123","condition":{"attribute":"collection_id","operator":"StringEquals","values":["col123"]}}},"permissions":{"permission/admin":{"group/admin":["policy/123"]}}}`
+
+func newEncodedBundleClienter(body []byte, contentType, contentEncoding string, request **http.Request) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			*request = req
+			headers := http.Header{}
+			headers.Set("Content-Type", contentType)
+			if contentEncoding != "" {
+				headers.Set("Content-Encoding", contentEncoding)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     headers,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func gzipBytes(b []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(b)
+	So(err, ShouldBeNil)
+	So(w.Close(), ShouldBeNil)
+	return buf.Bytes()
+}
+
+func brotliBytes(b []byte) []byte {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	_, err := w.Write(b)
+	So(err, ShouldBeNil)
+	So(w.Close(), ShouldBeNil)
+	return buf.Bytes()
+}
+
+func TestAPIClient_GetPermissionsBundle_Encodings(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a permissions API that returns a normalised bundle", t, func() {
+		var request *http.Request
+		body := []byte(exampleNormalisedBundleJSON)
+
+		Convey("When GetPermissionsBundle is called", func() {
+			apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(body, models.BundleMediaTypeNormalised, "", &request))
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the compressed and normalised encodings are requested", func() {
+				So(request.Header.Get("Accept"), ShouldStartWith, models.BundleMediaTypeNormalised)
+				So(request.Header.Get("Accept-Encoding"), ShouldEqual, "br, gzip")
+			})
+
+			Convey("Then the bundle is decoded", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, getExampleBundle())
+			})
+		})
+
+		Convey("When the bundle is gzip compressed", func() {
+			apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(gzipBytes(body), models.BundleMediaTypeNormalised, "gzip", &request))
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is decoded", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, getExampleBundle())
+			})
+		})
+
+		Convey("When the bundle is brotli compressed", func() {
+			apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(brotliBytes(body), models.BundleMediaTypeNormalised, "br", &request))
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is decoded", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, getExampleBundle())
+			})
+		})
+
+		Convey("When the bundle references an unknown policy", func() {
+			invalid := []byte(`{"policies":{},"permissions":{"permission/admin":{"group/admin":["policy/123"]}}}`)
+			apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(invalid, models.BundleMediaTypeNormalised, "", &request))
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, sdk.ErrFailedToParsePermissionsResponse)
+				So(bundle, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a permissions API that returns a gzip compressed JSON bundle", t, func() {
+		var request *http.Request
+		body := gzipBytes(getExampleBundleJSON())
+		apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(body, "application/json; charset=utf-8", "gzip", &request))
+
+		Convey("When GetPermissionsBundle is called", func() {
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is decoded", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, getExampleBundle())
+			})
+		})
+	})
+
+	Convey("Given a permissions API that returns a gzip compressed body larger than the maximum decoded size", t, func() {
+		var request *http.Request
+		body := gzipBytes(make([]byte, sdk.MaxDecodedContentSize+1))
+		apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(body, "application/json", "gzip", &request))
+
+		Convey("When GetPermissionsBundle is called", func() {
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, sdk.ErrDecodedContentTooLarge)
+				So(bundle, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a permissions API that returns an unsupported content encoding", t, func() {
+		var request *http.Request
+		apiClient := sdk.NewClientWithClienter(host, newEncodedBundleClienter(getExampleBundleJSON(), "application/json", "zstd", &request))
+
+		Convey("When GetPermissionsBundle is called", func() {
+			bundle, err := apiClient.GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, sdk.ErrUnsupportedContentEncoding)
+				So(bundle, ShouldBeNil)
+			})
+		})
+	})
+}
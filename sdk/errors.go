@@ -1,6 +1,15 @@
 package sdk
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+)
 
 var (
 
@@ -12,4 +21,132 @@ var (
 
 	// ErrNotCached error error used when permissions are not found in the cache.
 	ErrNotCached = errors.New("permissions bundle not found in the cache")
+
+	// ErrNoVerificationKeys error used when a verifying client is created without any verification keys.
+	ErrNoVerificationKeys = errors.New("at least one bundle verification key is required")
+
+	// ErrInvalidVerificationKey error used when a verification key is not a valid Ed25519 JSON web key.
+	ErrInvalidVerificationKey = errors.New("bundle verification key is not a valid Ed25519 JSON web key")
+
+	// ErrBundleSignatureMissing error used when a verifying client receives a permissions bundle without a signature.
+	ErrBundleSignatureMissing = errors.New("permissions bundle response is not signed")
+
+	// ErrBundleSignatureInvalid error used when the permissions bundle signature does not match any trusted key.
+	ErrBundleSignatureInvalid = errors.New("permissions bundle signature is invalid")
+
+	// ErrUnsupportedContentEncoding error used when the permissions API responds with a content encoding the client cannot decode.
+	ErrUnsupportedContentEncoding = errors.New("unsupported permissions bundle content encoding")
+
+	// ErrDecodedContentTooLarge error used when a compressed permissions bundle decompresses to more than MaxDecodedContentSize bytes.
+	ErrDecodedContentTooLarge = errors.New("decompressed permissions bundle is too large")
 )
+
+// Errors returned by the permissions API, which an APIError with the matching error code wraps, so that callers can
+// check for them with errors.Is
+var (
+	// ErrPolicyNotFound error used when the requested policy does not exist.
+	ErrPolicyNotFound = errors.New("policy not found")
+
+	// ErrPolicyAlreadyExists error used when a policy is created with the ID of an existing policy.
+	ErrPolicyAlreadyExists = errors.New("policy already exists")
+
+	// ErrRoleNotFound error used when the requested role, or the role of a policy, does not exist.
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrInvalidPolicy error used when a policy is rejected by the permissions API validation.
+	ErrInvalidPolicy = errors.New("invalid policy")
+
+	// ErrLastPolicyEntity error used when the last entity of a policy is removed.
+	ErrLastPolicyEntity = errors.New("the last entity of a policy cannot be removed")
+
+	// ErrPolicyDeleted error used when a deleted policy is changed before it is restored.
+	ErrPolicyDeleted = errors.New("policy is deleted")
+
+	// ErrPolicyNotDeleted error used when a policy that is not deleted is restored.
+	ErrPolicyNotDeleted = errors.New("policy is not deleted")
+
+	// ErrLockout error used when a change would leave no entity able to administer the policies.
+	ErrLockout = errors.New("the change would lock everyone out of policy administration")
+
+	// ErrProtectedPolicy error used when a protected policy is changed without override.
+	ErrProtectedPolicy = errors.New("policy is protected")
+)
+
+// errorCodes maps the error codes of the permissions API to the SDK errors that they represent
+var errorCodes = map[string]error{
+	models.PolicyNotFoundError:      ErrPolicyNotFound,
+	models.PolicyAlreadyExistsError: ErrPolicyAlreadyExists,
+	models.RoleNotFoundError:        ErrRoleNotFound,
+	models.InvalidPolicyError:       ErrInvalidPolicy,
+	models.LastPolicyEntityError:    ErrLastPolicyEntity,
+	models.PolicyDeletedError:       ErrPolicyDeleted,
+	models.PolicyNotDeletedError:    ErrPolicyNotDeleted,
+	models.LockoutError:             ErrLockout,
+	models.ProtectedPolicyError:     ErrProtectedPolicy,
+}
+
+// maxErrorBodySize limits how much of an error response body is read
+const maxErrorBodySize = 64 * 1024
+
+// APIError is returned when the permissions API responds with an unexpected status code. The code and description
+// are taken from the first error of the response body, if the body is a permissions API error response. Otherwise,
+// the description is the plain text body, if there is one.
+type APIError struct {
+	StatusCode  int
+	Status      string
+	Code        string
+	Description string
+	endpoint    string
+}
+
+// Error returns a string representation of the error. Implements error interface.
+func (e *APIError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("unexpected status returned from the permissions api")
+	if e.endpoint != "" {
+		sb.WriteString(" " + e.endpoint + " endpoint")
+	}
+	sb.WriteString(": " + e.Status)
+
+	switch {
+	case e.Code != "":
+		fmt.Fprintf(&sb, " (%s: %s)", e.Code, e.Description)
+	case e.Description != "":
+		fmt.Fprintf(&sb, " (%s)", e.Description)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the SDK error that the error code represents, if any, to support errors.Is
+func (e *APIError) Unwrap() error {
+	return errorCodes[e.Code]
+}
+
+// newAPIError creates an APIError from an unexpected response of the named permissions API endpoint
+func newAPIError(resp *http.Response, endpoint string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		endpoint:   endpoint,
+	}
+	if resp.Body == nil {
+		return apiErr
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil || len(b) == 0 {
+		return apiErr
+	}
+
+	var errorResponse struct {
+		Errors []models.Error `json:"errors"`
+	}
+	if err := json.Unmarshal(b, &errorResponse); err == nil && len(errorResponse.Errors) > 0 {
+		apiErr.Code = errorResponse.Errors[0].Code
+		apiErr.Description = errorResponse.Errors[0].Description
+		return apiErr
+	}
+
+	apiErr.Description = strings.TrimSpace(string(b))
+	return apiErr
+}
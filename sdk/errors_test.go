@@ -0,0 +1,111 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newErrorResponseClient(statusCode int, body string) *sdk.APIClient {
+	httpClient := &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: statusCode,
+				Status:     http.StatusText(statusCode),
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+	return sdk.NewClientWithClienter(host, httpClient)
+}
+
+func TestAPIClient_TypedErrors(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the permissions API responds that a policy is not found", t, func() {
+		apiClient := newErrorResponseClient(http.StatusNotFound, `{"errors":[{"code":"PolicyNotFoundError","description":"policy not found"}]}`)
+
+		Convey("When GetPolicy is called", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the error is a policy not found error", func() {
+				So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeTrue)
+				So(errors.Is(err, sdk.ErrRoleNotFound), ShouldBeFalse)
+			})
+
+			Convey("Then the error carries the status, code and description of the response", func() {
+				var apiErr *sdk.APIError
+				So(errors.As(err, &apiErr), ShouldBeTrue)
+				So(apiErr.StatusCode, ShouldEqual, http.StatusNotFound)
+				So(apiErr.Code, ShouldEqual, models.PolicyNotFoundError)
+				So(apiErr.Description, ShouldEqual, models.PolicyNotFoundDescription)
+				So(err.Error(), ShouldEqual, "unexpected status returned from the permissions api permissions-getpolicy endpoint: Not Found (PolicyNotFoundError: policy not found)")
+			})
+		})
+	})
+
+	Convey("Given the permissions API responds that a policy already exists", t, func() {
+		apiClient := newErrorResponseClient(http.StatusConflict, `{"errors":[{"code":"PolicyAlreadyExistsError","description":"policy already exists with given ID"}]}`)
+
+		Convey("When PostPolicyWithID is called, the error is a policy already exists error", func() {
+			_, err := apiClient.PostPolicyWithID(ctx, "policy1", models.PolicyInfo{}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyAlreadyExists), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given the permissions API rejects a policy as invalid", t, func() {
+		apiClient := newErrorResponseClient(http.StatusBadRequest, `{"errors":[{"code":"InvalidPolicyError","description":"missing mandatory fields: role"}]}`)
+
+		Convey("When PostPolicy is called, the error is an invalid policy error with the validation failure as its description", func() {
+			_, err := apiClient.PostPolicy(ctx, models.PolicyInfo{}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrInvalidPolicy), ShouldBeTrue)
+
+			var apiErr *sdk.APIError
+			So(errors.As(err, &apiErr), ShouldBeTrue)
+			So(apiErr.Description, ShouldEqual, "missing mandatory fields: role")
+		})
+	})
+
+	Convey("Given the permissions API responds that a policy is deleted", t, func() {
+		apiClient := newErrorResponseClient(http.StatusConflict, `{"errors":[{"code":"PolicyDeletedError","description":"policy with given ID is deleted, restore it before changing it"}]}`)
+
+		Convey("When PutPolicy is called, the error is a policy deleted error", func() {
+			_, err := apiClient.PutPolicy(ctx, "policy1", models.Policy{}, sdk.Headers{})
+			So(errors.Is(err, sdk.ErrPolicyDeleted), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given the permissions API responds that a role is not found", t, func() {
+		apiClient := newErrorResponseClient(http.StatusNotFound, `{"errors":[{"code":"RoleNotFoundError","description":"role not found"}]}`)
+
+		Convey("When GetRole is called, the error is a role not found error", func() {
+			_, err := apiClient.GetRole(ctx, "admin", sdk.Headers{})
+			So(errors.Is(err, sdk.ErrRoleNotFound), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given the permissions API responds with a plain text internal server error", t, func() {
+		apiClient := newErrorResponseClient(http.StatusInternalServerError, "internal server error\n")
+
+		Convey("When DeletePolicy is called", func() {
+			err := apiClient.DeletePolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the error carries the status and the body as its description, and is not one of the SDK errors", func() {
+				var apiErr *sdk.APIError
+				So(errors.As(err, &apiErr), ShouldBeTrue)
+				So(apiErr.StatusCode, ShouldEqual, http.StatusInternalServerError)
+				So(apiErr.Code, ShouldBeEmpty)
+				So(apiErr.Description, ShouldEqual, "internal server error")
+				So(errors.Is(err, sdk.ErrPolicyNotFound), ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package sdk
+
+import "strings"
+
+// Decision is the outcome of evaluating whether an entity has a permission
+type Decision struct {
+	// Allowed is true if a policy grants the permission
+	Allowed bool
+	// Entity is the user or group entity that the matching policy applies to, e.g. groups/admin
+	Entity string
+	// Policy is the policy that grants the permission, or nil if the permission is denied
+	Policy *Policy
+}
+
+// Evaluator decides whether users have permissions, using a permissions bundle
+type Evaluator struct {
+	bundle Bundle
+}
+
+// NewEvaluator creates an Evaluator for the given permissions bundle
+func NewEvaluator(bundle Bundle) *Evaluator {
+	return &Evaluator{bundle: bundle}
+}
+
+// Evaluate decides whether the user, or one of their groups, has the permission given the attributes of the request.
+// The permission is allowed by the first policy of the user's entities, the user first followed by their groups, whose
+// condition is met:
+//
+//   - a policy without a condition attribute always applies
+//   - a condition is not met if the attribute is not in the attributes map
+//   - StringEquals is met if the attribute value is equal to one of the condition values
+//   - StartsWith is met if the attribute value starts with one of the condition values
+//   - any other operator is never met
+//
+// Values are compared case-sensitively. The permission is denied if no policy applies.
+func (e *Evaluator) Evaluate(entityData EntityData, permission string, attributes map[string]string) Decision {
+	entityLookup, ok := e.bundle[permission]
+	if !ok {
+		return Decision{}
+	}
+
+	for _, entity := range entityData.Entities() {
+		policies := entityLookup[entity]
+		for i := range policies {
+			if policies[i].Condition.IsMet(attributes) {
+				return Decision{Allowed: true, Entity: entity, Policy: &policies[i]}
+			}
+		}
+	}
+
+	return Decision{}
+}
+
+// IsMet returns true if the condition is met by the attributes. A condition without an attribute is always met.
+func (condition Condition) IsMet(attributes map[string]string) bool {
+	if condition.Attribute == "" {
+		return true
+	}
+
+	value, ok := attributes[condition.Attribute]
+	if !ok {
+		return false
+	}
+
+	for _, conditionValue := range condition.Values {
+		switch condition.Operator {
+		case OperatorStringEquals:
+			if value == conditionValue {
+				return true
+			}
+		case OperatorStartsWith:
+			if strings.HasPrefix(value, conditionValue) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+package sdk_test
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	bundle := sdk.Bundle{
+		"datasets:read": {
+			"groups/viewers": {{ID: "viewers-read"}},
+		},
+		"datasets:edit": {
+			"users/editor@ons.gov.uk": {{
+				ID:        "editor-edit-cpih",
+				Condition: sdk.Condition{Attribute: "dataset_id", Operator: sdk.OperatorStringEquals, Values: []string{"cpih01", "cpih02"}},
+			}},
+			"groups/publishers": {{
+				ID:        "publishers-edit-collection",
+				Condition: sdk.Condition{Attribute: "collection_id", Operator: sdk.OperatorStartsWith, Values: []string{"col-", "release-"}},
+			}},
+			"groups/admins": {{ID: "admins-edit"}},
+		},
+		"datasets:delete": {
+			"groups/publishers": {
+				{ID: "publishers-delete-unknown", Condition: sdk.Condition{Attribute: "dataset_id", Operator: "Contains", Values: []string{"cpih"}}},
+				{ID: "publishers-delete-empty", Condition: sdk.Condition{Attribute: "dataset_id", Operator: sdk.OperatorStringEquals}},
+				{ID: "publishers-delete-cpih", Condition: sdk.Condition{Attribute: "dataset_id", Operator: sdk.OperatorStringEquals, Values: []string{"cpih01"}}},
+			},
+		},
+	}
+	evaluator := sdk.NewEvaluator(bundle)
+
+	editor := sdk.EntityData{UserID: "editor@ons.gov.uk"}
+	publisher := sdk.EntityData{UserID: "publisher@ons.gov.uk", Groups: []string{"viewers", "publishers"}}
+	admin := sdk.EntityData{UserID: "editor@ons.gov.uk", Groups: []string{"admins"}}
+
+	tests := []struct {
+		name           string
+		entityData     sdk.EntityData
+		permission     string
+		attributes     map[string]string
+		expectedPolicy string
+		expectedEntity string
+	}{
+		{name: "a permission that is not in the bundle is denied", entityData: admin, permission: "datasets:create"},
+		{name: "a user without any policy for the permission is denied", entityData: editor, permission: "datasets:read"},
+		{name: "entity data without a user or groups is denied", entityData: sdk.EntityData{}, permission: "datasets:read"},
+		{name: "an empty group is ignored", entityData: sdk.EntityData{Groups: []string{""}}, permission: "datasets:read"},
+		{name: "a group policy without a condition is allowed", entityData: publisher, permission: "datasets:read",
+			expectedPolicy: "viewers-read", expectedEntity: "groups/viewers"},
+		{name: "a policy without a condition is allowed whatever the attributes", entityData: admin, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "other"}, expectedPolicy: "admins-edit", expectedEntity: "groups/admins"},
+		{name: "a user policy is evaluated before the group policies", entityData: admin, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "cpih01"}, expectedPolicy: "editor-edit-cpih", expectedEntity: "users/editor@ons.gov.uk"},
+		{name: "StringEquals is met by any of the condition values", entityData: editor, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "cpih02"}, expectedPolicy: "editor-edit-cpih", expectedEntity: "users/editor@ons.gov.uk"},
+		{name: "StringEquals is not met by a different value", entityData: editor, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "cpih03"}},
+		{name: "StringEquals is not met by a prefix of a value", entityData: editor, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "cpih"}},
+		{name: "StringEquals is case sensitive", entityData: editor, permission: "datasets:edit",
+			attributes: map[string]string{"dataset_id": "CPIH01"}},
+		{name: "a condition is not met if the attribute is missing", entityData: editor, permission: "datasets:edit",
+			attributes: map[string]string{"collection_id": "cpih01"}},
+		{name: "a condition is not met if there are no attributes", entityData: editor, permission: "datasets:edit"},
+		{name: "StartsWith is met by a value starting with one of the condition values", entityData: publisher, permission: "datasets:edit",
+			attributes: map[string]string{"collection_id": "release-2024"}, expectedPolicy: "publishers-edit-collection", expectedEntity: "groups/publishers"},
+		{name: "StartsWith is met by a value equal to a condition value", entityData: publisher, permission: "datasets:edit",
+			attributes: map[string]string{"collection_id": "col-"}, expectedPolicy: "publishers-edit-collection", expectedEntity: "groups/publishers"},
+		{name: "StartsWith is not met by a value containing a condition value", entityData: publisher, permission: "datasets:edit",
+			attributes: map[string]string{"collection_id": "my-col-1"}},
+		{name: "StartsWith is case sensitive", entityData: publisher, permission: "datasets:edit",
+			attributes: map[string]string{"collection_id": "COL-1"}},
+		{name: "an unknown operator and a condition without values are never met, but a later policy can be", entityData: publisher,
+			permission: "datasets:delete", attributes: map[string]string{"dataset_id": "cpih01"},
+			expectedPolicy: "publishers-delete-cpih", expectedEntity: "groups/publishers"},
+		{name: "an unknown operator is not met even by an equal value", entityData: publisher, permission: "datasets:delete",
+			attributes: map[string]string{"dataset_id": "cpih"}},
+	}
+
+	Convey("Given an evaluator for a permissions bundle", t, func() {
+		for _, tc := range tests {
+			Convey("Then "+tc.name, func() {
+				decision := evaluator.Evaluate(tc.entityData, tc.permission, tc.attributes)
+
+				if tc.expectedPolicy == "" {
+					So(decision, ShouldResemble, sdk.Decision{})
+					return
+				}
+				So(decision.Allowed, ShouldBeTrue)
+				So(decision.Entity, ShouldEqual, tc.expectedEntity)
+				So(decision.Policy, ShouldNotBeNil)
+				So(decision.Policy.ID, ShouldEqual, tc.expectedPolicy)
+			})
+		}
+	})
+}
+
+func TestEntityData_Entities(t *testing.T) {
+	Convey("Given entity data with a user and groups", t, func() {
+		entityData := sdk.EntityData{UserID: "user1", Groups: []string{"group1", "", "group2"}}
+
+		Convey("Then the entities are the user followed by the non-empty groups", func() {
+			So(entityData.Entities(), ShouldResemble, []string{"users/user1", "groups/group1", "groups/group2"})
+		})
+	})
+}
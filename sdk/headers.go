@@ -2,15 +2,40 @@ package sdk
 
 import (
 	"net/http"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
 )
 
+// Headers are the optional headers that are sent with a request to the permissions API
 type Headers struct {
+	// Authorization is the bearer token of the user, without the "Bearer " prefix
 	Authorization string
+	// ServiceAuthToken is the bearer token of the calling service, without the "Bearer " prefix. It is only sent if
+	// there is no user Authorization, as both are sent in the Authorization header.
+	ServiceAuthToken string
+	// FlorenceToken is the access token of the Florence user the request is made on behalf of
+	FlorenceToken string
+	// CollectionID is the ID of the collection the request is made for
+	CollectionID string
+	// RequestID is the ID used to correlate the request with the request that caused it
+	RequestID string
 }
 
 // Add adds any provided headers to the request
 func (h *Headers) Add(req *http.Request) {
-	if h.Authorization != "" {
+	switch {
+	case h.Authorization != "":
 		req.Header.Set(Authorization, BearerPrefix+h.Authorization)
+	case h.ServiceAuthToken != "":
+		req.Header.Set(Authorization, BearerPrefix+h.ServiceAuthToken)
+	}
+	if h.FlorenceToken != "" {
+		req.Header.Set(dprequest.FlorenceHeaderKey, h.FlorenceToken)
+	}
+	if h.CollectionID != "" {
+		req.Header.Set(dprequest.CollectionIDHeaderKey, h.CollectionID)
+	}
+	if h.RequestID != "" {
+		req.Header.Set(dprequest.RequestHeaderKey, h.RequestID)
 	}
 }
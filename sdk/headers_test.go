@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -40,4 +41,49 @@ func TestHeaders_Add(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given Headers with a ServiceAuthToken", t, func() {
+		headers := &Headers{
+			ServiceAuthToken: "test-service-token",
+		}
+
+		Convey("When Add is called", func() {
+			req := &http.Request{Header: http.Header{}}
+			headers.Add(req)
+
+			Convey("Then the service token is set as the Authorization header", func() {
+				So(req.Header.Get(Authorization), ShouldEqual, BearerPrefix+headers.ServiceAuthToken)
+			})
+		})
+
+		Convey("And an Authorization token, when Add is called", func() {
+			headers.Authorization = "test-auth-token"
+			req := &http.Request{Header: http.Header{}}
+			headers.Add(req)
+
+			Convey("Then the user token is set as the Authorization header", func() {
+				So(req.Header.Values(Authorization), ShouldResemble, []string{BearerPrefix + headers.Authorization})
+			})
+		})
+	})
+
+	Convey("Given Headers with Florence, collection and request IDs", t, func() {
+		headers := &Headers{
+			FlorenceToken: "test-florence-token",
+			CollectionID:  "col-123",
+			RequestID:     "req-456",
+		}
+
+		Convey("When Add is called", func() {
+			req := &http.Request{Header: http.Header{}}
+			headers.Add(req)
+
+			Convey("Then they are forwarded in their headers", func() {
+				So(req.Header.Get(dprequest.FlorenceHeaderKey), ShouldEqual, "test-florence-token")
+				So(req.Header.Get(dprequest.CollectionIDHeaderKey), ShouldEqual, "col-123")
+				So(req.Header.Get(dprequest.RequestHeaderKey), ShouldEqual, "req-456")
+				So(req.Header.Get(Authorization), ShouldBeEmpty)
+			})
+		})
+	})
 }
@@ -9,12 +9,18 @@ import (
 //go:generate moq -out ./mocks/client.go -pkg mocks . Clienter
 
 type Clienter interface {
-	GetRoles(ctx context.Context, headers Headers) (*models.Roles, error)
-	GetRole(ctx context.Context, id string, headers Headers) (*models.Roles, error)
+	GetRoles(ctx context.Context, headers Headers, opts ...ListOption) (*models.Roles, error)
+	ListAllRoles(ctx context.Context, headers Headers, pageSize int) ([]models.Role, error)
+	GetRole(ctx context.Context, id string, headers Headers) (*models.Role, error)
 	PostPolicy(ctx context.Context, policy models.PolicyInfo, headers Headers) (*models.Policy, error)
 	PostPolicyWithID(ctx context.Context, id string, policy models.PolicyInfo, headers Headers) (*models.Policy, error)
 	DeletePolicy(ctx context.Context, id string, headers Headers) error
+	RestorePolicy(ctx context.Context, id string, headers Headers) error
 	GetPolicy(ctx context.Context, id string, headers Headers) (*models.Policy, error)
-	PutPolicy(ctx context.Context, id string, policy models.Policy, headers Headers) error
+	PutPolicy(ctx context.Context, id string, policy models.Policy, headers Headers) (created bool, err error)
+	UpdatePolicy(ctx context.Context, id string, policy models.Policy, headers Headers) error
+	AddPolicyEntity(ctx context.Context, policyID, entity string, headers Headers) (added bool, err error)
+	RemovePolicyEntity(ctx context.Context, policyID, entity string, headers Headers) error
 	GetPermissionsBundle(ctx context.Context, headers Headers) (Bundle, error)
+	GetBundleVerificationKeys(ctx context.Context, headers Headers) (*models.JSONWebKeySet, error)
 }
@@ -20,30 +20,48 @@ var _ sdk.Clienter = &ClienterMock{}
 //
 //		// make and configure a mocked sdk.Clienter
 //		mockedClienter := &ClienterMock{
+//			AddPolicyEntityFunc: func(ctx context.Context, policyID string, entity string, headers sdk.Headers) (bool, error) {
+//				panic("mock out the AddPolicyEntity method")
+//			},
 //			DeletePolicyFunc: func(ctx context.Context, id string, headers sdk.Headers) error {
 //				panic("mock out the DeletePolicy method")
 //			},
+//			GetBundleVerificationKeysFunc: func(ctx context.Context, headers sdk.Headers) (*models.JSONWebKeySet, error) {
+//				panic("mock out the GetBundleVerificationKeys method")
+//			},
 //			GetPermissionsBundleFunc: func(ctx context.Context, headers sdk.Headers) (sdk.Bundle, error) {
 //				panic("mock out the GetPermissionsBundle method")
 //			},
 //			GetPolicyFunc: func(ctx context.Context, id string, headers sdk.Headers) (*models.Policy, error) {
 //				panic("mock out the GetPolicy method")
 //			},
-//			GetRoleFunc: func(ctx context.Context, id string, headers sdk.Headers) (*models.Roles, error) {
+//			GetRoleFunc: func(ctx context.Context, id string, headers sdk.Headers) (*models.Role, error) {
 //				panic("mock out the GetRole method")
 //			},
-//			GetRolesFunc: func(ctx context.Context, headers sdk.Headers) (*models.Roles, error) {
+//			GetRolesFunc: func(ctx context.Context, headers sdk.Headers, opts ...sdk.ListOption) (*models.Roles, error) {
 //				panic("mock out the GetRoles method")
 //			},
+//			ListAllRolesFunc: func(ctx context.Context, headers sdk.Headers, pageSize int) ([]models.Role, error) {
+//				panic("mock out the ListAllRoles method")
+//			},
 //			PostPolicyFunc: func(ctx context.Context, policy models.PolicyInfo, headers sdk.Headers) (*models.Policy, error) {
 //				panic("mock out the PostPolicy method")
 //			},
 //			PostPolicyWithIDFunc: func(ctx context.Context, id string, policy models.PolicyInfo, headers sdk.Headers) (*models.Policy, error) {
 //				panic("mock out the PostPolicyWithID method")
 //			},
-//			PutPolicyFunc: func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error {
+//			PutPolicyFunc: func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) (bool, error) {
 //				panic("mock out the PutPolicy method")
 //			},
+//			RemovePolicyEntityFunc: func(ctx context.Context, policyID string, entity string, headers sdk.Headers) error {
+//				panic("mock out the RemovePolicyEntity method")
+//			},
+//			RestorePolicyFunc: func(ctx context.Context, id string, headers sdk.Headers) error {
+//				panic("mock out the RestorePolicy method")
+//			},
+//			UpdatePolicyFunc: func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error {
+//				panic("mock out the UpdatePolicy method")
+//			},
 //		}
 //
 //		// use mockedClienter in code that requires sdk.Clienter
@@ -51,9 +69,15 @@ var _ sdk.Clienter = &ClienterMock{}
 //
 //	}
 type ClienterMock struct {
+	// AddPolicyEntityFunc mocks the AddPolicyEntity method.
+	AddPolicyEntityFunc func(ctx context.Context, policyID string, entity string, headers sdk.Headers) (bool, error)
+
 	// DeletePolicyFunc mocks the DeletePolicy method.
 	DeletePolicyFunc func(ctx context.Context, id string, headers sdk.Headers) error
 
+	// GetBundleVerificationKeysFunc mocks the GetBundleVerificationKeys method.
+	GetBundleVerificationKeysFunc func(ctx context.Context, headers sdk.Headers) (*models.JSONWebKeySet, error)
+
 	// GetPermissionsBundleFunc mocks the GetPermissionsBundle method.
 	GetPermissionsBundleFunc func(ctx context.Context, headers sdk.Headers) (sdk.Bundle, error)
 
@@ -61,10 +85,13 @@ type ClienterMock struct {
 	GetPolicyFunc func(ctx context.Context, id string, headers sdk.Headers) (*models.Policy, error)
 
 	// GetRoleFunc mocks the GetRole method.
-	GetRoleFunc func(ctx context.Context, id string, headers sdk.Headers) (*models.Roles, error)
+	GetRoleFunc func(ctx context.Context, id string, headers sdk.Headers) (*models.Role, error)
 
 	// GetRolesFunc mocks the GetRoles method.
-	GetRolesFunc func(ctx context.Context, headers sdk.Headers) (*models.Roles, error)
+	GetRolesFunc func(ctx context.Context, headers sdk.Headers, opts ...sdk.ListOption) (*models.Roles, error)
+
+	// ListAllRolesFunc mocks the ListAllRoles method.
+	ListAllRolesFunc func(ctx context.Context, headers sdk.Headers, pageSize int) ([]models.Role, error)
 
 	// PostPolicyFunc mocks the PostPolicy method.
 	PostPolicyFunc func(ctx context.Context, policy models.PolicyInfo, headers sdk.Headers) (*models.Policy, error)
@@ -73,10 +100,30 @@ type ClienterMock struct {
 	PostPolicyWithIDFunc func(ctx context.Context, id string, policy models.PolicyInfo, headers sdk.Headers) (*models.Policy, error)
 
 	// PutPolicyFunc mocks the PutPolicy method.
-	PutPolicyFunc func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error
+	PutPolicyFunc func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) (bool, error)
+
+	// RemovePolicyEntityFunc mocks the RemovePolicyEntity method.
+	RemovePolicyEntityFunc func(ctx context.Context, policyID string, entity string, headers sdk.Headers) error
+
+	// RestorePolicyFunc mocks the RestorePolicy method.
+	RestorePolicyFunc func(ctx context.Context, id string, headers sdk.Headers) error
+
+	// UpdatePolicyFunc mocks the UpdatePolicy method.
+	UpdatePolicyFunc func(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddPolicyEntity holds details about calls to the AddPolicyEntity method.
+		AddPolicyEntity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PolicyID is the policyID argument value.
+			PolicyID string
+			// Entity is the entity argument value.
+			Entity string
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+		}
 		// DeletePolicy holds details about calls to the DeletePolicy method.
 		DeletePolicy []struct {
 			// Ctx is the ctx argument value.
@@ -86,6 +133,13 @@ type ClienterMock struct {
 			// Headers is the headers argument value.
 			Headers sdk.Headers
 		}
+		// GetBundleVerificationKeys holds details about calls to the GetBundleVerificationKeys method.
+		GetBundleVerificationKeys []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+		}
 		// GetPermissionsBundle holds details about calls to the GetPermissionsBundle method.
 		GetPermissionsBundle []struct {
 			// Ctx is the ctx argument value.
@@ -117,6 +171,17 @@ type ClienterMock struct {
 			Ctx context.Context
 			// Headers is the headers argument value.
 			Headers sdk.Headers
+			// Opts is the opts argument value.
+			Opts []sdk.ListOption
+		}
+		// ListAllRoles holds details about calls to the ListAllRoles method.
+		ListAllRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+			// PageSize is the pageSize argument value.
+			PageSize int
 		}
 		// PostPolicy holds details about calls to the PostPolicy method.
 		PostPolicy []struct {
@@ -149,15 +214,96 @@ type ClienterMock struct {
 			// Headers is the headers argument value.
 			Headers sdk.Headers
 		}
+		// RemovePolicyEntity holds details about calls to the RemovePolicyEntity method.
+		RemovePolicyEntity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PolicyID is the policyID argument value.
+			PolicyID string
+			// Entity is the entity argument value.
+			Entity string
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+		}
+		// RestorePolicy holds details about calls to the RestorePolicy method.
+		RestorePolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+		}
+		// UpdatePolicy holds details about calls to the UpdatePolicy method.
+		UpdatePolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Policy is the policy argument value.
+			Policy models.Policy
+			// Headers is the headers argument value.
+			Headers sdk.Headers
+		}
 	}
-	lockDeletePolicy         sync.RWMutex
-	lockGetPermissionsBundle sync.RWMutex
-	lockGetPolicy            sync.RWMutex
-	lockGetRole              sync.RWMutex
-	lockGetRoles             sync.RWMutex
-	lockPostPolicy           sync.RWMutex
-	lockPostPolicyWithID     sync.RWMutex
-	lockPutPolicy            sync.RWMutex
+	lockAddPolicyEntity           sync.RWMutex
+	lockDeletePolicy              sync.RWMutex
+	lockGetBundleVerificationKeys sync.RWMutex
+	lockGetPermissionsBundle      sync.RWMutex
+	lockGetPolicy                 sync.RWMutex
+	lockGetRole                   sync.RWMutex
+	lockGetRoles                  sync.RWMutex
+	lockListAllRoles              sync.RWMutex
+	lockPostPolicy                sync.RWMutex
+	lockPostPolicyWithID          sync.RWMutex
+	lockPutPolicy                 sync.RWMutex
+	lockRemovePolicyEntity        sync.RWMutex
+	lockRestorePolicy             sync.RWMutex
+	lockUpdatePolicy              sync.RWMutex
+}
+
+// AddPolicyEntity calls AddPolicyEntityFunc.
+func (mock *ClienterMock) AddPolicyEntity(ctx context.Context, policyID string, entity string, headers sdk.Headers) (bool, error) {
+	if mock.AddPolicyEntityFunc == nil {
+		panic("ClienterMock.AddPolicyEntityFunc: method is nil but Clienter.AddPolicyEntity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		PolicyID string
+		Entity   string
+		Headers  sdk.Headers
+	}{
+		Ctx:      ctx,
+		PolicyID: policyID,
+		Entity:   entity,
+		Headers:  headers,
+	}
+	mock.lockAddPolicyEntity.Lock()
+	mock.calls.AddPolicyEntity = append(mock.calls.AddPolicyEntity, callInfo)
+	mock.lockAddPolicyEntity.Unlock()
+	return mock.AddPolicyEntityFunc(ctx, policyID, entity, headers)
+}
+
+// AddPolicyEntityCalls gets all the calls that were made to AddPolicyEntity.
+// Check the length with:
+//
+//	len(mockedClienter.AddPolicyEntityCalls())
+func (mock *ClienterMock) AddPolicyEntityCalls() []struct {
+	Ctx      context.Context
+	PolicyID string
+	Entity   string
+	Headers  sdk.Headers
+} {
+	var calls []struct {
+		Ctx      context.Context
+		PolicyID string
+		Entity   string
+		Headers  sdk.Headers
+	}
+	mock.lockAddPolicyEntity.RLock()
+	calls = mock.calls.AddPolicyEntity
+	mock.lockAddPolicyEntity.RUnlock()
+	return calls
 }
 
 // DeletePolicy calls DeletePolicyFunc.
@@ -200,6 +346,42 @@ func (mock *ClienterMock) DeletePolicyCalls() []struct {
 	return calls
 }
 
+// GetBundleVerificationKeys calls GetBundleVerificationKeysFunc.
+func (mock *ClienterMock) GetBundleVerificationKeys(ctx context.Context, headers sdk.Headers) (*models.JSONWebKeySet, error) {
+	if mock.GetBundleVerificationKeysFunc == nil {
+		panic("ClienterMock.GetBundleVerificationKeysFunc: method is nil but Clienter.GetBundleVerificationKeys was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Headers sdk.Headers
+	}{
+		Ctx:     ctx,
+		Headers: headers,
+	}
+	mock.lockGetBundleVerificationKeys.Lock()
+	mock.calls.GetBundleVerificationKeys = append(mock.calls.GetBundleVerificationKeys, callInfo)
+	mock.lockGetBundleVerificationKeys.Unlock()
+	return mock.GetBundleVerificationKeysFunc(ctx, headers)
+}
+
+// GetBundleVerificationKeysCalls gets all the calls that were made to GetBundleVerificationKeys.
+// Check the length with:
+//
+//	len(mockedClienter.GetBundleVerificationKeysCalls())
+func (mock *ClienterMock) GetBundleVerificationKeysCalls() []struct {
+	Ctx     context.Context
+	Headers sdk.Headers
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Headers sdk.Headers
+	}
+	mock.lockGetBundleVerificationKeys.RLock()
+	calls = mock.calls.GetBundleVerificationKeys
+	mock.lockGetBundleVerificationKeys.RUnlock()
+	return calls
+}
+
 // GetPermissionsBundle calls GetPermissionsBundleFunc.
 func (mock *ClienterMock) GetPermissionsBundle(ctx context.Context, headers sdk.Headers) (sdk.Bundle, error) {
 	if mock.GetPermissionsBundleFunc == nil {
@@ -277,7 +459,7 @@ func (mock *ClienterMock) GetPolicyCalls() []struct {
 }
 
 // GetRole calls GetRoleFunc.
-func (mock *ClienterMock) GetRole(ctx context.Context, id string, headers sdk.Headers) (*models.Roles, error) {
+func (mock *ClienterMock) GetRole(ctx context.Context, id string, headers sdk.Headers) (*models.Role, error) {
 	if mock.GetRoleFunc == nil {
 		panic("ClienterMock.GetRoleFunc: method is nil but Clienter.GetRole was just called")
 	}
@@ -317,21 +499,23 @@ func (mock *ClienterMock) GetRoleCalls() []struct {
 }
 
 // GetRoles calls GetRolesFunc.
-func (mock *ClienterMock) GetRoles(ctx context.Context, headers sdk.Headers) (*models.Roles, error) {
+func (mock *ClienterMock) GetRoles(ctx context.Context, headers sdk.Headers, opts ...sdk.ListOption) (*models.Roles, error) {
 	if mock.GetRolesFunc == nil {
 		panic("ClienterMock.GetRolesFunc: method is nil but Clienter.GetRoles was just called")
 	}
 	callInfo := struct {
 		Ctx     context.Context
 		Headers sdk.Headers
+		Opts    []sdk.ListOption
 	}{
 		Ctx:     ctx,
 		Headers: headers,
+		Opts:    opts,
 	}
 	mock.lockGetRoles.Lock()
 	mock.calls.GetRoles = append(mock.calls.GetRoles, callInfo)
 	mock.lockGetRoles.Unlock()
-	return mock.GetRolesFunc(ctx, headers)
+	return mock.GetRolesFunc(ctx, headers, opts...)
 }
 
 // GetRolesCalls gets all the calls that were made to GetRoles.
@@ -341,10 +525,12 @@ func (mock *ClienterMock) GetRoles(ctx context.Context, headers sdk.Headers) (*m
 func (mock *ClienterMock) GetRolesCalls() []struct {
 	Ctx     context.Context
 	Headers sdk.Headers
+	Opts    []sdk.ListOption
 } {
 	var calls []struct {
 		Ctx     context.Context
 		Headers sdk.Headers
+		Opts    []sdk.ListOption
 	}
 	mock.lockGetRoles.RLock()
 	calls = mock.calls.GetRoles
@@ -352,6 +538,46 @@ func (mock *ClienterMock) GetRolesCalls() []struct {
 	return calls
 }
 
+// ListAllRoles calls ListAllRolesFunc.
+func (mock *ClienterMock) ListAllRoles(ctx context.Context, headers sdk.Headers, pageSize int) ([]models.Role, error) {
+	if mock.ListAllRolesFunc == nil {
+		panic("ClienterMock.ListAllRolesFunc: method is nil but Clienter.ListAllRoles was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Headers  sdk.Headers
+		PageSize int
+	}{
+		Ctx:      ctx,
+		Headers:  headers,
+		PageSize: pageSize,
+	}
+	mock.lockListAllRoles.Lock()
+	mock.calls.ListAllRoles = append(mock.calls.ListAllRoles, callInfo)
+	mock.lockListAllRoles.Unlock()
+	return mock.ListAllRolesFunc(ctx, headers, pageSize)
+}
+
+// ListAllRolesCalls gets all the calls that were made to ListAllRoles.
+// Check the length with:
+//
+//	len(mockedClienter.ListAllRolesCalls())
+func (mock *ClienterMock) ListAllRolesCalls() []struct {
+	Ctx      context.Context
+	Headers  sdk.Headers
+	PageSize int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Headers  sdk.Headers
+		PageSize int
+	}
+	mock.lockListAllRoles.RLock()
+	calls = mock.calls.ListAllRoles
+	mock.lockListAllRoles.RUnlock()
+	return calls
+}
+
 // PostPolicy calls PostPolicyFunc.
 func (mock *ClienterMock) PostPolicy(ctx context.Context, policy models.PolicyInfo, headers sdk.Headers) (*models.Policy, error) {
 	if mock.PostPolicyFunc == nil {
@@ -437,7 +663,7 @@ func (mock *ClienterMock) PostPolicyWithIDCalls() []struct {
 }
 
 // PutPolicy calls PutPolicyFunc.
-func (mock *ClienterMock) PutPolicy(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error {
+func (mock *ClienterMock) PutPolicy(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) (bool, error) {
 	if mock.PutPolicyFunc == nil {
 		panic("ClienterMock.PutPolicyFunc: method is nil but Clienter.PutPolicy was just called")
 	}
@@ -479,3 +705,131 @@ func (mock *ClienterMock) PutPolicyCalls() []struct {
 	mock.lockPutPolicy.RUnlock()
 	return calls
 }
+
+// RemovePolicyEntity calls RemovePolicyEntityFunc.
+func (mock *ClienterMock) RemovePolicyEntity(ctx context.Context, policyID string, entity string, headers sdk.Headers) error {
+	if mock.RemovePolicyEntityFunc == nil {
+		panic("ClienterMock.RemovePolicyEntityFunc: method is nil but Clienter.RemovePolicyEntity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		PolicyID string
+		Entity   string
+		Headers  sdk.Headers
+	}{
+		Ctx:      ctx,
+		PolicyID: policyID,
+		Entity:   entity,
+		Headers:  headers,
+	}
+	mock.lockRemovePolicyEntity.Lock()
+	mock.calls.RemovePolicyEntity = append(mock.calls.RemovePolicyEntity, callInfo)
+	mock.lockRemovePolicyEntity.Unlock()
+	return mock.RemovePolicyEntityFunc(ctx, policyID, entity, headers)
+}
+
+// RemovePolicyEntityCalls gets all the calls that were made to RemovePolicyEntity.
+// Check the length with:
+//
+//	len(mockedClienter.RemovePolicyEntityCalls())
+func (mock *ClienterMock) RemovePolicyEntityCalls() []struct {
+	Ctx      context.Context
+	PolicyID string
+	Entity   string
+	Headers  sdk.Headers
+} {
+	var calls []struct {
+		Ctx      context.Context
+		PolicyID string
+		Entity   string
+		Headers  sdk.Headers
+	}
+	mock.lockRemovePolicyEntity.RLock()
+	calls = mock.calls.RemovePolicyEntity
+	mock.lockRemovePolicyEntity.RUnlock()
+	return calls
+}
+
+// RestorePolicy calls RestorePolicyFunc.
+func (mock *ClienterMock) RestorePolicy(ctx context.Context, id string, headers sdk.Headers) error {
+	if mock.RestorePolicyFunc == nil {
+		panic("ClienterMock.RestorePolicyFunc: method is nil but Clienter.RestorePolicy was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		Headers sdk.Headers
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Headers: headers,
+	}
+	mock.lockRestorePolicy.Lock()
+	mock.calls.RestorePolicy = append(mock.calls.RestorePolicy, callInfo)
+	mock.lockRestorePolicy.Unlock()
+	return mock.RestorePolicyFunc(ctx, id, headers)
+}
+
+// RestorePolicyCalls gets all the calls that were made to RestorePolicy.
+// Check the length with:
+//
+//	len(mockedClienter.RestorePolicyCalls())
+func (mock *ClienterMock) RestorePolicyCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	Headers sdk.Headers
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		Headers sdk.Headers
+	}
+	mock.lockRestorePolicy.RLock()
+	calls = mock.calls.RestorePolicy
+	mock.lockRestorePolicy.RUnlock()
+	return calls
+}
+
+// UpdatePolicy calls UpdatePolicyFunc.
+func (mock *ClienterMock) UpdatePolicy(ctx context.Context, id string, policy models.Policy, headers sdk.Headers) error {
+	if mock.UpdatePolicyFunc == nil {
+		panic("ClienterMock.UpdatePolicyFunc: method is nil but Clienter.UpdatePolicy was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		Policy  models.Policy
+		Headers sdk.Headers
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Policy:  policy,
+		Headers: headers,
+	}
+	mock.lockUpdatePolicy.Lock()
+	mock.calls.UpdatePolicy = append(mock.calls.UpdatePolicy, callInfo)
+	mock.lockUpdatePolicy.Unlock()
+	return mock.UpdatePolicyFunc(ctx, id, policy, headers)
+}
+
+// UpdatePolicyCalls gets all the calls that were made to UpdatePolicy.
+// Check the length with:
+//
+//	len(mockedClienter.UpdatePolicyCalls())
+func (mock *ClienterMock) UpdatePolicyCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	Policy  models.Policy
+	Headers sdk.Headers
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		Policy  models.Policy
+		Headers sdk.Headers
+	}
+	mock.lockUpdatePolicy.RLock()
+	calls = mock.calls.UpdatePolicy
+	mock.lockUpdatePolicy.RUnlock()
+	return calls
+}
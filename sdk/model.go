@@ -1,5 +1,7 @@
 package sdk
 
+import "github.com/ONSdigital/dp-permissions-api/models"
+
 // EntityIDToPolicies maps an entity ID to a slice of policies.
 type EntityIDToPolicies map[string][]Policy
 
@@ -28,6 +30,22 @@ type EntityData struct {
 	Groups []string
 }
 
+// Entities maps the entity data to the entity IDs used in policies, the user first followed by the groups
+func (entityData EntityData) Entities() []string {
+	var entities []string
+
+	if entityData.UserID != "" {
+		entities = append(entities, models.EntityPrefixUsers+entityData.UserID)
+	}
+	for _, group := range entityData.Groups {
+		if group != "" {
+			entities = append(entities, models.EntityPrefixGroups+group)
+		}
+	}
+
+	return entities
+}
+
 const (
 	OperatorStringEquals Operator = "StringEquals"
 	OperatorStartsWith   Operator = "StartsWith"
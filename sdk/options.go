@@ -0,0 +1,48 @@
+package sdk
+
+import "time"
+
+// Default retry policy of a client created with NewClient
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 2 * time.Second
+)
+
+// Option configures an APIClient
+type Option func(*APIClient)
+
+// WithMaxRetries sets the maximum number of times that a request to an idempotent endpoint (GET, HEAD, PUT and
+// DELETE) is retried after a transport error, a 429 Too Many Requests or a 5xx response. Requests are not retried
+// if maxRetries is 0. Requests to create resources with POST are never retried.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *APIClient) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff sets the backoff between retries. The backoff doubles with every retry, starting from initial and
+// capped at maximum, and the actual wait is a random duration of up to the backoff, so that clients that failed at
+// the same time do not retry at the same time.
+func WithBackoff(initial, maximum time.Duration) Option {
+	return func(c *APIClient) {
+		c.initialBackoff = initial
+		c.maxBackoff = maximum
+	}
+}
+
+// WithTimeout sets the maximum duration of each attempt of a call to the permissions API, including reading the
+// response body. The context passed to the call bounds the overall duration, including any retries.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *APIClient) {
+		c.timeout = timeout
+	}
+}
+
+// WithTokenSource sets the source of the service auth token that is sent with requests that have no Authorization,
+// i.e. when neither Headers.Authorization nor Headers.ServiceAuthToken is set.
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(c *APIClient) {
+		c.tokenSource = tokenSource
+	}
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+)
+
+// ListOption sets a pagination query parameter of a request for a list of items
+type ListOption func(query url.Values)
+
+// WithOffset sets the number of items to skip at the start of the list
+func WithOffset(offset int) ListOption {
+	return func(query url.Values) {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+}
+
+// WithLimit sets the maximum number of items to return, which the permissions API caps at its maximum limit
+func WithLimit(limit int) ListOption {
+	return func(query url.Values) {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// ListAllRoles gets all the roles, by getting pages of pageSize roles until the total count of roles is reached.
+// The server default limit is used as the page size if pageSize is not positive.
+//
+// Roles that are added or deleted while the pages are being got can shift the later pages, so a role may be returned
+// in two pages, or may not be returned at all. A role is only included once in the returned list, and the walk stops
+// at the total count of the latest page, or at the first empty page.
+func (c *APIClient) ListAllRoles(ctx context.Context, headers Headers, pageSize int) ([]models.Role, error) {
+	roles := []models.Role{}
+	seen := map[string]struct{}{}
+
+	for offset := 0; ; {
+		opts := []ListOption{WithOffset(offset)}
+		if pageSize > 0 {
+			opts = append(opts, WithLimit(pageSize))
+		}
+
+		page, err := c.GetRoles(ctx, headers, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range page.Items {
+			if _, ok := seen[page.Items[i].ID]; !ok {
+				seen[page.Items[i].ID] = struct{}{}
+				roles = append(roles, page.Items[i])
+			}
+		}
+
+		offset += len(page.Items)
+		if len(page.Items) == 0 || offset >= page.TotalCount {
+			return roles, nil
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newRolesHTTPClient returns a mock http client that serves pages of the roles returned by getRoles, the way the
+// permissions API does, with a default limit of 20
+func newRolesHTTPClient(getRoles func() []models.Role) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			roles := getRoles()
+
+			offset, limit := 0, 20
+			if value := req.URL.Query().Get("offset"); value != "" {
+				offset, _ = strconv.Atoi(value)
+			}
+			if value := req.URL.Query().Get("limit"); value != "" {
+				limit, _ = strconv.Atoi(value)
+			}
+
+			items := []models.Role{}
+			if offset < len(roles) {
+				items = roles[offset:min(offset+limit, len(roles))]
+			}
+			b, _ := json.Marshal(models.Roles{Count: len(items), Offset: offset, Limit: limit, Items: items, TotalCount: len(roles)})
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(b)))}, nil
+		},
+	}
+}
+
+func newTestRoles(ids ...string) []models.Role {
+	roles := make([]models.Role, 0, len(ids))
+	for _, id := range ids {
+		roles = append(roles, models.Role{ID: id, Name: id, Permissions: []string{testPermissionAll}})
+	}
+	return roles
+}
+
+func getRoleIDs(roles []models.Role) []string {
+	ids := make([]string, 0, len(roles))
+	for i := range roles {
+		ids = append(ids, roles[i].ID)
+	}
+	return ids
+}
+
+func TestAPIClient_GetRoles_Pagination(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a permissions API with five roles", t, func() {
+		roles := newTestRoles("a", "b", "c", "d", "e")
+		httpClient := newRolesHTTPClient(func() []models.Role { return roles })
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When GetRoles is called with an offset and limit", func() {
+			page, err := apiClient.GetRoles(ctx, sdk.Headers{}, sdk.WithOffset(1), sdk.WithLimit(2))
+
+			Convey("Then the offset and limit are sent as query parameters, and the requested page is returned", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls()[0].Req.URL.RawQuery, ShouldEqual, "limit=2&offset=1")
+				So(getRoleIDs(page.Items), ShouldResemble, []string{"b", "c"})
+				So(page.TotalCount, ShouldEqual, 5)
+			})
+		})
+
+		Convey("When GetRoles is called without options, no query parameters are sent", func() {
+			_, err := apiClient.GetRoles(ctx, sdk.Headers{})
+			So(err, ShouldBeNil)
+			So(httpClient.DoCalls()[0].Req.URL.RawQuery, ShouldBeEmpty)
+		})
+
+		Convey("When ListAllRoles is called with a page size of 2", func() {
+			allRoles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 2)
+
+			Convey("Then all the pages are got until the total count is reached", func() {
+				So(err, ShouldBeNil)
+				So(getRoleIDs(allRoles), ShouldResemble, []string{"a", "b", "c", "d", "e"})
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+		})
+	})
+
+	Convey("Given a permissions API where a role is added before the current page while the pages are got", t, func() {
+		roles := newTestRoles("b", "c", "d")
+		calls := 0
+		httpClient := newRolesHTTPClient(func() []models.Role {
+			calls++
+			if calls == 2 {
+				roles = newTestRoles("a", "b", "c", "d")
+			}
+			return roles
+		})
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When ListAllRoles is called, the role shifted into the next page is only returned once", func() {
+			allRoles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 2)
+			So(err, ShouldBeNil)
+			So(getRoleIDs(allRoles), ShouldResemble, []string{"b", "c", "d"})
+		})
+	})
+
+	Convey("Given a permissions API without any roles", t, func() {
+		apiClient := sdk.NewClientWithClienter(host, newRolesHTTPClient(func() []models.Role { return nil }))
+
+		Convey("When ListAllRoles is called, an empty list is returned", func() {
+			allRoles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 2)
+			So(err, ShouldBeNil)
+			So(allRoles, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a permissions API that fails while the pages are got", t, func() {
+		rolesHTTPClient := newRolesHTTPClient(func() []models.Role { return newTestRoles("a", "b", "c") })
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				if req.URL.Query().Get("offset") != "0" {
+					return &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Status:     "500 Internal Server Error",
+						Body:       io.NopCloser(strings.NewReader(`{"errors":[{"code":"GetRolesError","description":"retrieving roles from DB returned an error"}]}`)),
+					}, nil
+				}
+				return rolesHTTPClient.Do(ctx, req)
+			},
+		}
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When ListAllRoles is called, the error is returned", func() {
+			allRoles, err := apiClient.ListAllRoles(ctx, sdk.Headers{}, 2)
+			So(err, ShouldNotBeNil)
+			So(allRoles, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// do sends the request to the permissions API, applying the client's service auth token, timeout and retry policy
+func (c *APIClient) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	maxRetries := c.maxRetries
+	if !isIdempotent(req.Method) {
+		maxRetries = 0
+	}
+
+	if err := c.addServiceAuthToken(ctx, req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.attempt(ctx, req)
+		if attempt >= maxRetries || !isRetryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil && resp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(c.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends the request once, with the client's timeout if there is one. The timeout is cancelled when the
+// response body is closed, so that the body can still be read after attempt returns.
+func (c *APIClient) attempt(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if c.timeout <= 0 {
+		return c.httpCli.Do(ctx, req)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	resp, err := c.httpCli.Do(ctx, req)
+	if err != nil || resp == nil || resp.Body == nil {
+		cancel()
+		return resp, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns a random wait of up to the exponential backoff for the given retry attempt
+func (c *APIClient) backoff(attempt int) time.Duration {
+	backoff := c.maxBackoff
+	if attempt < 32 && c.initialBackoff<<attempt < c.maxBackoff {
+		backoff = c.initialBackoff << attempt
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	//nolint:gosec // jitter spreads retries out, it does not need a secure random number
+	return rand.N(backoff + 1)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// cancelOnClose cancels the context of a request when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
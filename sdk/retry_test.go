@@ -0,0 +1,146 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newSequenceHTTPClient returns a mock http client that responds with the given status codes in turn, repeating the
+// last one, and records the body of each request
+func newSequenceHTTPClient(statusCodes ...int) (*dphttp.ClienterMock, *[]string) {
+	var bodies []string
+	httpClient := &dphttp.ClienterMock{}
+	httpClient.DoFunc = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+
+		statusCode := statusCodes[min(len(httpClient.DoCalls())-1, len(statusCodes)-1)]
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Body:       io.NopCloser(strings.NewReader(`{"id": "policy1"}`)),
+		}, nil
+	}
+	return httpClient, &bodies
+}
+
+func TestAPIClient_Retries(t *testing.T) {
+	ctx := context.Background()
+	fastBackoff := sdk.WithBackoff(time.Millisecond, 2*time.Millisecond)
+
+	Convey("Given a permissions API that is unavailable before it recovers", t, func() {
+		httpClient, bodies := newSequenceHTTPClient(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK)
+
+		Convey("When a GET is made by a client with retries", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(3), fastBackoff)
+			policy, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the request is retried until it succeeds", func() {
+				So(err, ShouldBeNil)
+				So(policy.ID, ShouldEqual, "policy1")
+				So(httpClient.DoCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a PUT is made by a client with retries", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(3), fastBackoff)
+			_, err := apiClient.PutPolicy(ctx, "policy1", models.Policy{ID: "policy1", Role: "admin"}, sdk.Headers{})
+
+			Convey("Then the request body is sent again with every retry", func() {
+				So(err, ShouldBeNil)
+				So(*bodies, ShouldHaveLength, 3)
+				So((*bodies)[2], ShouldEqual, (*bodies)[0])
+				So((*bodies)[0], ShouldContainSubstring, `"role":"admin"`)
+			})
+		})
+
+		Convey("When a POST is made by a client with retries", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(3), fastBackoff)
+			_, err := apiClient.PostPolicy(ctx, models.PolicyInfo{}, sdk.Headers{})
+
+			Convey("Then the request is not retried, as it is not idempotent", func() {
+				So(err, ShouldNotBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a GET is made by a client with fewer retries than needed", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(1), fastBackoff)
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the error of the last attempt is returned", func() {
+				var apiErr *sdk.APIError
+				So(errors.As(err, &apiErr), ShouldBeTrue)
+				So(apiErr.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a GET is made by a client created with an http client and no retry options", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient)
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then a single attempt is made", func() {
+				So(err, ShouldNotBeNil)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the context is cancelled while waiting to retry", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(3), sdk.WithBackoff(time.Minute, time.Minute))
+			cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+			defer cancel()
+			_, err := apiClient.GetPolicy(cancelCtx, "policy1", sdk.Headers{})
+
+			Convey("Then the context error is returned without further attempts", func() {
+				So(err, ShouldEqual, context.DeadlineExceeded)
+				So(httpClient.DoCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a permissions API that rejects the request", t, func() {
+		httpClient, _ := newSequenceHTTPClient(http.StatusNotFound)
+		apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithMaxRetries(3), fastBackoff)
+
+		Convey("When a GET is made, client errors are not retried", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+			So(err, ShouldNotBeNil)
+			So(httpClient.DoCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestAPIClient_Timeout(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a permissions API that does not respond", t, func() {
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}
+
+		Convey("When a call is made by a client with a timeout and a retry", func() {
+			apiClient := sdk.NewClientWithClienter(host, httpClient,
+				sdk.WithTimeout(5*time.Millisecond), sdk.WithMaxRetries(1), sdk.WithBackoff(time.Millisecond, time.Millisecond))
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then each attempt times out, and the timeout error is returned", func() {
+				So(err, ShouldEqual, context.DeadlineExceeded)
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
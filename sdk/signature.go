@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+)
+
+// bundleVerifier checks the detached signatures of permissions bundles against a set of trusted keys
+type bundleVerifier struct {
+	keys map[string]ed25519.PublicKey
+}
+
+func newBundleVerifier(keySet models.JSONWebKeySet) (*bundleVerifier, error) {
+	if len(keySet.Keys) == 0 {
+		return nil, ErrNoVerificationKeys
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.KeyType != models.KeyTypeOctetKeyPair || key.Curve != models.CurveEd25519 {
+			return nil, ErrInvalidVerificationKey
+		}
+
+		publicKey, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return nil, ErrInvalidVerificationKey
+		}
+
+		keys[key.KeyID] = publicKey
+	}
+
+	return &bundleVerifier{keys: keys}, nil
+}
+
+// verify returns an error if the signature was not created over the payload by the key with the given ID
+func (v *bundleVerifier) verify(payload []byte, keyID, signature string) error {
+	if signature == "" {
+		return ErrBundleSignatureMissing
+	}
+
+	publicKey, ok := v.keys[keyID]
+	if !ok {
+		return ErrBundleSignatureInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrBundleSignatureInvalid
+	}
+
+	if !ed25519.Verify(publicKey, payload, sig) {
+		return ErrBundleSignatureInvalid
+	}
+
+	return nil
+}
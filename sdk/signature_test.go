@@ -0,0 +1,160 @@
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-permissions-api/signing"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newTestSigner() *signing.Signer {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	So(err, ShouldBeNil)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	So(err, ShouldBeNil)
+	signer, err := signing.NewSigner("test-key", base64.StdEncoding.EncodeToString(der))
+	So(err, ShouldBeNil)
+	return signer
+}
+
+func newSignedBundleClienter(body []byte, headers http.Header) *dphttp.ClienterMock {
+	return &dphttp.ClienterMock{
+		DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     headers,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		},
+	}
+}
+
+func TestNewVerifyingClient(t *testing.T) {
+	Convey("When NewVerifyingClient is called without any keys", t, func() {
+		apiClient, err := sdk.NewVerifyingClient(host, models.JSONWebKeySet{})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldEqual, sdk.ErrNoVerificationKeys)
+			So(apiClient, ShouldBeNil)
+		})
+	})
+
+	Convey("When NewVerifyingClient is called with an invalid key", t, func() {
+		apiClient, err := sdk.NewVerifyingClient(host, models.JSONWebKeySet{
+			Keys: []models.JSONWebKey{{KeyType: "RSA", KeyID: "rsa-key"}},
+		})
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldEqual, sdk.ErrInvalidVerificationKey)
+			So(apiClient, ShouldBeNil)
+		})
+	})
+}
+
+func TestAPIClient_GetPermissionsBundle_Verifying(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a verifying client that trusts the signing key", t, func() {
+		signer := newTestSigner()
+		body := getExampleBundleJSON()
+
+		newClient := func(headers http.Header, responseBody []byte) *sdk.APIClient {
+			apiClient, err := sdk.NewVerifyingClientWithClienter(host, newSignedBundleClienter(responseBody, headers), signer.JWKS())
+			So(err, ShouldBeNil)
+			return apiClient
+		}
+
+		Convey("When a correctly signed bundle is returned", func() {
+			headers := http.Header{}
+			headers.Set(models.BundleSignatureHeader, signer.Sign(body))
+			headers.Set(models.BundleSignatureKeyIDHeader, signer.KeyID())
+
+			bundle, err := newClient(headers, body).GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is returned", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, getExampleBundle())
+			})
+		})
+
+		Convey("When a bundle that has been tampered with is returned", func() {
+			headers := http.Header{}
+			headers.Set(models.BundleSignatureHeader, signer.Sign(body))
+			headers.Set(models.BundleSignatureKeyIDHeader, signer.KeyID())
+			tampered := bytes.Replace(body, []byte("group/admin"), []byte("group/other"), 1)
+
+			bundle, err := newClient(headers, tampered).GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is rejected", func() {
+				So(err, ShouldEqual, sdk.ErrBundleSignatureInvalid)
+				So(bundle, ShouldBeNil)
+			})
+		})
+
+		Convey("When a bundle signed by an unknown key is returned", func() {
+			otherSigner := newTestSigner()
+			headers := http.Header{}
+			headers.Set(models.BundleSignatureHeader, otherSigner.Sign(body))
+			headers.Set(models.BundleSignatureKeyIDHeader, signer.KeyID())
+
+			bundle, err := newClient(headers, body).GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is rejected", func() {
+				So(err, ShouldEqual, sdk.ErrBundleSignatureInvalid)
+				So(bundle, ShouldBeNil)
+			})
+		})
+
+		Convey("When an unsigned bundle is returned", func() {
+			bundle, err := newClient(http.Header{}, body).GetPermissionsBundle(ctx, sdk.Headers{})
+
+			Convey("Then the bundle is rejected", func() {
+				So(err, ShouldEqual, sdk.ErrBundleSignatureMissing)
+				So(bundle, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestAPIClient_GetBundleVerificationKeys(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a mock http client that returns a key set", t, func() {
+		signer := newTestSigner()
+		var requestedURL string
+		httpClient := &dphttp.ClienterMock{
+			DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				requestedURL = req.URL.String()
+				b, err := json.Marshal(signer.JWKS())
+				So(err, ShouldBeNil)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(b)),
+				}, nil
+			},
+		}
+		apiClient := sdk.NewClientWithClienter(host, httpClient)
+
+		Convey("When GetBundleVerificationKeys is called", func() {
+			keys, err := apiClient.GetBundleVerificationKeys(ctx, sdk.Headers{})
+
+			Convey("Then the key set is returned", func() {
+				So(err, ShouldBeNil)
+				So(requestedURL, ShouldEqual, host+"/v1/permissions-bundle/keys")
+				So(*keys, ShouldResemble, signer.JWKS())
+			})
+		})
+	})
+}
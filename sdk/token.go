@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TokenSource provides the service auth token that is sent to the permissions API when a request has no
+// Authorization header. Token is called for every request, so that a long-running client always uses a current
+// token, and implementations that get their token remotely should cache it until it needs refreshing.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always provides the same token
+type StaticTokenSource string
+
+// Token returns the static token
+func (s StaticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// TokenSourceFunc is an adapter to use a function as a TokenSource
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx)
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// addServiceAuthToken sets the Authorization header of the request to the token of the client's TokenSource, unless
+// the request already has an Authorization header or the client has no TokenSource
+func (c *APIClient) addServiceAuthToken(ctx context.Context, req *http.Request) error {
+	if c.tokenSource == nil || req.Header.Get(Authorization) != "" {
+		return nil
+	}
+
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get service auth token: %w", err)
+	}
+	if token != "" {
+		req.Header.Set(Authorization, BearerPrefix+token)
+	}
+	return nil
+}
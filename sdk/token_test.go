@@ -0,0 +1,82 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/sdk"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPIClient_TokenSource(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client with a token source that refreshes its token", t, func() {
+		httpClient, _ := newSequenceHTTPClient(http.StatusOK)
+		tokens := []string{"service-token-1", "service-token-2"}
+		calls := 0
+		tokenSource := sdk.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			token := tokens[min(calls, len(tokens)-1)]
+			calls++
+			return token, nil
+		})
+		apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithTokenSource(tokenSource))
+
+		Convey("When requests are made without an Authorization", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+			So(err, ShouldBeNil)
+			_, err = apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the current token of the token source is sent with each request", func() {
+				So(httpClient.DoCalls(), ShouldHaveLength, 2)
+				So(httpClient.DoCalls()[0].Req.Header.Get(sdk.Authorization), ShouldEqual, "Bearer service-token-1")
+				So(httpClient.DoCalls()[1].Req.Header.Get(sdk.Authorization), ShouldEqual, "Bearer service-token-2")
+			})
+		})
+
+		Convey("When a request is made with a ServiceAuthToken", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{ServiceAuthToken: "explicit-token"})
+
+			Convey("Then the given token is sent and the token source is not used", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls()[0].Req.Header.Get(sdk.Authorization), ShouldEqual, "Bearer explicit-token")
+				So(calls, ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a client with a token source that fails", t, func() {
+		httpClient, _ := newSequenceHTTPClient(http.StatusOK)
+		errTokenUnavailable := errors.New("token unavailable")
+		tokenSource := sdk.TokenSourceFunc(func(ctx context.Context) (string, error) {
+			return "", errTokenUnavailable
+		})
+		apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithTokenSource(tokenSource))
+
+		Convey("When a request is made without an Authorization", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the token error is returned and no request is sent", func() {
+				So(errors.Is(err, errTokenUnavailable), ShouldBeTrue)
+				So(httpClient.DoCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a client with a static token source", t, func() {
+		httpClient, _ := newSequenceHTTPClient(http.StatusOK)
+		apiClient := sdk.NewClientWithClienter(host, httpClient, sdk.WithTokenSource(sdk.StaticTokenSource("static-token")))
+
+		Convey("When a request is made without an Authorization", func() {
+			_, err := apiClient.GetPolicy(ctx, "policy1", sdk.Headers{})
+
+			Convey("Then the static token is sent", func() {
+				So(err, ShouldBeNil)
+				So(httpClient.DoCalls()[0].Req.Header.Get(sdk.Authorization), ShouldEqual, "Bearer static-token")
+			})
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ONSdigital/dp-permissions-api/api"
 	"github.com/ONSdigital/dp-permissions-api/permissions"
+	"github.com/ONSdigital/dp-permissions-api/purge"
 
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-permissions-api/config"
@@ -41,8 +42,9 @@ type HealthChecker interface {
 	AddCheck(name string, checker healthcheck.Checker) (err error)
 }
 
-// PermissionsStore includes all store functions for the API and permissions package
+// PermissionsStore includes all store functions for the API, permissions and purge packages
 type PermissionsStore interface {
 	api.PermissionsStore
 	permissions.Store
+	purge.Store
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ONSdigital/dp-permissions-api/models"
 	"github.com/ONSdigital/dp-permissions-api/service"
 	"sync"
+	"time"
 )
 
 // Ensure, that PermissionsStoreMock does implement service.PermissionsStore.
@@ -24,21 +25,57 @@ var _ service.PermissionsStore = &PermissionsStoreMock{}
 //			AddPolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.Policy, error) {
 //				panic("mock out the AddPolicy method")
 //			},
+//			AddPolicyEntityFunc: func(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error) {
+//				panic("mock out the AddPolicyEntity method")
+//			},
+//			AddRoleFunc: func(ctx context.Context, role *models.Role) (*models.Role, error) {
+//				panic("mock out the AddRole method")
+//			},
 //			CheckerFunc: func(ctx context.Context, state *healthcheck.CheckState) error {
 //				panic("mock out the Checker method")
 //			},
 //			CloseFunc: func(ctx context.Context) error {
 //				panic("mock out the Close method")
 //			},
-//			DeletePolicyFunc: func(ctx context.Context, id string) error {
+//			CountPoliciesByRoleFunc: func(ctx context.Context, roleIDs []string) (map[string]int, error) {
+//				panic("mock out the CountPoliciesByRole method")
+//			},
+//			DeletePoliciesByRoleFunc: func(ctx context.Context, roleID string) ([]string, error) {
+//				panic("mock out the DeletePoliciesByRole method")
+//			},
+//			DeletePolicyFunc: func(ctx context.Context, id string, deletedBy string) error {
 //				panic("mock out the DeletePolicy method")
 //			},
+//			DeleteRoleFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteRole method")
+//			},
+//			FindRolesFunc: func(ctx context.Context, filter models.RoleFilter, offset int, limit int) (*models.Roles, error) {
+//				panic("mock out the FindRoles method")
+//			},
 //			GetAllBundlePoliciesFunc: func(ctx context.Context) ([]*models.BundlePolicy, error) {
 //				panic("mock out the GetAllBundlePolicies method")
 //			},
+//			GetAllPermissionsFunc: func(ctx context.Context) ([]*models.Permission, error) {
+//				panic("mock out the GetAllPermissions method")
+//			},
 //			GetAllRolesFunc: func(ctx context.Context) ([]*models.Role, error) {
 //				panic("mock out the GetAllRoles method")
 //			},
+//			GetDeletedPoliciesFunc: func(ctx context.Context, offset int, limit int) (*models.Policies, error) {
+//				panic("mock out the GetDeletedPolicies method")
+//			},
+//			GetDeletedPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
+//				panic("mock out the GetDeletedPolicy method")
+//			},
+//			GetPermissionFunc: func(ctx context.Context, id string) (*models.Permission, error) {
+//				panic("mock out the GetPermission method")
+//			},
+//			GetPermissionsFunc: func(ctx context.Context, offset int, limit int) (*models.Permissions, error) {
+//				panic("mock out the GetPermissions method")
+//			},
+//			GetPoliciesByRoleFunc: func(ctx context.Context, roleID string, offset int, limit int) (*models.Policies, error) {
+//				panic("mock out the GetPoliciesByRole method")
+//			},
 //			GetPolicyFunc: func(ctx context.Context, id string) (*models.Policy, error) {
 //				panic("mock out the GetPolicy method")
 //			},
@@ -48,9 +85,39 @@ var _ service.PermissionsStore = &PermissionsStoreMock{}
 //			GetRolesFunc: func(ctx context.Context, offset int, limit int) (*models.Roles, error) {
 //				panic("mock out the GetRoles method")
 //			},
+//			GetRolesGrantingPermissionFunc: func(ctx context.Context, permission string) ([]*models.Role, error) {
+//				panic("mock out the GetRolesGrantingPermission method")
+//			},
+//			GetRolesWithDeprecatedPermissionsFunc: func(ctx context.Context, offset int, limit int) (*models.Roles, error) {
+//				panic("mock out the GetRolesWithDeprecatedPermissions method")
+//			},
+//			GetUnknownPermissionsFunc: func(ctx context.Context, permissions []string) ([]string, error) {
+//				panic("mock out the GetUnknownPermissions method")
+//			},
+//			PurgeDeletedPoliciesFunc: func(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+//				panic("mock out the PurgeDeletedPolicies method")
+//			},
+//			RemovePolicyEntityFunc: func(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error) {
+//				panic("mock out the RemovePolicyEntity method")
+//			},
+//			ReplacePolicyFunc: func(ctx context.Context, policy *models.Policy) error {
+//				panic("mock out the ReplacePolicy method")
+//			},
+//			ReplacePolicyIfUnmodifiedFunc: func(ctx context.Context, policy *models.Policy, version int) error {
+//				panic("mock out the ReplacePolicyIfUnmodified method")
+//			},
+//			RestorePolicyFunc: func(ctx context.Context, id string, updatedBy string) error {
+//				panic("mock out the RestorePolicy method")
+//			},
 //			UpdatePolicyFunc: func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
 //				panic("mock out the UpdatePolicy method")
 //			},
+//			UpdateRoleFunc: func(ctx context.Context, role *models.Role) error {
+//				panic("mock out the UpdateRole method")
+//			},
+//			UpsertPermissionFunc: func(ctx context.Context, permission *models.Permission) (*models.UpdateResult, error) {
+//				panic("mock out the UpsertPermission method")
+//			},
 //		}
 //
 //		// use mockedPermissionsStore in code that requires service.PermissionsStore
@@ -61,21 +128,57 @@ type PermissionsStoreMock struct {
 	// AddPolicyFunc mocks the AddPolicy method.
 	AddPolicyFunc func(ctx context.Context, policy *models.Policy) (*models.Policy, error)
 
+	// AddPolicyEntityFunc mocks the AddPolicyEntity method.
+	AddPolicyEntityFunc func(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error)
+
+	// AddRoleFunc mocks the AddRole method.
+	AddRoleFunc func(ctx context.Context, role *models.Role) (*models.Role, error)
+
 	// CheckerFunc mocks the Checker method.
 	CheckerFunc func(ctx context.Context, state *healthcheck.CheckState) error
 
 	// CloseFunc mocks the Close method.
 	CloseFunc func(ctx context.Context) error
 
+	// CountPoliciesByRoleFunc mocks the CountPoliciesByRole method.
+	CountPoliciesByRoleFunc func(ctx context.Context, roleIDs []string) (map[string]int, error)
+
+	// DeletePoliciesByRoleFunc mocks the DeletePoliciesByRole method.
+	DeletePoliciesByRoleFunc func(ctx context.Context, roleID string) ([]string, error)
+
 	// DeletePolicyFunc mocks the DeletePolicy method.
-	DeletePolicyFunc func(ctx context.Context, id string) error
+	DeletePolicyFunc func(ctx context.Context, id string, deletedBy string) error
+
+	// DeleteRoleFunc mocks the DeleteRole method.
+	DeleteRoleFunc func(ctx context.Context, id string) error
+
+	// FindRolesFunc mocks the FindRoles method.
+	FindRolesFunc func(ctx context.Context, filter models.RoleFilter, offset int, limit int) (*models.Roles, error)
 
 	// GetAllBundlePoliciesFunc mocks the GetAllBundlePolicies method.
 	GetAllBundlePoliciesFunc func(ctx context.Context) ([]*models.BundlePolicy, error)
 
+	// GetAllPermissionsFunc mocks the GetAllPermissions method.
+	GetAllPermissionsFunc func(ctx context.Context) ([]*models.Permission, error)
+
 	// GetAllRolesFunc mocks the GetAllRoles method.
 	GetAllRolesFunc func(ctx context.Context) ([]*models.Role, error)
 
+	// GetDeletedPoliciesFunc mocks the GetDeletedPolicies method.
+	GetDeletedPoliciesFunc func(ctx context.Context, offset int, limit int) (*models.Policies, error)
+
+	// GetDeletedPolicyFunc mocks the GetDeletedPolicy method.
+	GetDeletedPolicyFunc func(ctx context.Context, id string) (*models.Policy, error)
+
+	// GetPermissionFunc mocks the GetPermission method.
+	GetPermissionFunc func(ctx context.Context, id string) (*models.Permission, error)
+
+	// GetPermissionsFunc mocks the GetPermissions method.
+	GetPermissionsFunc func(ctx context.Context, offset int, limit int) (*models.Permissions, error)
+
+	// GetPoliciesByRoleFunc mocks the GetPoliciesByRole method.
+	GetPoliciesByRoleFunc func(ctx context.Context, roleID string, offset int, limit int) (*models.Policies, error)
+
 	// GetPolicyFunc mocks the GetPolicy method.
 	GetPolicyFunc func(ctx context.Context, id string) (*models.Policy, error)
 
@@ -85,9 +188,39 @@ type PermissionsStoreMock struct {
 	// GetRolesFunc mocks the GetRoles method.
 	GetRolesFunc func(ctx context.Context, offset int, limit int) (*models.Roles, error)
 
+	// GetRolesGrantingPermissionFunc mocks the GetRolesGrantingPermission method.
+	GetRolesGrantingPermissionFunc func(ctx context.Context, permission string) ([]*models.Role, error)
+
+	// GetRolesWithDeprecatedPermissionsFunc mocks the GetRolesWithDeprecatedPermissions method.
+	GetRolesWithDeprecatedPermissionsFunc func(ctx context.Context, offset int, limit int) (*models.Roles, error)
+
+	// GetUnknownPermissionsFunc mocks the GetUnknownPermissions method.
+	GetUnknownPermissionsFunc func(ctx context.Context, permissions []string) ([]string, error)
+
+	// PurgeDeletedPoliciesFunc mocks the PurgeDeletedPolicies method.
+	PurgeDeletedPoliciesFunc func(ctx context.Context, deletedBefore time.Time) ([]string, error)
+
+	// RemovePolicyEntityFunc mocks the RemovePolicyEntity method.
+	RemovePolicyEntityFunc func(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error)
+
+	// ReplacePolicyFunc mocks the ReplacePolicy method.
+	ReplacePolicyFunc func(ctx context.Context, policy *models.Policy) error
+
+	// ReplacePolicyIfUnmodifiedFunc mocks the ReplacePolicyIfUnmodified method.
+	ReplacePolicyIfUnmodifiedFunc func(ctx context.Context, policy *models.Policy, version int) error
+
+	// RestorePolicyFunc mocks the RestorePolicy method.
+	RestorePolicyFunc func(ctx context.Context, id string, updatedBy string) error
+
 	// UpdatePolicyFunc mocks the UpdatePolicy method.
 	UpdatePolicyFunc func(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error)
 
+	// UpdateRoleFunc mocks the UpdateRole method.
+	UpdateRoleFunc func(ctx context.Context, role *models.Role) error
+
+	// UpsertPermissionFunc mocks the UpsertPermission method.
+	UpsertPermissionFunc func(ctx context.Context, permission *models.Permission) (*models.UpdateResult, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// AddPolicy holds details about calls to the AddPolicy method.
@@ -97,6 +230,24 @@ type PermissionsStoreMock struct {
 			// Policy is the policy argument value.
 			Policy *models.Policy
 		}
+		// AddPolicyEntity holds details about calls to the AddPolicyEntity method.
+		AddPolicyEntity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PolicyID is the policyID argument value.
+			PolicyID string
+			// Entity is the entity argument value.
+			Entity string
+			// UpdatedBy is the updatedBy argument value.
+			UpdatedBy string
+		}
+		// AddRole holds details about calls to the AddRole method.
+		AddRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Role is the role argument value.
+			Role *models.Role
+		}
 		// Checker holds details about calls to the Checker method.
 		Checker []struct {
 			// Ctx is the ctx argument value.
@@ -109,23 +260,105 @@ type PermissionsStoreMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// CountPoliciesByRole holds details about calls to the CountPoliciesByRole method.
+		CountPoliciesByRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RoleIDs is the roleIDs argument value.
+			RoleIDs []string
+		}
+		// DeletePoliciesByRole holds details about calls to the DeletePoliciesByRole method.
+		DeletePoliciesByRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RoleID is the roleID argument value.
+			RoleID string
+		}
 		// DeletePolicy holds details about calls to the DeletePolicy method.
 		DeletePolicy []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID string
+			// DeletedBy is the deletedBy argument value.
+			DeletedBy string
+		}
+		// DeleteRole holds details about calls to the DeleteRole method.
+		DeleteRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// FindRoles holds details about calls to the FindRoles method.
+		FindRoles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Filter is the filter argument value.
+			Filter models.RoleFilter
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
 		}
 		// GetAllBundlePolicies holds details about calls to the GetAllBundlePolicies method.
 		GetAllBundlePolicies []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetAllPermissions holds details about calls to the GetAllPermissions method.
+		GetAllPermissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetAllRoles holds details about calls to the GetAllRoles method.
 		GetAllRoles []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetDeletedPolicies holds details about calls to the GetDeletedPolicies method.
+		GetDeletedPolicies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetDeletedPolicy holds details about calls to the GetDeletedPolicy method.
+		GetDeletedPolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetPermission holds details about calls to the GetPermission method.
+		GetPermission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetPermissions holds details about calls to the GetPermissions method.
+		GetPermissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetPoliciesByRole holds details about calls to the GetPoliciesByRole method.
+		GetPoliciesByRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RoleID is the roleID argument value.
+			RoleID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetPolicy holds details about calls to the GetPolicy method.
 		GetPolicy []struct {
 			// Ctx is the ctx argument value.
@@ -149,6 +382,72 @@ type PermissionsStoreMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// GetRolesGrantingPermission holds details about calls to the GetRolesGrantingPermission method.
+		GetRolesGrantingPermission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Permission is the permission argument value.
+			Permission string
+		}
+		// GetRolesWithDeprecatedPermissions holds details about calls to the GetRolesWithDeprecatedPermissions method.
+		GetRolesWithDeprecatedPermissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetUnknownPermissions holds details about calls to the GetUnknownPermissions method.
+		GetUnknownPermissions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Permissions is the permissions argument value.
+			Permissions []string
+		}
+		// PurgeDeletedPolicies holds details about calls to the PurgeDeletedPolicies method.
+		PurgeDeletedPolicies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DeletedBefore is the deletedBefore argument value.
+			DeletedBefore time.Time
+		}
+		// RemovePolicyEntity holds details about calls to the RemovePolicyEntity method.
+		RemovePolicyEntity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PolicyID is the policyID argument value.
+			PolicyID string
+			// Entity is the entity argument value.
+			Entity string
+			// UpdatedBy is the updatedBy argument value.
+			UpdatedBy string
+		}
+		// ReplacePolicy holds details about calls to the ReplacePolicy method.
+		ReplacePolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Policy is the policy argument value.
+			Policy *models.Policy
+		}
+		// ReplacePolicyIfUnmodified holds details about calls to the ReplacePolicyIfUnmodified method.
+		ReplacePolicyIfUnmodified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Policy is the policy argument value.
+			Policy *models.Policy
+			// Version is the version argument value.
+			Version int
+		}
+		// RestorePolicy holds details about calls to the RestorePolicy method.
+		RestorePolicy []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// UpdatedBy is the updatedBy argument value.
+			UpdatedBy string
+		}
 		// UpdatePolicy holds details about calls to the UpdatePolicy method.
 		UpdatePolicy []struct {
 			// Ctx is the ctx argument value.
@@ -156,17 +455,53 @@ type PermissionsStoreMock struct {
 			// Policy is the policy argument value.
 			Policy *models.Policy
 		}
+		// UpdateRole holds details about calls to the UpdateRole method.
+		UpdateRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Role is the role argument value.
+			Role *models.Role
+		}
+		// UpsertPermission holds details about calls to the UpsertPermission method.
+		UpsertPermission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Permission is the permission argument value.
+			Permission *models.Permission
+		}
 	}
-	lockAddPolicy            sync.RWMutex
-	lockChecker              sync.RWMutex
-	lockClose                sync.RWMutex
-	lockDeletePolicy         sync.RWMutex
-	lockGetAllBundlePolicies sync.RWMutex
-	lockGetAllRoles          sync.RWMutex
-	lockGetPolicy            sync.RWMutex
-	lockGetRole              sync.RWMutex
-	lockGetRoles             sync.RWMutex
-	lockUpdatePolicy         sync.RWMutex
+	lockAddPolicy                         sync.RWMutex
+	lockAddPolicyEntity                   sync.RWMutex
+	lockAddRole                           sync.RWMutex
+	lockChecker                           sync.RWMutex
+	lockClose                             sync.RWMutex
+	lockCountPoliciesByRole               sync.RWMutex
+	lockDeletePoliciesByRole              sync.RWMutex
+	lockDeletePolicy                      sync.RWMutex
+	lockDeleteRole                        sync.RWMutex
+	lockFindRoles                         sync.RWMutex
+	lockGetAllBundlePolicies              sync.RWMutex
+	lockGetAllPermissions                 sync.RWMutex
+	lockGetAllRoles                       sync.RWMutex
+	lockGetDeletedPolicies                sync.RWMutex
+	lockGetDeletedPolicy                  sync.RWMutex
+	lockGetPermission                     sync.RWMutex
+	lockGetPermissions                    sync.RWMutex
+	lockGetPoliciesByRole                 sync.RWMutex
+	lockGetPolicy                         sync.RWMutex
+	lockGetRole                           sync.RWMutex
+	lockGetRoles                          sync.RWMutex
+	lockGetRolesGrantingPermission        sync.RWMutex
+	lockGetRolesWithDeprecatedPermissions sync.RWMutex
+	lockGetUnknownPermissions             sync.RWMutex
+	lockPurgeDeletedPolicies              sync.RWMutex
+	lockRemovePolicyEntity                sync.RWMutex
+	lockReplacePolicy                     sync.RWMutex
+	lockReplacePolicyIfUnmodified         sync.RWMutex
+	lockRestorePolicy                     sync.RWMutex
+	lockUpdatePolicy                      sync.RWMutex
+	lockUpdateRole                        sync.RWMutex
+	lockUpsertPermission                  sync.RWMutex
 }
 
 // AddPolicy calls AddPolicyFunc.
@@ -205,6 +540,86 @@ func (mock *PermissionsStoreMock) AddPolicyCalls() []struct {
 	return calls
 }
 
+// AddPolicyEntity calls AddPolicyEntityFunc.
+func (mock *PermissionsStoreMock) AddPolicyEntity(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error) {
+	if mock.AddPolicyEntityFunc == nil {
+		panic("PermissionsStoreMock.AddPolicyEntityFunc: method is nil but PermissionsStore.AddPolicyEntity was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PolicyID  string
+		Entity    string
+		UpdatedBy string
+	}{
+		Ctx:       ctx,
+		PolicyID:  policyID,
+		Entity:    entity,
+		UpdatedBy: updatedBy,
+	}
+	mock.lockAddPolicyEntity.Lock()
+	mock.calls.AddPolicyEntity = append(mock.calls.AddPolicyEntity, callInfo)
+	mock.lockAddPolicyEntity.Unlock()
+	return mock.AddPolicyEntityFunc(ctx, policyID, entity, updatedBy)
+}
+
+// AddPolicyEntityCalls gets all the calls that were made to AddPolicyEntity.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.AddPolicyEntityCalls())
+func (mock *PermissionsStoreMock) AddPolicyEntityCalls() []struct {
+	Ctx       context.Context
+	PolicyID  string
+	Entity    string
+	UpdatedBy string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PolicyID  string
+		Entity    string
+		UpdatedBy string
+	}
+	mock.lockAddPolicyEntity.RLock()
+	calls = mock.calls.AddPolicyEntity
+	mock.lockAddPolicyEntity.RUnlock()
+	return calls
+}
+
+// AddRole calls AddRoleFunc.
+func (mock *PermissionsStoreMock) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if mock.AddRoleFunc == nil {
+		panic("PermissionsStoreMock.AddRoleFunc: method is nil but PermissionsStore.AddRole was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Role *models.Role
+	}{
+		Ctx:  ctx,
+		Role: role,
+	}
+	mock.lockAddRole.Lock()
+	mock.calls.AddRole = append(mock.calls.AddRole, callInfo)
+	mock.lockAddRole.Unlock()
+	return mock.AddRoleFunc(ctx, role)
+}
+
+// AddRoleCalls gets all the calls that were made to AddRole.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.AddRoleCalls())
+func (mock *PermissionsStoreMock) AddRoleCalls() []struct {
+	Ctx  context.Context
+	Role *models.Role
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Role *models.Role
+	}
+	mock.lockAddRole.RLock()
+	calls = mock.calls.AddRole
+	mock.lockAddRole.RUnlock()
+	return calls
+}
+
 // Checker calls CheckerFunc.
 func (mock *PermissionsStoreMock) Checker(ctx context.Context, state *healthcheck.CheckState) error {
 	if mock.CheckerFunc == nil {
@@ -273,110 +688,122 @@ func (mock *PermissionsStoreMock) CloseCalls() []struct {
 	return calls
 }
 
-// DeletePolicy calls DeletePolicyFunc.
-func (mock *PermissionsStoreMock) DeletePolicy(ctx context.Context, id string) error {
-	if mock.DeletePolicyFunc == nil {
-		panic("PermissionsStoreMock.DeletePolicyFunc: method is nil but PermissionsStore.DeletePolicy was just called")
+// CountPoliciesByRole calls CountPoliciesByRoleFunc.
+func (mock *PermissionsStoreMock) CountPoliciesByRole(ctx context.Context, roleIDs []string) (map[string]int, error) {
+	if mock.CountPoliciesByRoleFunc == nil {
+		panic("PermissionsStoreMock.CountPoliciesByRoleFunc: method is nil but PermissionsStore.CountPoliciesByRole was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  string
+		Ctx     context.Context
+		RoleIDs []string
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:     ctx,
+		RoleIDs: roleIDs,
 	}
-	mock.lockDeletePolicy.Lock()
-	mock.calls.DeletePolicy = append(mock.calls.DeletePolicy, callInfo)
-	mock.lockDeletePolicy.Unlock()
-	return mock.DeletePolicyFunc(ctx, id)
+	mock.lockCountPoliciesByRole.Lock()
+	mock.calls.CountPoliciesByRole = append(mock.calls.CountPoliciesByRole, callInfo)
+	mock.lockCountPoliciesByRole.Unlock()
+	return mock.CountPoliciesByRoleFunc(ctx, roleIDs)
 }
 
-// DeletePolicyCalls gets all the calls that were made to DeletePolicy.
+// CountPoliciesByRoleCalls gets all the calls that were made to CountPoliciesByRole.
 // Check the length with:
 //
-//	len(mockedPermissionsStore.DeletePolicyCalls())
-func (mock *PermissionsStoreMock) DeletePolicyCalls() []struct {
-	Ctx context.Context
-	ID  string
+//	len(mockedPermissionsStore.CountPoliciesByRoleCalls())
+func (mock *PermissionsStoreMock) CountPoliciesByRoleCalls() []struct {
+	Ctx     context.Context
+	RoleIDs []string
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  string
+		Ctx     context.Context
+		RoleIDs []string
 	}
-	mock.lockDeletePolicy.RLock()
-	calls = mock.calls.DeletePolicy
-	mock.lockDeletePolicy.RUnlock()
+	mock.lockCountPoliciesByRole.RLock()
+	calls = mock.calls.CountPoliciesByRole
+	mock.lockCountPoliciesByRole.RUnlock()
 	return calls
 }
 
-// GetAllBundlePolicies calls GetAllBundlePoliciesFunc.
-func (mock *PermissionsStoreMock) GetAllBundlePolicies(ctx context.Context) ([]*models.BundlePolicy, error) {
-	if mock.GetAllBundlePoliciesFunc == nil {
-		panic("PermissionsStoreMock.GetAllBundlePoliciesFunc: method is nil but PermissionsStore.GetAllBundlePolicies was just called")
+// DeletePoliciesByRole calls DeletePoliciesByRoleFunc.
+func (mock *PermissionsStoreMock) DeletePoliciesByRole(ctx context.Context, roleID string) ([]string, error) {
+	if mock.DeletePoliciesByRoleFunc == nil {
+		panic("PermissionsStoreMock.DeletePoliciesByRoleFunc: method is nil but PermissionsStore.DeletePoliciesByRole was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx    context.Context
+		RoleID string
 	}{
-		Ctx: ctx,
+		Ctx:    ctx,
+		RoleID: roleID,
 	}
-	mock.lockGetAllBundlePolicies.Lock()
-	mock.calls.GetAllBundlePolicies = append(mock.calls.GetAllBundlePolicies, callInfo)
-	mock.lockGetAllBundlePolicies.Unlock()
-	return mock.GetAllBundlePoliciesFunc(ctx)
+	mock.lockDeletePoliciesByRole.Lock()
+	mock.calls.DeletePoliciesByRole = append(mock.calls.DeletePoliciesByRole, callInfo)
+	mock.lockDeletePoliciesByRole.Unlock()
+	return mock.DeletePoliciesByRoleFunc(ctx, roleID)
 }
 
-// GetAllBundlePoliciesCalls gets all the calls that were made to GetAllBundlePolicies.
+// DeletePoliciesByRoleCalls gets all the calls that were made to DeletePoliciesByRole.
 // Check the length with:
 //
-//	len(mockedPermissionsStore.GetAllBundlePoliciesCalls())
-func (mock *PermissionsStoreMock) GetAllBundlePoliciesCalls() []struct {
-	Ctx context.Context
+//	len(mockedPermissionsStore.DeletePoliciesByRoleCalls())
+func (mock *PermissionsStoreMock) DeletePoliciesByRoleCalls() []struct {
+	Ctx    context.Context
+	RoleID string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx    context.Context
+		RoleID string
 	}
-	mock.lockGetAllBundlePolicies.RLock()
-	calls = mock.calls.GetAllBundlePolicies
-	mock.lockGetAllBundlePolicies.RUnlock()
+	mock.lockDeletePoliciesByRole.RLock()
+	calls = mock.calls.DeletePoliciesByRole
+	mock.lockDeletePoliciesByRole.RUnlock()
 	return calls
 }
 
-// GetAllRoles calls GetAllRolesFunc.
-func (mock *PermissionsStoreMock) GetAllRoles(ctx context.Context) ([]*models.Role, error) {
-	if mock.GetAllRolesFunc == nil {
-		panic("PermissionsStoreMock.GetAllRolesFunc: method is nil but PermissionsStore.GetAllRoles was just called")
+// DeletePolicy calls DeletePolicyFunc.
+func (mock *PermissionsStoreMock) DeletePolicy(ctx context.Context, id string, deletedBy string) error {
+	if mock.DeletePolicyFunc == nil {
+		panic("PermissionsStoreMock.DeletePolicyFunc: method is nil but PermissionsStore.DeletePolicy was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx       context.Context
+		ID        string
+		DeletedBy string
 	}{
-		Ctx: ctx,
+		Ctx:       ctx,
+		ID:        id,
+		DeletedBy: deletedBy,
 	}
-	mock.lockGetAllRoles.Lock()
-	mock.calls.GetAllRoles = append(mock.calls.GetAllRoles, callInfo)
-	mock.lockGetAllRoles.Unlock()
-	return mock.GetAllRolesFunc(ctx)
+	mock.lockDeletePolicy.Lock()
+	mock.calls.DeletePolicy = append(mock.calls.DeletePolicy, callInfo)
+	mock.lockDeletePolicy.Unlock()
+	return mock.DeletePolicyFunc(ctx, id, deletedBy)
 }
 
-// GetAllRolesCalls gets all the calls that were made to GetAllRoles.
+// DeletePolicyCalls gets all the calls that were made to DeletePolicy.
 // Check the length with:
 //
-//	len(mockedPermissionsStore.GetAllRolesCalls())
-func (mock *PermissionsStoreMock) GetAllRolesCalls() []struct {
-	Ctx context.Context
+//	len(mockedPermissionsStore.DeletePolicyCalls())
+func (mock *PermissionsStoreMock) DeletePolicyCalls() []struct {
+	Ctx       context.Context
+	ID        string
+	DeletedBy string
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx       context.Context
+		ID        string
+		DeletedBy string
 	}
-	mock.lockGetAllRoles.RLock()
-	calls = mock.calls.GetAllRoles
-	mock.lockGetAllRoles.RUnlock()
+	mock.lockDeletePolicy.RLock()
+	calls = mock.calls.DeletePolicy
+	mock.lockDeletePolicy.RUnlock()
 	return calls
 }
 
-// GetPolicy calls GetPolicyFunc.
-func (mock *PermissionsStoreMock) GetPolicy(ctx context.Context, id string) (*models.Policy, error) {
-	if mock.GetPolicyFunc == nil {
-		panic("PermissionsStoreMock.GetPolicyFunc: method is nil but PermissionsStore.GetPolicy was just called")
+// DeleteRole calls DeleteRoleFunc.
+func (mock *PermissionsStoreMock) DeleteRole(ctx context.Context, id string) error {
+	if mock.DeleteRoleFunc == nil {
+		panic("PermissionsStoreMock.DeleteRoleFunc: method is nil but PermissionsStore.DeleteRole was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -385,17 +812,17 @@ func (mock *PermissionsStoreMock) GetPolicy(ctx context.Context, id string) (*mo
 		Ctx: ctx,
 		ID:  id,
 	}
-	mock.lockGetPolicy.Lock()
-	mock.calls.GetPolicy = append(mock.calls.GetPolicy, callInfo)
-	mock.lockGetPolicy.Unlock()
-	return mock.GetPolicyFunc(ctx, id)
+	mock.lockDeleteRole.Lock()
+	mock.calls.DeleteRole = append(mock.calls.DeleteRole, callInfo)
+	mock.lockDeleteRole.Unlock()
+	return mock.DeleteRoleFunc(ctx, id)
 }
 
-// GetPolicyCalls gets all the calls that were made to GetPolicy.
+// DeleteRoleCalls gets all the calls that were made to DeleteRole.
 // Check the length with:
 //
-//	len(mockedPermissionsStore.GetPolicyCalls())
-func (mock *PermissionsStoreMock) GetPolicyCalls() []struct {
+//	len(mockedPermissionsStore.DeleteRoleCalls())
+func (mock *PermissionsStoreMock) DeleteRoleCalls() []struct {
 	Ctx context.Context
 	ID  string
 } {
@@ -403,9 +830,381 @@ func (mock *PermissionsStoreMock) GetPolicyCalls() []struct {
 		Ctx context.Context
 		ID  string
 	}
-	mock.lockGetPolicy.RLock()
-	calls = mock.calls.GetPolicy
-	mock.lockGetPolicy.RUnlock()
+	mock.lockDeleteRole.RLock()
+	calls = mock.calls.DeleteRole
+	mock.lockDeleteRole.RUnlock()
+	return calls
+}
+
+// FindRoles calls FindRolesFunc.
+func (mock *PermissionsStoreMock) FindRoles(ctx context.Context, filter models.RoleFilter, offset int, limit int) (*models.Roles, error) {
+	if mock.FindRolesFunc == nil {
+		panic("PermissionsStoreMock.FindRolesFunc: method is nil but PermissionsStore.FindRoles was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Filter models.RoleFilter
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Filter: filter,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockFindRoles.Lock()
+	mock.calls.FindRoles = append(mock.calls.FindRoles, callInfo)
+	mock.lockFindRoles.Unlock()
+	return mock.FindRolesFunc(ctx, filter, offset, limit)
+}
+
+// FindRolesCalls gets all the calls that were made to FindRoles.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.FindRolesCalls())
+func (mock *PermissionsStoreMock) FindRolesCalls() []struct {
+	Ctx    context.Context
+	Filter models.RoleFilter
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Filter models.RoleFilter
+		Offset int
+		Limit  int
+	}
+	mock.lockFindRoles.RLock()
+	calls = mock.calls.FindRoles
+	mock.lockFindRoles.RUnlock()
+	return calls
+}
+
+// GetAllBundlePolicies calls GetAllBundlePoliciesFunc.
+func (mock *PermissionsStoreMock) GetAllBundlePolicies(ctx context.Context) ([]*models.BundlePolicy, error) {
+	if mock.GetAllBundlePoliciesFunc == nil {
+		panic("PermissionsStoreMock.GetAllBundlePoliciesFunc: method is nil but PermissionsStore.GetAllBundlePolicies was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllBundlePolicies.Lock()
+	mock.calls.GetAllBundlePolicies = append(mock.calls.GetAllBundlePolicies, callInfo)
+	mock.lockGetAllBundlePolicies.Unlock()
+	return mock.GetAllBundlePoliciesFunc(ctx)
+}
+
+// GetAllBundlePoliciesCalls gets all the calls that were made to GetAllBundlePolicies.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetAllBundlePoliciesCalls())
+func (mock *PermissionsStoreMock) GetAllBundlePoliciesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllBundlePolicies.RLock()
+	calls = mock.calls.GetAllBundlePolicies
+	mock.lockGetAllBundlePolicies.RUnlock()
+	return calls
+}
+
+// GetAllPermissions calls GetAllPermissionsFunc.
+func (mock *PermissionsStoreMock) GetAllPermissions(ctx context.Context) ([]*models.Permission, error) {
+	if mock.GetAllPermissionsFunc == nil {
+		panic("PermissionsStoreMock.GetAllPermissionsFunc: method is nil but PermissionsStore.GetAllPermissions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllPermissions.Lock()
+	mock.calls.GetAllPermissions = append(mock.calls.GetAllPermissions, callInfo)
+	mock.lockGetAllPermissions.Unlock()
+	return mock.GetAllPermissionsFunc(ctx)
+}
+
+// GetAllPermissionsCalls gets all the calls that were made to GetAllPermissions.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetAllPermissionsCalls())
+func (mock *PermissionsStoreMock) GetAllPermissionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllPermissions.RLock()
+	calls = mock.calls.GetAllPermissions
+	mock.lockGetAllPermissions.RUnlock()
+	return calls
+}
+
+// GetAllRoles calls GetAllRolesFunc.
+func (mock *PermissionsStoreMock) GetAllRoles(ctx context.Context) ([]*models.Role, error) {
+	if mock.GetAllRolesFunc == nil {
+		panic("PermissionsStoreMock.GetAllRolesFunc: method is nil but PermissionsStore.GetAllRoles was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllRoles.Lock()
+	mock.calls.GetAllRoles = append(mock.calls.GetAllRoles, callInfo)
+	mock.lockGetAllRoles.Unlock()
+	return mock.GetAllRolesFunc(ctx)
+}
+
+// GetAllRolesCalls gets all the calls that were made to GetAllRoles.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetAllRolesCalls())
+func (mock *PermissionsStoreMock) GetAllRolesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllRoles.RLock()
+	calls = mock.calls.GetAllRoles
+	mock.lockGetAllRoles.RUnlock()
+	return calls
+}
+
+// GetDeletedPolicies calls GetDeletedPoliciesFunc.
+func (mock *PermissionsStoreMock) GetDeletedPolicies(ctx context.Context, offset int, limit int) (*models.Policies, error) {
+	if mock.GetDeletedPoliciesFunc == nil {
+		panic("PermissionsStoreMock.GetDeletedPoliciesFunc: method is nil but PermissionsStore.GetDeletedPolicies was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetDeletedPolicies.Lock()
+	mock.calls.GetDeletedPolicies = append(mock.calls.GetDeletedPolicies, callInfo)
+	mock.lockGetDeletedPolicies.Unlock()
+	return mock.GetDeletedPoliciesFunc(ctx, offset, limit)
+}
+
+// GetDeletedPoliciesCalls gets all the calls that were made to GetDeletedPolicies.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetDeletedPoliciesCalls())
+func (mock *PermissionsStoreMock) GetDeletedPoliciesCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockGetDeletedPolicies.RLock()
+	calls = mock.calls.GetDeletedPolicies
+	mock.lockGetDeletedPolicies.RUnlock()
+	return calls
+}
+
+// GetDeletedPolicy calls GetDeletedPolicyFunc.
+func (mock *PermissionsStoreMock) GetDeletedPolicy(ctx context.Context, id string) (*models.Policy, error) {
+	if mock.GetDeletedPolicyFunc == nil {
+		panic("PermissionsStoreMock.GetDeletedPolicyFunc: method is nil but PermissionsStore.GetDeletedPolicy was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetDeletedPolicy.Lock()
+	mock.calls.GetDeletedPolicy = append(mock.calls.GetDeletedPolicy, callInfo)
+	mock.lockGetDeletedPolicy.Unlock()
+	return mock.GetDeletedPolicyFunc(ctx, id)
+}
+
+// GetDeletedPolicyCalls gets all the calls that were made to GetDeletedPolicy.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetDeletedPolicyCalls())
+func (mock *PermissionsStoreMock) GetDeletedPolicyCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetDeletedPolicy.RLock()
+	calls = mock.calls.GetDeletedPolicy
+	mock.lockGetDeletedPolicy.RUnlock()
+	return calls
+}
+
+// GetPermission calls GetPermissionFunc.
+func (mock *PermissionsStoreMock) GetPermission(ctx context.Context, id string) (*models.Permission, error) {
+	if mock.GetPermissionFunc == nil {
+		panic("PermissionsStoreMock.GetPermissionFunc: method is nil but PermissionsStore.GetPermission was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetPermission.Lock()
+	mock.calls.GetPermission = append(mock.calls.GetPermission, callInfo)
+	mock.lockGetPermission.Unlock()
+	return mock.GetPermissionFunc(ctx, id)
+}
+
+// GetPermissionCalls gets all the calls that were made to GetPermission.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetPermissionCalls())
+func (mock *PermissionsStoreMock) GetPermissionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetPermission.RLock()
+	calls = mock.calls.GetPermission
+	mock.lockGetPermission.RUnlock()
+	return calls
+}
+
+// GetPermissions calls GetPermissionsFunc.
+func (mock *PermissionsStoreMock) GetPermissions(ctx context.Context, offset int, limit int) (*models.Permissions, error) {
+	if mock.GetPermissionsFunc == nil {
+		panic("PermissionsStoreMock.GetPermissionsFunc: method is nil but PermissionsStore.GetPermissions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetPermissions.Lock()
+	mock.calls.GetPermissions = append(mock.calls.GetPermissions, callInfo)
+	mock.lockGetPermissions.Unlock()
+	return mock.GetPermissionsFunc(ctx, offset, limit)
+}
+
+// GetPermissionsCalls gets all the calls that were made to GetPermissions.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetPermissionsCalls())
+func (mock *PermissionsStoreMock) GetPermissionsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockGetPermissions.RLock()
+	calls = mock.calls.GetPermissions
+	mock.lockGetPermissions.RUnlock()
+	return calls
+}
+
+// GetPoliciesByRole calls GetPoliciesByRoleFunc.
+func (mock *PermissionsStoreMock) GetPoliciesByRole(ctx context.Context, roleID string, offset int, limit int) (*models.Policies, error) {
+	if mock.GetPoliciesByRoleFunc == nil {
+		panic("PermissionsStoreMock.GetPoliciesByRoleFunc: method is nil but PermissionsStore.GetPoliciesByRole was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		RoleID string
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		RoleID: roleID,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetPoliciesByRole.Lock()
+	mock.calls.GetPoliciesByRole = append(mock.calls.GetPoliciesByRole, callInfo)
+	mock.lockGetPoliciesByRole.Unlock()
+	return mock.GetPoliciesByRoleFunc(ctx, roleID, offset, limit)
+}
+
+// GetPoliciesByRoleCalls gets all the calls that were made to GetPoliciesByRole.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetPoliciesByRoleCalls())
+func (mock *PermissionsStoreMock) GetPoliciesByRoleCalls() []struct {
+	Ctx    context.Context
+	RoleID string
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		RoleID string
+		Offset int
+		Limit  int
+	}
+	mock.lockGetPoliciesByRole.RLock()
+	calls = mock.calls.GetPoliciesByRole
+	mock.lockGetPoliciesByRole.RUnlock()
+	return calls
+}
+
+// GetPolicy calls GetPolicyFunc.
+func (mock *PermissionsStoreMock) GetPolicy(ctx context.Context, id string) (*models.Policy, error) {
+	if mock.GetPolicyFunc == nil {
+		panic("PermissionsStoreMock.GetPolicyFunc: method is nil but PermissionsStore.GetPolicy was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetPolicy.Lock()
+	mock.calls.GetPolicy = append(mock.calls.GetPolicy, callInfo)
+	mock.lockGetPolicy.Unlock()
+	return mock.GetPolicyFunc(ctx, id)
+}
+
+// GetPolicyCalls gets all the calls that were made to GetPolicy.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetPolicyCalls())
+func (mock *PermissionsStoreMock) GetPolicyCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetPolicy.RLock()
+	calls = mock.calls.GetPolicy
+	mock.lockGetPolicy.RUnlock()
 	return calls
 }
 
@@ -485,6 +1284,314 @@ func (mock *PermissionsStoreMock) GetRolesCalls() []struct {
 	return calls
 }
 
+// GetRolesGrantingPermission calls GetRolesGrantingPermissionFunc.
+func (mock *PermissionsStoreMock) GetRolesGrantingPermission(ctx context.Context, permission string) ([]*models.Role, error) {
+	if mock.GetRolesGrantingPermissionFunc == nil {
+		panic("PermissionsStoreMock.GetRolesGrantingPermissionFunc: method is nil but PermissionsStore.GetRolesGrantingPermission was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Permission string
+	}{
+		Ctx:        ctx,
+		Permission: permission,
+	}
+	mock.lockGetRolesGrantingPermission.Lock()
+	mock.calls.GetRolesGrantingPermission = append(mock.calls.GetRolesGrantingPermission, callInfo)
+	mock.lockGetRolesGrantingPermission.Unlock()
+	return mock.GetRolesGrantingPermissionFunc(ctx, permission)
+}
+
+// GetRolesGrantingPermissionCalls gets all the calls that were made to GetRolesGrantingPermission.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetRolesGrantingPermissionCalls())
+func (mock *PermissionsStoreMock) GetRolesGrantingPermissionCalls() []struct {
+	Ctx        context.Context
+	Permission string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Permission string
+	}
+	mock.lockGetRolesGrantingPermission.RLock()
+	calls = mock.calls.GetRolesGrantingPermission
+	mock.lockGetRolesGrantingPermission.RUnlock()
+	return calls
+}
+
+// GetRolesWithDeprecatedPermissions calls GetRolesWithDeprecatedPermissionsFunc.
+func (mock *PermissionsStoreMock) GetRolesWithDeprecatedPermissions(ctx context.Context, offset int, limit int) (*models.Roles, error) {
+	if mock.GetRolesWithDeprecatedPermissionsFunc == nil {
+		panic("PermissionsStoreMock.GetRolesWithDeprecatedPermissionsFunc: method is nil but PermissionsStore.GetRolesWithDeprecatedPermissions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockGetRolesWithDeprecatedPermissions.Lock()
+	mock.calls.GetRolesWithDeprecatedPermissions = append(mock.calls.GetRolesWithDeprecatedPermissions, callInfo)
+	mock.lockGetRolesWithDeprecatedPermissions.Unlock()
+	return mock.GetRolesWithDeprecatedPermissionsFunc(ctx, offset, limit)
+}
+
+// GetRolesWithDeprecatedPermissionsCalls gets all the calls that were made to GetRolesWithDeprecatedPermissions.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetRolesWithDeprecatedPermissionsCalls())
+func (mock *PermissionsStoreMock) GetRolesWithDeprecatedPermissionsCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockGetRolesWithDeprecatedPermissions.RLock()
+	calls = mock.calls.GetRolesWithDeprecatedPermissions
+	mock.lockGetRolesWithDeprecatedPermissions.RUnlock()
+	return calls
+}
+
+// GetUnknownPermissions calls GetUnknownPermissionsFunc.
+func (mock *PermissionsStoreMock) GetUnknownPermissions(ctx context.Context, permissions []string) ([]string, error) {
+	if mock.GetUnknownPermissionsFunc == nil {
+		panic("PermissionsStoreMock.GetUnknownPermissionsFunc: method is nil but PermissionsStore.GetUnknownPermissions was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Permissions []string
+	}{
+		Ctx:         ctx,
+		Permissions: permissions,
+	}
+	mock.lockGetUnknownPermissions.Lock()
+	mock.calls.GetUnknownPermissions = append(mock.calls.GetUnknownPermissions, callInfo)
+	mock.lockGetUnknownPermissions.Unlock()
+	return mock.GetUnknownPermissionsFunc(ctx, permissions)
+}
+
+// GetUnknownPermissionsCalls gets all the calls that were made to GetUnknownPermissions.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.GetUnknownPermissionsCalls())
+func (mock *PermissionsStoreMock) GetUnknownPermissionsCalls() []struct {
+	Ctx         context.Context
+	Permissions []string
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Permissions []string
+	}
+	mock.lockGetUnknownPermissions.RLock()
+	calls = mock.calls.GetUnknownPermissions
+	mock.lockGetUnknownPermissions.RUnlock()
+	return calls
+}
+
+// PurgeDeletedPolicies calls PurgeDeletedPoliciesFunc.
+func (mock *PermissionsStoreMock) PurgeDeletedPolicies(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	if mock.PurgeDeletedPoliciesFunc == nil {
+		panic("PermissionsStoreMock.PurgeDeletedPoliciesFunc: method is nil but PermissionsStore.PurgeDeletedPolicies was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}{
+		Ctx:           ctx,
+		DeletedBefore: deletedBefore,
+	}
+	mock.lockPurgeDeletedPolicies.Lock()
+	mock.calls.PurgeDeletedPolicies = append(mock.calls.PurgeDeletedPolicies, callInfo)
+	mock.lockPurgeDeletedPolicies.Unlock()
+	return mock.PurgeDeletedPoliciesFunc(ctx, deletedBefore)
+}
+
+// PurgeDeletedPoliciesCalls gets all the calls that were made to PurgeDeletedPolicies.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.PurgeDeletedPoliciesCalls())
+func (mock *PermissionsStoreMock) PurgeDeletedPoliciesCalls() []struct {
+	Ctx           context.Context
+	DeletedBefore time.Time
+} {
+	var calls []struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+	}
+	mock.lockPurgeDeletedPolicies.RLock()
+	calls = mock.calls.PurgeDeletedPolicies
+	mock.lockPurgeDeletedPolicies.RUnlock()
+	return calls
+}
+
+// RemovePolicyEntity calls RemovePolicyEntityFunc.
+func (mock *PermissionsStoreMock) RemovePolicyEntity(ctx context.Context, policyID string, entity string, updatedBy string) (bool, error) {
+	if mock.RemovePolicyEntityFunc == nil {
+		panic("PermissionsStoreMock.RemovePolicyEntityFunc: method is nil but PermissionsStore.RemovePolicyEntity was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		PolicyID  string
+		Entity    string
+		UpdatedBy string
+	}{
+		Ctx:       ctx,
+		PolicyID:  policyID,
+		Entity:    entity,
+		UpdatedBy: updatedBy,
+	}
+	mock.lockRemovePolicyEntity.Lock()
+	mock.calls.RemovePolicyEntity = append(mock.calls.RemovePolicyEntity, callInfo)
+	mock.lockRemovePolicyEntity.Unlock()
+	return mock.RemovePolicyEntityFunc(ctx, policyID, entity, updatedBy)
+}
+
+// RemovePolicyEntityCalls gets all the calls that were made to RemovePolicyEntity.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.RemovePolicyEntityCalls())
+func (mock *PermissionsStoreMock) RemovePolicyEntityCalls() []struct {
+	Ctx       context.Context
+	PolicyID  string
+	Entity    string
+	UpdatedBy string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		PolicyID  string
+		Entity    string
+		UpdatedBy string
+	}
+	mock.lockRemovePolicyEntity.RLock()
+	calls = mock.calls.RemovePolicyEntity
+	mock.lockRemovePolicyEntity.RUnlock()
+	return calls
+}
+
+// ReplacePolicy calls ReplacePolicyFunc.
+func (mock *PermissionsStoreMock) ReplacePolicy(ctx context.Context, policy *models.Policy) error {
+	if mock.ReplacePolicyFunc == nil {
+		panic("PermissionsStoreMock.ReplacePolicyFunc: method is nil but PermissionsStore.ReplacePolicy was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Policy *models.Policy
+	}{
+		Ctx:    ctx,
+		Policy: policy,
+	}
+	mock.lockReplacePolicy.Lock()
+	mock.calls.ReplacePolicy = append(mock.calls.ReplacePolicy, callInfo)
+	mock.lockReplacePolicy.Unlock()
+	return mock.ReplacePolicyFunc(ctx, policy)
+}
+
+// ReplacePolicyCalls gets all the calls that were made to ReplacePolicy.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.ReplacePolicyCalls())
+func (mock *PermissionsStoreMock) ReplacePolicyCalls() []struct {
+	Ctx    context.Context
+	Policy *models.Policy
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Policy *models.Policy
+	}
+	mock.lockReplacePolicy.RLock()
+	calls = mock.calls.ReplacePolicy
+	mock.lockReplacePolicy.RUnlock()
+	return calls
+}
+
+// ReplacePolicyIfUnmodified calls ReplacePolicyIfUnmodifiedFunc.
+func (mock *PermissionsStoreMock) ReplacePolicyIfUnmodified(ctx context.Context, policy *models.Policy, version int) error {
+	if mock.ReplacePolicyIfUnmodifiedFunc == nil {
+		panic("PermissionsStoreMock.ReplacePolicyIfUnmodifiedFunc: method is nil but PermissionsStore.ReplacePolicyIfUnmodified was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Policy  *models.Policy
+		Version int
+	}{
+		Ctx:     ctx,
+		Policy:  policy,
+		Version: version,
+	}
+	mock.lockReplacePolicyIfUnmodified.Lock()
+	mock.calls.ReplacePolicyIfUnmodified = append(mock.calls.ReplacePolicyIfUnmodified, callInfo)
+	mock.lockReplacePolicyIfUnmodified.Unlock()
+	return mock.ReplacePolicyIfUnmodifiedFunc(ctx, policy, version)
+}
+
+// ReplacePolicyIfUnmodifiedCalls gets all the calls that were made to ReplacePolicyIfUnmodified.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.ReplacePolicyIfUnmodifiedCalls())
+func (mock *PermissionsStoreMock) ReplacePolicyIfUnmodifiedCalls() []struct {
+	Ctx     context.Context
+	Policy  *models.Policy
+	Version int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Policy  *models.Policy
+		Version int
+	}
+	mock.lockReplacePolicyIfUnmodified.RLock()
+	calls = mock.calls.ReplacePolicyIfUnmodified
+	mock.lockReplacePolicyIfUnmodified.RUnlock()
+	return calls
+}
+
+// RestorePolicy calls RestorePolicyFunc.
+func (mock *PermissionsStoreMock) RestorePolicy(ctx context.Context, id string, updatedBy string) error {
+	if mock.RestorePolicyFunc == nil {
+		panic("PermissionsStoreMock.RestorePolicyFunc: method is nil but PermissionsStore.RestorePolicy was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ID        string
+		UpdatedBy string
+	}{
+		Ctx:       ctx,
+		ID:        id,
+		UpdatedBy: updatedBy,
+	}
+	mock.lockRestorePolicy.Lock()
+	mock.calls.RestorePolicy = append(mock.calls.RestorePolicy, callInfo)
+	mock.lockRestorePolicy.Unlock()
+	return mock.RestorePolicyFunc(ctx, id, updatedBy)
+}
+
+// RestorePolicyCalls gets all the calls that were made to RestorePolicy.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.RestorePolicyCalls())
+func (mock *PermissionsStoreMock) RestorePolicyCalls() []struct {
+	Ctx       context.Context
+	ID        string
+	UpdatedBy string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ID        string
+		UpdatedBy string
+	}
+	mock.lockRestorePolicy.RLock()
+	calls = mock.calls.RestorePolicy
+	mock.lockRestorePolicy.RUnlock()
+	return calls
+}
+
 // UpdatePolicy calls UpdatePolicyFunc.
 func (mock *PermissionsStoreMock) UpdatePolicy(ctx context.Context, policy *models.Policy) (*models.UpdateResult, error) {
 	if mock.UpdatePolicyFunc == nil {
@@ -520,3 +1627,75 @@ func (mock *PermissionsStoreMock) UpdatePolicyCalls() []struct {
 	mock.lockUpdatePolicy.RUnlock()
 	return calls
 }
+
+// UpdateRole calls UpdateRoleFunc.
+func (mock *PermissionsStoreMock) UpdateRole(ctx context.Context, role *models.Role) error {
+	if mock.UpdateRoleFunc == nil {
+		panic("PermissionsStoreMock.UpdateRoleFunc: method is nil but PermissionsStore.UpdateRole was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Role *models.Role
+	}{
+		Ctx:  ctx,
+		Role: role,
+	}
+	mock.lockUpdateRole.Lock()
+	mock.calls.UpdateRole = append(mock.calls.UpdateRole, callInfo)
+	mock.lockUpdateRole.Unlock()
+	return mock.UpdateRoleFunc(ctx, role)
+}
+
+// UpdateRoleCalls gets all the calls that were made to UpdateRole.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.UpdateRoleCalls())
+func (mock *PermissionsStoreMock) UpdateRoleCalls() []struct {
+	Ctx  context.Context
+	Role *models.Role
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Role *models.Role
+	}
+	mock.lockUpdateRole.RLock()
+	calls = mock.calls.UpdateRole
+	mock.lockUpdateRole.RUnlock()
+	return calls
+}
+
+// UpsertPermission calls UpsertPermissionFunc.
+func (mock *PermissionsStoreMock) UpsertPermission(ctx context.Context, permission *models.Permission) (*models.UpdateResult, error) {
+	if mock.UpsertPermissionFunc == nil {
+		panic("PermissionsStoreMock.UpsertPermissionFunc: method is nil but PermissionsStore.UpsertPermission was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Permission *models.Permission
+	}{
+		Ctx:        ctx,
+		Permission: permission,
+	}
+	mock.lockUpsertPermission.Lock()
+	mock.calls.UpsertPermission = append(mock.calls.UpsertPermission, callInfo)
+	mock.lockUpsertPermission.Unlock()
+	return mock.UpsertPermissionFunc(ctx, permission)
+}
+
+// UpsertPermissionCalls gets all the calls that were made to UpsertPermission.
+// Check the length with:
+//
+//	len(mockedPermissionsStore.UpsertPermissionCalls())
+func (mock *PermissionsStoreMock) UpsertPermissionCalls() []struct {
+	Ctx        context.Context
+	Permission *models.Permission
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Permission *models.Permission
+	}
+	mock.lockUpsertPermission.RLock()
+	calls = mock.calls.UpsertPermission
+	mock.lockUpsertPermission.RUnlock()
+	return calls
+}
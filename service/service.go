@@ -3,7 +3,12 @@ package service
 import (
 	"context"
 
+	"github.com/ONSdigital/dp-permissions-api/entities"
+	"github.com/ONSdigital/dp-permissions-api/metrics"
 	"github.com/ONSdigital/dp-permissions-api/permissions"
+	"github.com/ONSdigital/dp-permissions-api/purge"
+	"github.com/ONSdigital/dp-permissions-api/signing"
+	"github.com/ONSdigital/dp-permissions-api/tracing"
 
 	"github.com/ONSdigital/dp-permissions-api/api"
 	"github.com/ONSdigital/dp-permissions-api/config"
@@ -12,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-authorisation/v2/zebedeeclient"
 )
 
 // Service contains all the configs, server and clients to run the dp-topic-api API
@@ -24,6 +30,8 @@ type Service struct {
 	HealthCheck             HealthChecker
 	MongoDB                 PermissionsStore
 	AuthorisationMiddleware authorisation.Middleware
+	purger                  *purge.Purger
+	shutdownTracing         func(context.Context) error
 }
 
 // Run the service
@@ -31,10 +39,18 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 	log.Info(ctx, "running service")
 	log.Info(ctx, "using service configuration", log.Data{"config": cfg})
 
+	// Set up tracing, which is a no-op unless open telemetry is enabled
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		log.Fatal(ctx, "failed to set up open telemetry tracing", err)
+		return nil, err
+	}
+
 	// Get HTTP Server and ... // ADD CODE: Add any middleware that your service requires
 	r := mux.NewRouter()
+	r.Use(tracing.Middleware)
 
-	s := serviceList.GetHTTPServer(cfg.BindAddr, r)
+	s := serviceList.GetHTTPServer(cfg.BindAddr, metrics.Middleware(r))
 
 	// Get MongoDB client
 	mongoDB, err := serviceList.GetMongoDB(ctx, cfg)
@@ -52,8 +68,28 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		return nil, err
 	}
 
+	var bundleSigner api.BundleSigner
+	if cfg.BundleSigningKey != "" {
+		bundleSigner, err = signing.NewSigner(cfg.BundleSigningKeyID, cfg.BundleSigningKey)
+		if err != nil {
+			log.Fatal(ctx, "could not instantiate bundle signer", err)
+			return nil, err
+		}
+	}
+
+	var entityResolver api.EntityResolver
+	if cfg.IdentityAPIURL != "" {
+		entityResolver = entities.NewIdentityResolver(cfg.IdentityAPIURL, cfg.ServiceAuthToken, cfg.ServiceEntities)
+	}
+
+	// Service auth tokens are only identified when authorisation is enabled, as the middleware does not parse them otherwise
+	var serviceTokenChecker api.ServiceTokenChecker
+	if cfg.AuthorisationConfig.Enabled {
+		serviceTokenChecker = zebedeeclient.NewZebedeeClient(cfg.AuthorisationConfig.ZebedeeURL)
+	}
+
 	// Setup the API
-	a := api.Setup(cfg, r, mongoDB, bundler, authorisationMiddleware)
+	a := api.Setup(cfg, r, mongoDB, bundler, bundleSigner, entityResolver, serviceTokenChecker, authorisationMiddleware)
 
 	hc, err := serviceList.GetHealthCheck(cfg, buildTime, gitCommit, version)
 
@@ -66,7 +102,15 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		return nil, errors.Wrap(err, "unable to register checkers")
 	}
 
+	// Purge the deleted policies after the retention period, unless they are kept forever
+	var purger *purge.Purger
+	if cfg.PolicyRetentionPeriod > 0 {
+		purger = purge.NewPurger(mongoDB, cfg.PolicyRetentionPeriod, cfg.PolicyPurgeInterval)
+		purger.Start(ctx)
+	}
+
 	r.StrictSlash(true).Path("/health").HandlerFunc(hc.Handler)
+	r.StrictSlash(true).Path("/metrics").Handler(metrics.Handler())
 	hc.Start(ctx)
 
 	// Run the http server in a new go-routine
@@ -85,6 +129,8 @@ func Run(ctx context.Context, cfg *config.Config, serviceList *ExternalServiceLi
 		Server:                  s,
 		MongoDB:                 mongoDB,
 		AuthorisationMiddleware: authorisationMiddleware,
+		purger:                  purger,
+		shutdownTracing:         shutdownTracing,
 	}, nil
 }
 
@@ -112,6 +158,11 @@ func (svc *Service) Close(ctx context.Context) error {
 			hasShutdownError = true
 		}
 
+		// stop purging before closing the connection to mongo db
+		if svc.purger != nil {
+			svc.purger.Stop()
+		}
+
 		if svc.ServiceList.MongoDB {
 			if err := svc.MongoDB.Close(ctx); err != nil {
 				log.Error(ctx, "error closing mongo db", err)
@@ -125,6 +176,14 @@ func (svc *Service) Close(ctx context.Context) error {
 				hasShutdownError = true
 			}
 		}
+
+		// flush any buffered spans last, so that spans from the shutdown of other dependencies are exported
+		if svc.shutdownTracing != nil {
+			if err := svc.shutdownTracing(ctx); err != nil {
+				log.Error(ctx, "failed to shutdown open telemetry tracing", err)
+				hasShutdownError = true
+			}
+		}
 	}()
 
 	// wait for shutdown success (via cancel) or failure (timeout)
@@ -0,0 +1,101 @@
+// Package signing provides detached signatures for permissions bundles, allowing consumers to verify that a bundle
+// was produced by the permissions API even when it has been cached or passed on by an intermediary.
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+)
+
+// A list of errors returned from package
+var (
+	ErrInvalidSigningKey = errors.New("bundle signing key must be a base64 encoded PKCS #8 Ed25519 private key")
+)
+
+// Signer signs serialised permissions bundles with an Ed25519 private key
+type Signer struct {
+	keyID      string
+	privateKey ed25519.PrivateKey
+}
+
+// NewSigner creates a new Signer from a base64 encoded PKCS #8 Ed25519 private key.
+// If keyID is empty, the RFC 7638 thumbprint of the public key is used instead.
+func NewSigner(keyID, encodedPrivateKey string) (*Signer, error) {
+	der, err := base64.StdEncoding.DecodeString(encodedPrivateKey)
+	if err != nil {
+		return nil, ErrInvalidSigningKey
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, ErrInvalidSigningKey
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidSigningKey
+	}
+
+	s := &Signer{
+		keyID:      keyID,
+		privateKey: privateKey,
+	}
+	if s.keyID == "" {
+		s.keyID = thumbprint(s.publicKey())
+	}
+
+	return s, nil
+}
+
+// KeyID returns the ID of the key used to sign bundles
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign returns the base64url encoded detached signature of the given payload
+func (s *Signer) Sign(payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(ed25519.Sign(s.privateKey, payload))
+}
+
+// JWKS returns the key set containing the public key that verifies signatures created by this Signer
+func (s *Signer) JWKS() models.JSONWebKeySet {
+	return models.JSONWebKeySet{
+		Keys: []models.JSONWebKey{
+			{
+				KeyType:   models.KeyTypeOctetKeyPair,
+				Curve:     models.CurveEd25519,
+				KeyID:     s.keyID,
+				Use:       models.KeyUseSignature,
+				Algorithm: models.AlgorithmEdDSA,
+				X:         base64.RawURLEncoding.EncodeToString(s.publicKey()),
+			},
+		},
+	}
+}
+
+func (s *Signer) publicKey() ed25519.PublicKey {
+	return s.privateKey.Public().(ed25519.PublicKey)
+}
+
+// thumbprint computes the RFC 7638 JWK thumbprint of an Ed25519 public key
+func thumbprint(publicKey ed25519.PublicKey) string {
+	// the members must be in lexicographic order, which the struct field order guarantees
+	b, _ := json.Marshal(struct {
+		Crv string `json:"crv"`
+		Kty string `json:"kty"`
+		X   string `json:"x"`
+	}{
+		Crv: models.CurveEd25519,
+		Kty: models.KeyTypeOctetKeyPair,
+		X:   base64.RawURLEncoding.EncodeToString(publicKey),
+	})
+
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
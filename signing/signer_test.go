@@ -0,0 +1,81 @@
+package signing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/models"
+	"github.com/ONSdigital/dp-permissions-api/signing"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSigner(t *testing.T) {
+	Convey("Given a base64 encoded PKCS #8 Ed25519 private key", t, func() {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		So(err, ShouldBeNil)
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		So(err, ShouldBeNil)
+		encodedKey := base64.StdEncoding.EncodeToString(der)
+
+		Convey("When NewSigner is called with a key ID", func() {
+			signer, err := signing.NewSigner("key-1", encodedKey)
+			So(err, ShouldBeNil)
+
+			Convey("Then the configured key ID is used", func() {
+				So(signer.KeyID(), ShouldEqual, "key-1")
+			})
+
+			Convey("Then the signature verifies against the public key", func() {
+				payload := []byte(`{"legacy:read":{}}`)
+				sig, err := base64.RawURLEncoding.DecodeString(signer.Sign(payload))
+				So(err, ShouldBeNil)
+				So(ed25519.Verify(publicKey, payload, sig), ShouldBeTrue)
+				So(ed25519.Verify(publicKey, []byte(`{}`), sig), ShouldBeFalse)
+			})
+
+			Convey("Then the JWKS contains the public key", func() {
+				jwks := signer.JWKS()
+				So(jwks.Keys, ShouldHaveLength, 1)
+				So(jwks.Keys[0].KeyID, ShouldEqual, "key-1")
+				So(jwks.Keys[0].KeyType, ShouldEqual, models.KeyTypeOctetKeyPair)
+				So(jwks.Keys[0].Curve, ShouldEqual, models.CurveEd25519)
+				So(jwks.Keys[0].Algorithm, ShouldEqual, models.AlgorithmEdDSA)
+				So(jwks.Keys[0].X, ShouldEqual, base64.RawURLEncoding.EncodeToString(publicKey))
+			})
+		})
+
+		Convey("When NewSigner is called without a key ID", func() {
+			signer, err := signing.NewSigner("", encodedKey)
+			So(err, ShouldBeNil)
+
+			Convey("Then the key ID is derived from the public key", func() {
+				So(signer.KeyID(), ShouldNotBeEmpty)
+				other, err := signing.NewSigner("", encodedKey)
+				So(err, ShouldBeNil)
+				So(other.KeyID(), ShouldEqual, signer.KeyID())
+			})
+		})
+	})
+
+	Convey("Given an invalid signing key", t, func() {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+		So(err, ShouldBeNil)
+
+		Convey("Then NewSigner returns an error", func() {
+			_, err = signing.NewSigner("", "not base64!")
+			So(err, ShouldEqual, signing.ErrInvalidSigningKey)
+
+			_, err = signing.NewSigner("", base64.StdEncoding.EncodeToString([]byte("not a key")))
+			So(err, ShouldEqual, signing.ErrInvalidSigningKey)
+
+			_, err = signing.NewSigner("", base64.StdEncoding.EncodeToString(rsaDER))
+			So(err, ShouldEqual, signing.ErrInvalidSigningKey)
+		})
+	})
+}
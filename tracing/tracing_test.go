@@ -0,0 +1,116 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-permissions-api/config"
+	"github.com/ONSdigital/dp-permissions-api/tracing"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentID    = "00f067aa0ba902b7"
+	testTraceParent = "00-" + testTraceID + "-" + testParentID + "-01"
+)
+
+func setupSpanRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+func TestSetup(t *testing.T) {
+	Convey("Given a configuration with open telemetry disabled", t, func() {
+		cfg := &config.Config{OtelEnabled: false}
+
+		Convey("When tracing is set up", func() {
+			shutdown, err := tracing.Setup(context.Background(), cfg)
+
+			Convey("Then no error is returned and the shutdown function does nothing", func() {
+				So(err, ShouldBeNil)
+				So(shutdown(context.Background()), ShouldBeNil)
+			})
+
+			Convey("Then the W3C trace context is propagated", func() {
+				So(otel.GetTextMapPropagator().Fields(), ShouldContain, "traceparent")
+			})
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router instrumented with the tracing middleware and a recording tracer provider", t, func() {
+		ctx := context.Background()
+		_, err := tracing.Setup(ctx, &config.Config{})
+		So(err, ShouldBeNil)
+		recorder := setupSpanRecorder()
+
+		r := mux.NewRouter()
+		r.Use(tracing.Middleware)
+		r.HandleFunc("/v1/policies/{id}", func(w http.ResponseWriter, req *http.Request) {
+			_, span := tracing.Start(req.Context(), "child")
+			span.End()
+			w.WriteHeader(http.StatusInternalServerError)
+		}).Methods(http.MethodGet)
+
+		Convey("When a request with a propagated trace context is made", func() {
+			req := httptest.NewRequest(http.MethodGet, "/v1/policies/policy1", http.NoBody)
+			req.Header.Set("traceparent", testTraceParent)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			So(spans, ShouldHaveLength, 2)
+			child, server := spans[0], spans[1]
+
+			Convey("Then a server span named after the route template is created in the propagated trace", func() {
+				So(server.Name(), ShouldEqual, "GET /v1/policies/{id}")
+				So(server.SpanContext().TraceID().String(), ShouldEqual, testTraceID)
+				So(server.Parent().SpanID().String(), ShouldEqual, testParentID)
+				So(server.Status().Code, ShouldEqual, codes.Error)
+			})
+
+			Convey("Then spans started by the handler are children of the server span", func() {
+				So(child.Parent().SpanID(), ShouldEqual, server.SpanContext().SpanID())
+			})
+		})
+	})
+}
+
+func TestEnd(t *testing.T) {
+	Convey("Given a recording tracer provider", t, func() {
+		ctx := context.Background()
+		recorder := setupSpanRecorder()
+
+		Convey("When a span is ended with an error", func() {
+			_, span := tracing.Start(ctx, "failing")
+			tracing.End(span, errors.New("mongo is down"))
+
+			Convey("Then the span has an error status and records the error", func() {
+				spans := recorder.Ended()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Status().Code, ShouldEqual, codes.Error)
+				So(spans[0].Status().Description, ShouldEqual, "mongo is down")
+				So(spans[0].Events(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a span is ended without an error", func() {
+			_, span := tracing.Start(ctx, "succeeding")
+			tracing.End(span, nil)
+
+			Convey("Then the span status is unset", func() {
+				So(recorder.Ended()[0].Status().Code, ShouldEqual, codes.Unset)
+			})
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// RouteTemplate returns the path template of the mux route matched by the request (e.g. /v1/policies/{id}),
+// or an empty string if the request has not been matched to a route
+func RouteTemplate(req *http.Request) string {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return ""
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return template
+}
+
+// StatusRecorder is a http.ResponseWriter that captures the status code written by a handler
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+// NewStatusRecorder creates a StatusRecorder, with the status defaulting to 200 OK if the handler does not write one
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+// WriteHeader captures the status code and writes it to the underlying ResponseWriter
+func (r *StatusRecorder) WriteHeader(status int) {
+	r.Status = status
+	r.ResponseWriter.WriteHeader(status)
+}